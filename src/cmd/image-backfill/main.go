@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/joho/godotenv"
+)
+
+// Isi data turunan gambar yang di-upload sebelum fiturnya ada: blurhash/LQIP/palette
+// dan hash untuk deteksi duplikat. Gambar lama yang belum terdaftar di image_assets
+// dimasukkan ke antrian image worker.
+func main() {
+	godotenv.Load()
+
+	batch := flag.Int("batch", 50, "jumlah asset yang diambil per query")
+	flag.Parse()
+
+	config.ConnectDB()
+	utils.InitStorage()
+
+	report, err := services.BackfillImageAssets(*batch, log.Printf)
+	if err != nil {
+		log.Fatalf("❌ Image backfill failed: %v", err)
+	}
+
+	log.Printf("✅ %d asset diproses, %d gambar lama masuk antrian image worker\n", report.Processed, report.Registered)
+	if report.Failed > 0 {
+		log.Fatalf("❌ %d gambar gagal diproses", report.Failed)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/migrations"
+)
+
+const usage = `Usage: migrate <command> [arg]
+
+Commands:
+  up [N]        Jalankan semua (atau N) migration berikutnya
+  down [N]      Rollback N migration terakhir (default 1)
+  goto V        Migrate naik/turun sampai version V
+  force V       Set version V tanpa menjalankan SQL (bersihkan status dirty, -1 = kosong)
+  status        Tampilkan daftar migration dan status-nya
+  version       Tampilkan version saat ini`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	config.ConnectDB()
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		log.Fatalf("❌ Failed to get sql.DB: %v", err)
+	}
+
+	ctx := context.Background()
+	runner, err := migrations.NewRunner(ctx, sqlDB)
+	if err != nil {
+		log.Fatalf("❌ Failed to start migration runner: %v", err)
+	}
+	defer runner.Close()
+
+	if err := run(ctx, runner, os.Args[1], os.Args[2:]); err != nil {
+		runner.Close()
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+func run(ctx context.Context, runner *migrations.Runner, command string, args []string) error {
+	switch command {
+	case "up":
+		n, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+		return runner.Up(ctx, int(n), log.Printf)
+
+	case "down":
+		n, err := intArg(args, 1)
+		if err != nil {
+			return err
+		}
+		return runner.Down(ctx, int(n), log.Printf)
+
+	case "goto":
+		if len(args) == 0 {
+			return fmt.Errorf("goto requires a version")
+		}
+		v, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+		return runner.Goto(ctx, v, log.Printf)
+
+	case "force":
+		if len(args) == 0 {
+			return fmt.Errorf("force requires a version")
+		}
+		v, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+		if err := runner.Force(ctx, v); err != nil {
+			return err
+		}
+		log.Printf("✅ Forced version %d\n", v)
+		return nil
+
+	case "status":
+		statuses, version, dirty, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			mark := "[ ]"
+			if s.Applied {
+				mark = "[x]"
+			}
+			fmt.Printf("%s %d_%s\n", mark, s.Version, s.Name)
+		}
+		fmt.Printf("\nCurrent version: %d (dirty: %t)\n", version, dirty)
+		return nil
+
+	case "version":
+		version, dirty, err := runner.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d (dirty: %t)\n", version, dirty)
+		return nil
+
+	default:
+		fmt.Println(usage)
+		return fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func intArg(args []string, fallback int64) (int64, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+	v, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", args[0])
+	}
+	return v, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/joho/godotenv"
+)
+
+// Cari (dan hapus) file di storage yang tidak direferensikan DB.
+// Default dry run, pakai -delete untuk benar-benar menghapus.
+func main() {
+	// Load .env dulu supaya default flag bisa dibaca dari env
+	godotenv.Load()
+
+	deleteOrphans := flag.Bool("delete", false, "hapus orphan (default hanya laporan)")
+	grace := flag.Duration("grace", services.GetStorageGCGrace(), "abaikan file yang lebih muda dari durasi ini")
+	flag.Parse()
+
+	config.ConnectDB()
+	utils.InitStorage()
+
+	if !*deleteOrphans {
+		log.Println("🔎 Dry run, tidak ada file yang dihapus (pakai -delete untuk menghapus)")
+	}
+
+	report, err := services.RunStorageGC(!*deleteOrphans, *grace, log.Printf)
+	if err != nil {
+		log.Fatalf("❌ Storage GC failed: %v", err)
+	}
+
+	if report.Failed > 0 {
+		log.Fatalf("❌ %d file gagal dihapus", report.Failed)
+	}
+}
@@ -1,10 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
@@ -18,7 +20,7 @@ func GetDetailAlbumBySlug(c *gin.Context) {
 		return
 	}
 
-	album, err := services.GetDetailAlbumBySlug(slug)
+	album, err := services.GetDetailAlbumBySlug(slug, c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to get detail album by slug")
 		return
@@ -42,27 +44,38 @@ func GetAlbumByCategorySlug(c *gin.Context) {
 	}
 
 	filter := c.Query("filter")
-	nextQuery := c.Query("next")
-	next, err := strconv.Atoi(nextQuery)
+
+	// next berisi cursor dari response sebelumnya, kosong (atau "0") = halaman pertama
+	next := c.Query("next")
+	if next == "0" {
+		next = ""
+	}
+
+	params, err := cursorParams(c, next, "10")
 	if err != nil {
-		utils.RespondError(c, http.StatusBadRequest, "invalid next query parameter")
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	albums, err := services.GetAlbumByCategorySlug(slug, next, 10, filter)
+	albums, err := services.GetAlbumByCategorySlug(slug, params, filter, c.GetString("locale"))
+	if isCursorError(err) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to get albums by category slug")
 		return
 	}
 
 	utils.RespondSuccess(c, gin.H{
-		"data": albums.Data,
-		"next": albums.NextValue,
+		"data":     albums.Data,
+		"next":     albums.Next,
+		"has_more": albums.HasMore,
 	})
 }
 
 func GetLatestAlbum(c *gin.Context) {
-	album, err := services.GetLatestAlbums()
+	album, err := services.GetLatestAlbums(c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to get latest album")
 		return
@@ -76,7 +89,19 @@ func GetLatestAlbum(c *gin.Context) {
 func GetAlbums(c *gin.Context) {
 	page := c.DefaultQuery("page", "1")
 	limit := c.DefaultQuery("limit", "10")
-	search := c.Query("search")
+
+	var filter services.AlbumListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
+	}
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		respondCursorList(c, cursor, func(params services.CursorParams) (interface{}, dto.CursorPageResponse, error) {
+			return services.GetAlbumsByCursor(params, filter, currentActor(c))
+		})
+		return
+	}
 
 	pageInt, err := strconv.Atoi(page)
 	if err != nil {
@@ -90,7 +115,7 @@ func GetAlbums(c *gin.Context) {
 		return
 	}
 
-	albums, total, err := services.GetAllAlbums(pageInt, limitInt, search)
+	albums, total, err := services.GetAllAlbums(pageInt, limitInt, filter, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "failed to get albums")
 		return
@@ -114,6 +139,35 @@ func CreateAlbum(c *gin.Context) {
 		return
 	}
 
+	// Cek hak akses sebelum upload supaya tidak ada file yatim
+	actor := currentActor(c)
+	if err := services.AuthorizeAlbumOwner(input.UserID, actor); err != nil {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+
+	publishAt, unpublishAt, err := services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	input.PublishAt = publishAt
+	input.UnpublishAt = unpublishAt
+
+	input.Translations, err = services.ParseTranslations(services.AuditEntityAlbum, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Visibility menentukan file di-upload ke storage public atau private
+	input.Visibility, err = services.ParseAlbumVisibility(input.Visibility)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	private := input.Visibility == services.AlbumVisibilityPrivate
+
 	// Upload images
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -122,6 +176,10 @@ func CreateAlbum(c *gin.Context) {
 	}
 
 	files := form.File["images"]
+	if rejectDuplicateUploads(c, nil, files) {
+		return
+	}
+
 	var imageUrls []string
 	for _, fileHeader := range files {
 		file, err := fileHeader.Open()
@@ -131,7 +189,7 @@ func CreateAlbum(c *gin.Context) {
 		}
 		defer file.Close()
 
-		url, err := utils.UploadToR2(file, fileHeader, "albums") // pakai bucket: luminor, prefix: albums
+		url, err := services.UploadAlbumImage(file, fileHeader, private) // prefix: albums
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "Failed to upload image")
 			return
@@ -150,7 +208,7 @@ func CreateAlbum(c *gin.Context) {
 		}
 		defer file.Close()
 
-		url, err := utils.UploadToR2(file, fileHeader, "albums") // thumbnail juga simpan ke prefix albums
+		url, err := services.UploadAlbumImage(file, fileHeader, private) // thumbnail juga simpan ke prefix albums
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "Failed to upload thumbnail")
 			return
@@ -164,7 +222,15 @@ func CreateAlbum(c *gin.Context) {
 		return
 	}
 
-	album, err := services.CreateAlbum(input)
+	album, err := services.CreateAlbum(input, actor)
+	if errors.Is(err, services.ErrForbidden) {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrMediaVisibilityMatch) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -177,12 +243,18 @@ func EditAlbum(c *gin.Context) {
 	id := c.Param("uuid")
 
 	// Cek apakah album dengan UUID tersebut ada
-	_, err := services.GetAlbumByUUID(id)
+	album, err := services.GetAlbumByUUID(id)
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to get album")
 		return
 	}
 
+	actor := currentActor(c)
+	if err := services.AuthorizeAlbum(album, actor); err != nil {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+
 	var input services.AlbumInput
 
 	// Ambil field dari PostForm
@@ -195,6 +267,18 @@ func EditAlbum(c *gin.Context) {
 	thumbnailUrl := c.PostForm("thumbnail_url")
 	mediaUrl := c.PostForm("media_url")
 
+	input.PublishAt, input.UnpublishAt, err = services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	input.Translations, err = services.ParseTranslations(services.AuditEntityAlbum, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Ambil file-file jika ada
 	form, err := c.MultipartForm()
 	if err != nil && err != http.ErrNotMultipart {
@@ -217,6 +301,10 @@ func EditAlbum(c *gin.Context) {
 	// Upload gambar baru jika ada
 	if form != nil && form.File != nil {
 		files := form.File["images"]
+		if rejectDuplicateUploads(c, &album, files) {
+			return
+		}
+
 		for _, fileHeader := range files {
 			file, err := fileHeader.Open()
 			if err != nil {
@@ -224,7 +312,7 @@ func EditAlbum(c *gin.Context) {
 				return
 			}
 
-			url, err := utils.UploadToR2(file, fileHeader, "albums")
+			url, err := services.UploadAlbumImage(file, fileHeader, services.IsPrivateAlbum(album))
 			file.Close()
 
 			if err != nil {
@@ -247,7 +335,7 @@ func EditAlbum(c *gin.Context) {
 		}
 		defer file.Close()
 
-		url, err := utils.UploadToR2(file, fileHeader, "albums")
+		url, err := services.UploadAlbumImage(file, fileHeader, services.IsPrivateAlbum(album))
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "Failed to upload thumbnail")
 			return
@@ -267,7 +355,15 @@ func EditAlbum(c *gin.Context) {
 	}
 
 	// Update album
-	updatedAlbum, err := services.UpdateAlbum(id, input)
+	updatedAlbum, err := services.UpdateAlbum(id, input, actor)
+	if errors.Is(err, services.ErrForbidden) {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrMediaVisibilityMatch) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -284,7 +380,11 @@ func DeleteAlbum(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteAlbum(id)
+	err := services.DeleteAlbum(id, currentActor(c))
+	if errors.Is(err, services.ErrForbidden) {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -314,6 +414,17 @@ func GetAlbumByUUID(c *gin.Context) {
 		return
 	}
 
+	if err := services.AuthorizeAlbum(album, currentActor(c)); err != nil {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+
+	translations, err := services.GetTranslations(services.AuditEntityAlbum, album.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	utils.RespondSuccess(c, gin.H{
 		"data": gin.H{
 			"uuid":         album.UUID,
@@ -322,10 +433,17 @@ func GetAlbumByUUID(c *gin.Context) {
 			"category_id":  album.Category.UUID,
 			"description":  album.Description,
 			"thumbnail":    album.Thumbnail,
-			"images":       album.Images,
+			"images":       services.AlbumMediaURLs(album),
+			"media":        services.MapAlbumMediaListToDTO(album.Media),
 			"user_id":      album.User.UUID,
 			"youtube_url":  album.YoutubeURL,
+			"visibility":   album.Visibility,
+			"watermark":    services.MapWatermarkOverrideToDTO(album.WatermarkMode, album.WatermarkPosition, album.WatermarkOpacity, album.WatermarkScale),
 			"is_published": album.IsPublished,
+			"publish_at":   album.PublishAt,
+			"unpublish_at": album.UnpublishAt,
+			"status":       services.ContentStatus(album.IsPublished, album.PublishAt, album.UnpublishAt),
+			"translations": translations,
 			"created_at":   album.CreatedAt,
 			"updated_at":   album.UpdatedAt,
 		},
@@ -341,7 +459,11 @@ func DeleteImageFromAlbum(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteImageFromAlbum(id, req.ImageURL)
+	err := services.DeleteImageFromAlbum(id, req.ImageURL, currentActor(c))
+	if errors.Is(err, services.ErrForbidden) {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -351,3 +473,27 @@ func DeleteImageFromAlbum(c *gin.Context) {
 		"message": "image deleted successfully",
 	})
 }
+
+// SetAlbumWatermark override watermark untuk satu album, gambarnya di-render ulang di background
+func SetAlbumWatermark(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.WatermarkOverrideInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	watermark, err := services.SetAlbumWatermark(id, input, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": watermark})
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func respondAlbumMediaError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrAlbumMediaNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrMediaVisibilityMatch):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func ReorderAlbumMedia(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.AlbumMediaOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	media, err := services.ReorderAlbumMedia(id, input, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": media})
+}
+
+func EditAlbumMedia(c *gin.Context) {
+	id := c.Param("uuid")
+	mediaID := c.Param("media_uuid")
+
+	var input services.AlbumMediaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	media, err := services.UpdateAlbumMedia(id, mediaID, input, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": media})
+}
+
+func SetAlbumCover(c *gin.Context) {
+	id := c.Param("uuid")
+	mediaID := c.Param("media_uuid")
+
+	media, err := services.SetAlbumCover(id, mediaID, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": media})
+}
+
+func CreateAlbumUploads(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.PresignUploadInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	uploads, err := services.CreatePresignedUploads(id, input, currentActor(c))
+	if errors.Is(err, services.ErrPresignNotSupported) {
+		utils.RespondError(c, http.StatusNotImplemented, err.Error())
+		return
+	}
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": uploads})
+}
+
+func ConfirmAlbumUploads(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.ConfirmUploadInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := services.ConfirmUploads(id, input, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": result})
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditEvents: filter opsional actor_uuid, action, entity_type, entity_uuid, from & to (RFC3339)
+func GetAuditEvents(c *gin.Context) {
+	page := c.DefaultQuery("page", "1")
+	limit := c.DefaultQuery("limit", "20")
+
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
+		return
+	}
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	var filter services.AuditEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
+	}
+
+	events, total, err := services.GetAuditEvents(pageInt, limitInt, filter)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get audit events")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  events,
+		"total": total,
+		"page":  pageInt,
+		"limit": limitInt,
+	})
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -28,7 +30,7 @@ func AdminLogin(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := services.Login(user.UUID, user.Role)
+	accessToken, refreshToken, err := services.Login(user, sessionMeta(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -50,36 +52,33 @@ func AdminRefreshToken(c *gin.Context) {
 		return
 	}
 
-	_, claims, err := utils.ValidateRefreshToken(refreshToken)
-	if err != nil {
-		utils.RespondError(c, http.StatusUnauthorized, "Missing refresh token in cookie")
+	user, newAccessToken, newRefreshToken, err := services.RotateSession(refreshToken, sessionMeta(c))
+	if errors.Is(err, services.ErrRefreshTokenReused) {
+		AdminClearTokenCookies(c)
+		utils.RespondError(c, http.StatusUnauthorized, "Refresh token has already been used, please login again")
 		return
 	}
-
-	newAccessToken, err := services.RefreshToken(refreshToken)
 	if err != nil {
 		utils.RespondError(c, http.StatusUnauthorized, "Invalid refresh token")
 		return
 	}
 
-	user, err := services.GetUserByUUID(claims.UserID)
-	if err != nil {
-		utils.RespondError(c, http.StatusInternalServerError, "Failed to get user")
-		return
-	}
-
-	AdminSetTokenCookies(c, newAccessToken, refreshToken, &user)
+	AdminSetTokenCookies(c, newAccessToken, newRefreshToken, user)
 
 	utils.RespondSuccess(c, gin.H{
 		"admin_access_token":  newAccessToken,
-		"admin_refresh_token": refreshToken,
+		"admin_refresh_token": newRefreshToken,
 	})
 }
 
 func AdminLogout(c *gin.Context) {
-	c.SetCookie("admin_access_token", "", -1, "/", "", false, true)
-	c.SetCookie("admin_refresh_token", "", -1, "/", "", false, true)
-	c.SetCookie("admin_user", "", -1, "/", "", false, true)
+	if refreshToken, err := c.Cookie("admin_refresh_token"); err == nil && refreshToken != "" {
+		if err := services.RevokeSessionByToken(refreshToken); err != nil {
+			fmt.Println("Warning: failed to revoke session on logout:", err)
+		}
+	}
+
+	AdminClearTokenCookies(c)
 
 	utils.RespondSuccess(c, gin.H{
 		"message": "Logged out successfully",
@@ -87,6 +86,19 @@ func AdminLogout(c *gin.Context) {
 
 }
 
+func AdminClearTokenCookies(c *gin.Context) {
+	c.SetCookie("admin_access_token", "", -1, "/", "", false, true)
+	c.SetCookie("admin_refresh_token", "", -1, "/", "", false, true)
+	c.SetCookie("admin_user", "", -1, "/", "", false, true)
+}
+
+func sessionMeta(c *gin.Context) services.SessionMeta {
+	return services.SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 func AdminVerifyToken(c *gin.Context) {
 	token, err := c.Cookie("admin_access_token")
 	if err != nil {
@@ -100,23 +112,77 @@ func AdminVerifyToken(c *gin.Context) {
 		return
 	}
 
+	permissions, err := services.GetRolePermissions(claims.Role)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to load permissions")
+		return
+	}
+
 	utils.RespondSuccess(c, gin.H{
-		"valid":   true,
-		"user_id": claims.UserID,
-		"role":    claims.Role,
-		"expires": claims.ExpiresAt.Time,
+		"valid":       true,
+		"user_id":     claims.UserID,
+		"role":        claims.Role,
+		"permissions": permissions,
+		"expires":     claims.ExpiresAt.Time,
 	})
 
 }
 
 func ForgotPassword(c *gin.Context) {
-	// TODO: implementasi kirim email reset password
-	utils.RespondError(c, http.StatusNotImplemented, "Forgot password not implemented")
+	var req struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid forgot password payload")
+		return
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := services.RequestPasswordReset(req.Email, c.ClientIP())
+	if errors.Is(err, services.ErrResetRateLimited) {
+		utils.RespondError(c, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to process forgot password request")
+		return
+	}
+
+	// Respon selalu sama supaya email terdaftar tidak bisa ditebak
+	utils.RespondSuccess(c, gin.H{
+		"message": "If the email is registered, a reset link has been sent",
+	})
 }
 
 func AdminResetPassword(c *gin.Context) {
-	// TODO: implementasi set password baru (dengan token reset)
-	utils.RespondError(c, http.StatusNotImplemented, "Reset password not implemented")
+	var input services.ResetPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid reset password payload")
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := services.ResetPassword(input)
+	if errors.Is(err, services.ErrResetTokenInvalid) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "Password has been reset successfully",
+	})
 }
 
 func AdminSetTokenCookies(c *gin.Context, accessToken string, refreshToken string, user *models.User) {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
@@ -14,7 +15,7 @@ import (
 var validate = validator.New()
 
 func GetPublishedCategories(c *gin.Context) {
-	categories, err := services.GetPublishedCategories()
+	categories, err := services.GetPublishedCategories(c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "failed to get faqs")
 		return
@@ -30,6 +31,13 @@ func GetCategories(c *gin.Context) {
 	limit := c.DefaultQuery("limit", "10")
 	search := c.Query("search")
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		respondCursorList(c, cursor, func(params services.CursorParams) (interface{}, dto.CursorPageResponse, error) {
+			return services.GetCategoriesByCursor(params, search)
+		})
+		return
+	}
+
 	pageInt, err := strconv.Atoi(page)
 	if err != nil {
 		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
@@ -72,6 +80,20 @@ func CreateCategory(c *gin.Context) {
 		return
 	}
 
+	publishAt, unpublishAt, err := services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	input.PublishAt = publishAt
+	input.UnpublishAt = unpublishAt
+
+	input.Translations, err = services.ParseTranslations(services.AuditEntityCategory, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if fileHeader, err := c.FormFile("image"); err == nil && fileHeader != nil {
 		file, err := fileHeader.Open()
 		if err != nil {
@@ -80,7 +102,7 @@ func CreateCategory(c *gin.Context) {
 		}
 		defer file.Close()
 
-		url, err := utils.UploadToR2(file, fileHeader, "categories") // thumbnail juga simpan ke prefix albums
+		url, err := services.UploadImage(file, fileHeader, "categories") // thumbnail juga simpan ke prefix albums
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "Failed to upload thumbnail")
 			return
@@ -88,7 +110,7 @@ func CreateCategory(c *gin.Context) {
 		input.PhotoUrl = url
 	}
 
-	category, err := services.CreateCategory(input)
+	category, err := services.CreateCategory(input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -117,7 +139,19 @@ func EditCategory(c *gin.Context) {
 		return
 	}
 
-	updatedCategory, err := services.UpdateCategory(id, input)
+	input.PublishAt, input.UnpublishAt, err = services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	input.Translations, err = services.ParseTranslations(services.AuditEntityCategory, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedCategory, err := services.UpdateCategory(id, input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -134,7 +168,7 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteCategory(id)
+	err := services.DeleteCategory(id, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -164,6 +198,12 @@ func GetCategoryByUUID(c *gin.Context) {
 		return
 	}
 
+	translations, err := services.GetTranslations(services.AuditEntityCategory, category.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	utils.RespondSuccess(c, gin.H{
 		"data": gin.H{
 			"uuid":         category.UUID,
@@ -173,6 +213,11 @@ func GetCategoryByUUID(c *gin.Context) {
 			"photo_url":    category.PhotoURL,
 			"youtube_url":  category.YoutubeURL,
 			"is_published": category.IsPublished,
+			"publish_at":   category.PublishAt,
+			"unpublish_at": category.UnpublishAt,
+			"status":       services.ContentStatus(category.IsPublished, category.PublishAt, category.UnpublishAt),
+			"watermark":    services.MapWatermarkOverrideToDTO(category.WatermarkMode, category.WatermarkPosition, category.WatermarkOpacity, category.WatermarkScale),
+			"translations": translations,
 			"created_at":   category.CreatedAt,
 			"updated_at":   category.UpdatedAt,
 		},
@@ -187,7 +232,7 @@ func DeleteImageCategory(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteImageCategory(id)
+	err := services.DeleteImageCategory(id, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -218,7 +263,7 @@ func GetCategoryBySlug(c *gin.Context) {
 		return
 	}
 
-	category, err := services.GetCategoryBySlug(slug)
+	category, err := services.GetCategoryBySlug(slug, c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -240,3 +285,27 @@ func GetCategoryBySlug(c *gin.Context) {
 		},
 	})
 }
+
+// SetCategoryWatermark override watermark untuk semua album di kategori ini
+func SetCategoryWatermark(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.WatermarkOverrideInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	watermark, err := services.SetCategoryWatermark(id, input, currentActor(c))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": watermark})
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func respondDownloadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrDownloadNotFound), errors.Is(err, services.ErrDownloadLinkNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrDownloadLimitReached):
+		utils.RespondError(c, http.StatusGone, err.Error())
+	case errors.Is(err, services.ErrDownloadRateLimited):
+		utils.RespondError(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, services.ErrDownloadSession):
+		utils.RespondError(c, http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, services.ErrDownloadNotStarted),
+		errors.Is(err, services.ErrDownloadEmpty),
+		errors.Is(err, services.ErrDownloadLinkExpiry):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, utils.ErrPrivateStorageDisabled):
+		utils.RespondError(c, http.StatusNotImplemented, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func GetAlbumDownloadLinks(c *gin.Context) {
+	links, err := services.GetAlbumDownloadLinks(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": links})
+}
+
+func CreateAlbumDownloadLink(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.AlbumDownloadLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := services.CreateAlbumDownloadLink(id, input, currentActor(c))
+	if err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": link})
+}
+
+func RevokeAlbumDownloadLink(c *gin.Context) {
+	if err := services.RevokeAlbumDownloadLink(c.Param("uuid"), c.Param("link_uuid"), currentActor(c)); err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "download link revoked successfully",
+	})
+}
+
+// DownloadPublicAlbum ZIP album yang sedang tayang, tanpa link
+func DownloadPublicAlbum(c *gin.Context) {
+	download, err := services.DownloadPublicAlbum(c.Request.Context(), c.Param("slug"), c.ClientIP())
+	if err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+
+	serveAlbumDownload(c, download)
+}
+
+// downloadSessionCookie sesi download yang dihitung, wajib dibawa request resume
+const downloadSessionCookie = "download_session"
+
+// DownloadAlbumByLink ZIP album lewat link download (album belum tayang / private)
+func DownloadAlbumByLink(c *gin.Context) {
+	session, _ := c.Cookie(downloadSessionCookie)
+	download, err := services.DownloadAlbumByLink(c.Request.Context(), c.Param("token"), isDownloadStart(c), session, c.ClientIP())
+	if err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+
+	if download.Session != "" {
+		// Path per link supaya sesi link lain tidak tertimpa
+		secure := utils.IsProduction()
+		http.SetCookie(c.Writer, &http.Cookie{
+			Name:     downloadSessionCookie,
+			Value:    download.Session,
+			Path:     c.Request.URL.Path,
+			HttpOnly: true,
+			Secure:   secure,
+			SameSite: http.SameSiteLaxMode,
+			Expires:  download.SessionExpiresAt,
+		})
+	}
+
+	serveAlbumDownload(c, download)
+}
+
+// isDownloadStart: GET tanpa Range atau Range dari byte 0. HEAD dan resume tidak dihitung sebagai download baru.
+func isDownloadStart(c *gin.Context) bool {
+	if c.Request.Method != http.MethodGet {
+		return false
+	}
+	rangeHeader := strings.TrimSpace(c.GetHeader("Range"))
+	return rangeHeader == "" || strings.HasPrefix(rangeHeader, "bytes=0-")
+}
+
+// serveAlbumDownload mengirim ZIP langsung dari storage. Range, If-Range dan HEAD ditangani http.ServeContent.
+func serveAlbumDownload(c *gin.Context, download services.AlbumDownload) {
+	defer download.Stream.Close()
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, strings.ReplaceAll(download.Filename, `"`, "")))
+	c.Header("ETag", download.ETag)
+	c.Header("Cache-Control", "private, no-store")
+	http.ServeContent(c.Writer, c.Request, download.Filename, download.Modified, download.Stream)
+}
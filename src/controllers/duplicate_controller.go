@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// rejectDuplicateUploads menolak upload yang isinya sudah ada di album, kecuali allow_duplicates=true.
+// Return true kalau response error sudah dikirim.
+func rejectDuplicateUploads(c *gin.Context, album *models.Album, files []*multipart.FileHeader) bool {
+	if len(files) == 0 || c.PostForm("allow_duplicates") == "true" {
+		return false
+	}
+
+	err := services.CheckAlbumDuplicates(album, files)
+	if errors.Is(err, services.ErrDuplicateImage) {
+		utils.RespondError(c, http.StatusConflict, err.Error())
+		return true
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return true
+	}
+	return false
+}
+
+func GetAlbumDuplicates(c *gin.Context) {
+	threshold, err := services.ParseDuplicateThreshold(c.Query("threshold"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	duplicates, err := services.GetAlbumDuplicates(c.Param("uuid"), threshold, currentActor(c))
+	if err != nil {
+		respondAlbumMediaError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": duplicates})
+}
+
+// GetDuplicateReport laporan duplikat seluruh library (admin)
+func GetDuplicateReport(c *gin.Context) {
+	threshold, err := services.ParseDuplicateThreshold(c.Query("threshold"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := services.GetDuplicateReport(threshold, currentActor(c))
+	if errors.Is(err, services.ErrForbidden) {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": report})
+}
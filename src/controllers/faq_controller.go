@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,13 @@ func GetFaqs(c *gin.Context) {
 	limit := c.DefaultQuery("limit", "10")
 	search := c.Query("search")
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		respondCursorList(c, cursor, func(params services.CursorParams) (interface{}, dto.CursorPageResponse, error) {
+			return services.GetFaqsByCursor(params, search)
+		})
+		return
+	}
+
 	pageInt, err := strconv.Atoi(page)
 	if err != nil {
 		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
@@ -42,7 +50,7 @@ func GetFaqs(c *gin.Context) {
 }
 
 func GetPublishedFaqs(c *gin.Context) {
-	faqs, err := services.GetPublishedFaqs()
+	faqs, err := services.GetPublishedFaqs(c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "failed to get faqs")
 		return
@@ -68,7 +76,17 @@ func CreateFaq(c *gin.Context) {
 		return
 	}
 
-	faq, err := services.CreateFaq(input)
+	if err := services.ValidatePublishWindow(input.PublishAt, input.UnpublishAt); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := services.ValidateTranslations(services.AuditEntityFaq, input.Translations); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	faq, err := services.CreateFaq(input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -97,7 +115,17 @@ func EditFaq(c *gin.Context) {
 		return
 	}
 
-	updatedFaq, err := services.UpdateFaq(id, input)
+	if err := services.ValidatePublishWindow(input.PublishAt, input.UnpublishAt); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := services.ValidateTranslations(services.AuditEntityFaq, input.Translations); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedFaq, err := services.UpdateFaq(id, input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -114,7 +142,7 @@ func DeleteFaq(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteFaq(id)
+	err := services.DeleteFaq(id, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -144,6 +172,12 @@ func GetFaqByUUID(c *gin.Context) {
 		return
 	}
 
+	translations, err := services.GetTranslations(services.AuditEntityFaq, faq.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	utils.RespondSuccess(c, gin.H{
 		"data": gin.H{
 			"uuid":         faq.UUID,
@@ -152,6 +186,10 @@ func GetFaqByUUID(c *gin.Context) {
 			"question_en":  faq.QuestionEn,
 			"question_id":  faq.QuestionID,
 			"is_published": faq.IsPublished,
+			"publish_at":   faq.PublishAt,
+			"unpublish_at": faq.UnpublishAt,
+			"status":       services.ContentStatus(faq.IsPublished, faq.PublishAt, faq.UnpublishAt),
+			"translations": translations,
 			"created_at":   faq.CreatedAt,
 			"updated_at":   faq.UpdatedAt,
 		},
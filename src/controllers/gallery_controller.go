@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func respondGalleryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrGalleryNotFound), errors.Is(err, services.ErrGalleryLinkNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrGalleryPasswordRequired), errors.Is(err, services.ErrGalleryPasswordInvalid):
+		utils.RespondError(c, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrGalleryRateLimited):
+		utils.RespondError(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, services.ErrAlbumNotPrivate),
+		errors.Is(err, services.ErrGalleryLinkExpiry),
+		errors.Is(err, services.ErrInvalidAlbumVisibility):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, utils.ErrPrivateStorageDisabled):
+		utils.RespondError(c, http.StatusNotImplemented, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// SetAlbumVisibility memindahkan album ke gallery private atau sebaliknya
+func SetAlbumVisibility(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.AlbumVisibilityInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	album, err := services.SetAlbumVisibility(id, input, currentActor(c))
+	if err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": album})
+}
+
+func GetGalleryLinks(c *gin.Context) {
+	links, err := services.GetGalleryLinks(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": links})
+}
+
+func CreateGalleryLink(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.GalleryLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := services.CreateGalleryLink(id, input, currentActor(c))
+	if err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": link})
+}
+
+func RevokeGalleryLink(c *gin.Context) {
+	if err := services.RevokeGalleryLink(c.Param("uuid"), c.Param("link_uuid"), currentActor(c)); err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "gallery link revoked successfully",
+	})
+}
+
+// OpenGallery endpoint publik untuk client, token akses password dikirim lewat header X-Gallery-Access
+func OpenGallery(c *gin.Context) {
+	gallery, err := services.OpenGallery(c.Param("token"), c.GetHeader("X-Gallery-Access"), c.GetString("locale"))
+	if err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	// Signed URL tidak boleh di-cache lebih lama dari masa berlakunya
+	c.Header("Cache-Control", "private, no-store")
+	utils.RespondSuccess(c, gin.H{"data": gallery})
+}
+
+func UnlockGallery(c *gin.Context) {
+	var input services.GalleryUnlockInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	access, err := services.UnlockGallery(c.Param("token"), input)
+	if err != nil {
+		respondGalleryError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": access})
+}
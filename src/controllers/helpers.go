@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const maxCursorLimit = 100
+
+// currentActor membaca user yang login dari context (di-set oleh middleware auth)
+func currentActor(c *gin.Context) services.Actor {
+	return services.Actor{
+		UserUUID:    c.GetString("user_id"),
+		Role:        c.GetString("role"),
+		Permissions: c.GetStringSlice("permissions"),
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+}
+
+// cursorParams membaca sort dan limit untuk keyset pagination
+func cursorParams(c *gin.Context, cursor string, defaultLimit string) (services.CursorParams, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", defaultLimit))
+	if err != nil || limit < 1 || limit > maxCursorLimit {
+		return services.CursorParams{}, errors.New("Invalid limit parameter")
+	}
+
+	return services.CursorParams{
+		Cursor: cursor,
+		Sort:   c.Query("sort"),
+		Limit:  limit,
+	}, nil
+}
+
+func isCursorError(err error) bool {
+	return errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, services.ErrInvalidSort)
+}
+
+// respondCursorList dipakai endpoint admin /lists kalau query "cursor" dikirim (kosong = halaman pertama)
+func respondCursorList(c *gin.Context, cursor string, fetch func(params services.CursorParams) (interface{}, dto.CursorPageResponse, error)) {
+	params, err := cursorParams(c, cursor, "10")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, page, err := fetch(params)
+	if isCursorError(err) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get data")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":     data,
+		"next":     page.Next,
+		"has_more": page.HasMore,
+		"limit":    params.Limit,
+	})
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func respondLeadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrLeadNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidLeadStatus), errors.Is(err, services.ErrLeadAssigneeNotFound):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// SubmitInquiry endpoint publik untuk form kontak website
+func SubmitInquiry(c *gin.Context) {
+	var input services.InquiryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid inquiry payload")
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := services.SubmitInquiry(input, c.ClientIP(), c.Request.UserAgent())
+	switch {
+	case errors.Is(err, services.ErrInquiryRateLimited):
+		utils.RespondError(c, http.StatusTooManyRequests, err.Error())
+		return
+	case errors.Is(err, services.ErrInvalidEventDate),
+		errors.Is(err, services.ErrInquiryCategory),
+		errors.Is(err, services.ErrInquiryPhotographer):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		utils.RespondError(c, http.StatusInternalServerError, "Failed to submit inquiry")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "Thank you, we will contact you soon",
+	})
+}
+
+// GetLeads: filter opsional status, assigned_uuid dan search (nama, email, telepon)
+func GetLeads(c *gin.Context) {
+	page := c.DefaultQuery("page", "1")
+	limit := c.DefaultQuery("limit", "10")
+
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
+		return
+	}
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	var filter services.LeadFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
+	}
+
+	leads, total, err := services.GetLeads(pageInt, limitInt, filter, currentActor(c))
+	if err != nil {
+		respondLeadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  leads,
+		"total": total,
+		"page":  pageInt,
+		"limit": limitInt,
+	})
+}
+
+func GetLeadByUUID(c *gin.Context) {
+	lead, err := services.GetLeadByUUID(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondLeadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": lead})
+}
+
+func UpdateLeadStatus(c *gin.Context) {
+	var input services.LeadStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lead, err := services.UpdateLeadStatus(c.Param("uuid"), input, currentActor(c))
+	if err != nil {
+		respondLeadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": lead})
+}
+
+func AssignLead(c *gin.Context) {
+	var input services.LeadAssignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lead, err := services.AssignLead(c.Param("uuid"), input, currentActor(c))
+	if err != nil {
+		respondLeadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": lead})
+}
+
+func AddLeadNote(c *gin.Context) {
+	var input services.LeadNoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	note, err := services.AddLeadNote(c.Param("uuid"), input, currentActor(c))
+	if err != nil {
+		respondLeadError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": note})
+}
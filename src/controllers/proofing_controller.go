@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func respondProofingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrProofingNotFound),
+		errors.Is(err, services.ErrProofingLinkNotFound),
+		errors.Is(err, services.ErrProofingListNotFound),
+		errors.Is(err, services.ErrProofingMediaNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrProofingSubmitted),
+		errors.Is(err, services.ErrProofingListExists),
+		errors.Is(err, services.ErrProofingLimitReached):
+		utils.RespondError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrProofingListLimit),
+		errors.Is(err, services.ErrProofingEmpty),
+		errors.Is(err, services.ErrGalleryLinkExpiry):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrProofingRateLimited):
+		utils.RespondError(c, http.StatusTooManyRequests, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func GetProofingLinks(c *gin.Context) {
+	links, err := services.GetProofingLinks(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": links})
+}
+
+func CreateProofingLink(c *gin.Context) {
+	var input services.ProofingLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := services.CreateProofingLink(c.Param("uuid"), input, currentActor(c))
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": link})
+}
+
+func GetProofingLink(c *gin.Context) {
+	link, err := services.GetProofingLink(c.Param("uuid"), c.Param("link_uuid"), currentActor(c))
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": link})
+}
+
+// ExportProofingSelection: format=txt (satu nama file per baris) atau lightroom
+// (dipisah koma, bisa langsung di-paste ke Library Filter Lightroom)
+func ExportProofingSelection(c *gin.Context) {
+	format := c.DefaultQuery("format", "txt")
+	if format != "txt" && format != "lightroom" {
+		utils.RespondError(c, http.StatusBadRequest, "format must be txt or lightroom")
+		return
+	}
+
+	filenames, err := services.ExportProofingSelection(c.Param("uuid"), c.Param("link_uuid"), c.Query("list"), currentActor(c))
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	separator := "\n"
+	if format == "lightroom" {
+		separator = ", "
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="selection-%s.txt"`, c.Param("link_uuid")))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(strings.Join(filenames, separator)))
+}
+
+func RevokeProofingLink(c *gin.Context) {
+	if err := services.RevokeProofingLink(c.Param("uuid"), c.Param("link_uuid"), currentActor(c)); err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "proofing link revoked successfully",
+	})
+}
+
+// ReopenProofingLink membuka kembali pilihan yang sudah di-submit client
+func ReopenProofingLink(c *gin.Context) {
+	if err := services.ReopenProofingLink(c.Param("uuid"), c.Param("link_uuid"), currentActor(c)); err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "proofing reopened successfully",
+	})
+}
+
+// OpenProofing endpoint publik untuk client, akses lewat token link proofing
+func OpenProofing(c *gin.Context) {
+	proofing, err := services.OpenProofing(c.Param("token"), c.GetString("locale"))
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	utils.RespondSuccess(c, gin.H{"data": proofing})
+}
+
+func CreateProofingList(c *gin.Context) {
+	var input services.ProofingListInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := services.CreateProofingList(c.Param("token"), input)
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": list})
+}
+
+func DeleteProofingList(c *gin.Context) {
+	if err := services.DeleteProofingList(c.Param("token"), c.Param("list_uuid")); err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "selection list deleted successfully",
+	})
+}
+
+func SetProofingItem(c *gin.Context) {
+	var input services.ProofingItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := services.SetProofingItem(c.Param("token"), c.Param("list_uuid"), input)
+	if err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": item})
+}
+
+func RemoveProofingItem(c *gin.Context) {
+	if err := services.RemoveProofingItem(c.Param("token"), c.Param("list_uuid"), c.Param("media_uuid")); err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "photo removed from selection",
+	})
+}
+
+func SubmitProofing(c *gin.Context) {
+	if err := services.SubmitProofing(c.Param("token")); err != nil {
+		respondProofingError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "Thank you, your selection has been submitted",
+	})
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func GetRoles(c *gin.Context) {
+	roles, err := services.GetRoles()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get roles")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data": roles,
+	})
+}
+
+func GetPermissions(c *gin.Context) {
+	permissions, err := services.GetPermissions()
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get permissions")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data": permissions,
+	})
+}
+
+func CreateRole(c *gin.Context) {
+	var input services.RoleInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input format")
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	role, err := services.CreateRole(input)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": role})
+}
+
+func EditRole(c *gin.Context) {
+	id := c.Param("uuid")
+
+	var input services.RoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input format")
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	role, err := services.UpdateRole(id, input)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": role})
+}
+
+func DeleteRole(c *gin.Context) {
+	id := c.Param("uuid")
+
+	if id == "" {
+		utils.RespondError(c, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	if err := services.DeleteRole(id); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "role deleted successfully",
+	})
+}
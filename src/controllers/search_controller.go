@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const maxSearchLimit = 50
+
+// Search: q wajib, type opsional (album,category,user,faq dipisah koma)
+func Search(c *gin.Context) {
+	page := c.DefaultQuery("page", "1")
+	limit := c.DefaultQuery("limit", "10")
+
+	pageInt, err := strconv.Atoi(page)
+	if err != nil || pageInt < 1 {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
+		return
+	}
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil || limitInt < 1 || limitInt > maxSearchLimit {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	input := services.SearchInput{
+		Query: strings.TrimSpace(c.Query("q")),
+		Lang:  c.GetString("locale"),
+	}
+	if input.Query == "" {
+		utils.RespondError(c, http.StatusBadRequest, "q is required")
+		return
+	}
+	if types := c.Query("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			input.Types = append(input.Types, strings.TrimSpace(t))
+		}
+	}
+
+	results, total, err := services.Search(input, pageInt, limitInt)
+	if errors.Is(err, services.ErrInvalidSearchType) {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to search")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  results,
+		"total": total,
+		"page":  pageInt,
+		"limit": limitInt,
+	})
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Sitemap dan feed boleh di-cache crawler/CDN, konten baru cukup muncul dalam satu jam
+const seoCacheControl = "public, max-age=3600"
+
+func respondXML(c *gin.Context, contentType string, data interface{}) {
+	body, err := xml.Marshal(data)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to encode xml")
+		return
+	}
+
+	c.Header("Cache-Control", seoCacheControl)
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
+
+func respondSeoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSitemapNotFound), errors.Is(err, services.ErrFeedNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidFeedFormat):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func GetSitemapIndex(c *gin.Context) {
+	index, err := services.GetSitemapIndex()
+	if err != nil {
+		respondSeoError(c, err)
+		return
+	}
+
+	respondXML(c, "application/xml; charset=utf-8", index)
+}
+
+// GetSitemap sitemap anak, mis. /sitemaps/categories.xml atau /sitemaps/albums-1.xml
+func GetSitemap(c *gin.Context) {
+	name, ok := strings.CutSuffix(c.Param("name"), ".xml")
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, services.ErrSitemapNotFound.Error())
+		return
+	}
+
+	sitemap, err := services.GetSitemap(name)
+	if err != nil {
+		respondSeoError(c, err)
+		return
+	}
+
+	respondXML(c, "application/xml; charset=utf-8", sitemap)
+}
+
+func GetRobotsTxt(c *gin.Context) {
+	c.Header("Cache-Control", seoCacheControl)
+	c.String(http.StatusOK, services.RobotsTxt())
+}
+
+func respondAlbumFeed(c *gin.Context, filter services.AlbumFeedFilter) {
+	format := c.Param("format")
+	feed, err := services.GetAlbumFeed(filter, format, c.GetString("locale"))
+	if err != nil {
+		respondSeoError(c, err)
+		return
+	}
+
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == services.FeedFormatAtom {
+		contentType = "application/atom+xml; charset=utf-8"
+	}
+	respondXML(c, contentType, feed)
+}
+
+// GetAlbumFeed feed album baru, :format = rss | atom
+func GetAlbumFeed(c *gin.Context) {
+	respondAlbumFeed(c, services.AlbumFeedFilter{})
+}
+
+func GetCategoryAlbumFeed(c *gin.Context) {
+	respondAlbumFeed(c, services.AlbumFeedFilter{CategorySlug: c.Param("slug")})
+}
+
+func GetPhotographerAlbumFeed(c *gin.Context) {
+	respondAlbumFeed(c, services.AlbumFeedFilter{PhotographerSlug: c.Param("slug")})
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func GetUserSessions(c *gin.Context) {
+	id := c.Param("uuid")
+
+	if id == "" {
+		utils.RespondError(c, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	currentToken, _ := c.Cookie("admin_refresh_token")
+
+	sessions, err := services.GetActiveSessions(id, currentToken)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data": sessions,
+	})
+}
+
+func RevokeUserSession(c *gin.Context) {
+	id := c.Param("uuid")
+	sessionID := c.Param("session_uuid")
+
+	if id == "" || sessionID == "" {
+		utils.RespondError(c, http.StatusBadRequest, "id and session id are required")
+		return
+	}
+
+	if err := services.RevokeUserSession(id, sessionID); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "session revoked successfully",
+	})
+}
+
+func RevokeAllUserSessions(c *gin.Context) {
+	id := c.Param("uuid")
+
+	if id == "" {
+		utils.RespondError(c, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	if err := services.RevokeAllUserSessions(id); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "all sessions revoked successfully",
+	})
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ServeStorageObject menyajikan file dari local storage (STORAGE_DRIVER=local)
+func ServeStorageObject(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	utils.StreamFromStorage(c, key, 24*time.Hour)
+}
+
+// UploadStorageObject menerima PUT dari presigned URL local storage,
+// perilakunya dibuat mirip PUT ke R2 supaya frontend tidak perlu membedakan
+func UploadStorageObject(c *gin.Context) {
+	uploadToLocalStorage(c, utils.Store)
+}
+
+// ServePrivateStorageObject menyajikan file local private storage, hanya dengan signed URL
+func ServePrivateStorageObject(c *gin.Context) {
+	store, ok := utils.PrivateStore.(*utils.LocalStorage)
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "not found")
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := store.VerifySignedGet(key, c.Query("expires"), c.Query("signature")); err != nil {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+
+	utils.StreamObject(c, store, key, "private, no-store")
+}
+
+// UploadPrivateStorageObject menerima PUT dari presigned URL untuk album private
+func UploadPrivateStorageObject(c *gin.Context) {
+	uploadToLocalStorage(c, utils.PrivateStore)
+}
+
+func uploadToLocalStorage(c *gin.Context, target utils.Storage) {
+	store, ok := target.(*utils.LocalStorage)
+	if !ok {
+		utils.RespondError(c, http.StatusNotFound, "not found")
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	contentType := c.GetHeader("Content-Type")
+
+	if err := store.VerifyPresignedPut(key, contentType, c.Query("expires"), c.Query("signature")); err != nil {
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+
+	maxSize := utils.GetUploadMaxSize()
+	if c.Request.ContentLength > maxSize {
+		utils.RespondError(c, http.StatusRequestEntityTooLarge, "file is too large")
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	if err := store.Put(c.Request.Context(), key, body, c.Request.ContentLength, contentType); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.RespondError(c, http.StatusRequestEntityTooLarge, "file is too large")
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, "failed to store file")
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
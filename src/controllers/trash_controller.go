@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/charis16/luminor-golang-be/src/services"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// trashPagination membaca page & limit, false kalau parameter tidak valid (response sudah dikirim)
+func trashPagination(c *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
+		return 0, 0, false
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid limit parameter")
+		return 0, 0, false
+	}
+
+	return page, limit, true
+}
+
+func respondRestoreError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotInTrash):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrForbidden):
+		utils.RespondError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrRestoreConflict):
+		utils.RespondError(c, http.StatusConflict, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func GetTrashedAlbums(c *gin.Context) {
+	page, limit, ok := trashPagination(c)
+	if !ok {
+		return
+	}
+
+	albums, total, err := services.GetTrashedAlbums(page, limit, currentActor(c))
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get trashed albums")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  albums,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func RestoreAlbum(c *gin.Context) {
+	if err := services.RestoreAlbum(c.Param("uuid"), currentActor(c)); err != nil {
+		respondRestoreError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "album restored successfully",
+	})
+}
+
+func GetTrashedCategories(c *gin.Context) {
+	page, limit, ok := trashPagination(c)
+	if !ok {
+		return
+	}
+
+	categories, total, err := services.GetTrashedCategories(page, limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get trashed categories")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  categories,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func RestoreCategory(c *gin.Context) {
+	restored, err := services.RestoreCategory(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondRestoreError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message":         "category restored successfully",
+		"albums_restored": restored,
+	})
+}
+
+func GetTrashedUsers(c *gin.Context) {
+	page, limit, ok := trashPagination(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := services.GetTrashedUsers(page, limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get trashed users")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  users,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func RestoreUser(c *gin.Context) {
+	restored, err := services.RestoreUser(c.Param("uuid"), currentActor(c))
+	if err != nil {
+		respondRestoreError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message":         "user restored successfully",
+		"albums_restored": restored,
+	})
+}
+
+func GetTrashedFaqs(c *gin.Context) {
+	page, limit, ok := trashPagination(c)
+	if !ok {
+		return
+	}
+
+	faqs, total, err := services.GetTrashedFaqs(page, limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, "failed to get trashed faqs")
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"data":  faqs,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+func RestoreFaq(c *gin.Context) {
+	if err := services.RestoreFaq(c.Param("uuid"), currentActor(c)); err != nil {
+		respondRestoreError(c, err)
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{
+		"message": "faq restored successfully",
+	})
+}
@@ -1,15 +1,26 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// respondUserSaveError: email yang bentrok (termasuk milik user di trash) = 409
+func respondUserSaveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserEmailExists), errors.Is(err, services.ErrUserEmailInTrash):
+		utils.RespondError(c, http.StatusConflict, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
 func GetUserPortfolioBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
@@ -17,7 +28,7 @@ func GetUserPortfolioBySlug(c *gin.Context) {
 		return
 	}
 
-	user, err := services.GetUserPortfolioBySlug(slug)
+	user, err := services.GetUserPortfolioBySlug(slug, c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "Failed to get user portfolio by slug")
 		return
@@ -61,6 +72,13 @@ func GetUsers(c *gin.Context) {
 	limit := c.DefaultQuery("limit", "10")
 	search := c.Query("search")
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		respondCursorList(c, cursor, func(params services.CursorParams) (interface{}, dto.CursorPageResponse, error) {
+			return services.GetUsersByCursor(params, search)
+		})
+		return
+	}
+
 	pageInt, err := strconv.Atoi(page)
 	if err != nil {
 		utils.RespondError(c, http.StatusBadRequest, "Invalid page parameter")
@@ -109,6 +127,18 @@ func CreateUser(c *gin.Context) {
 	isPublished := c.PostForm("is_published")
 	canLogin := c.PostForm("can_login")
 
+	publishAt, unpublishAt, err := services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	translations, err := services.ParseTranslations(services.AuditEntityUser, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	var photoURL string
 
 	fileHeader, err := c.FormFile("photo")
@@ -120,7 +150,7 @@ func CreateUser(c *gin.Context) {
 		}
 		defer file.Close()
 
-		photoURL, err = utils.UploadToR2(file, fileHeader, "users")
+		photoURL, err = services.UploadImage(file, fileHeader, "users")
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
 			return
@@ -141,12 +171,15 @@ func CreateUser(c *gin.Context) {
 		PhoneNumber:  phoneNumber,
 		IsPublished:  isPublished == "true",
 		CanLogin:     canLogin == "true",
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+		Translations: translations,
 		PhotoURL:     photoURL,
 	}
 
-	user, err := services.CreateUser(input)
+	user, err := services.CreateUser(input, currentActor(c))
 	if err != nil {
-		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		respondUserSaveError(c, err)
 		return
 	}
 
@@ -169,6 +202,18 @@ func EditUser(c *gin.Context) {
 	isPublished := c.PostForm("is_published")
 	canLogin := c.PostForm("can_login")
 
+	publishAt, unpublishAt, err := services.ParsePublishWindow(c.PostForm("publish_at"), c.PostForm("unpublish_at"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	translations, err := services.ParseTranslations(services.AuditEntityUser, c.PostForm("translations"))
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	user, err := services.GetUserByUUID(id)
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "failed to get user")
@@ -192,18 +237,11 @@ func EditUser(c *gin.Context) {
 		}
 		defer file.Close()
 
-		photoURL, err = utils.UploadToR2(file, fileHeader, "users")
+		photoURL, err = services.UploadImage(file, fileHeader, "users")
 		if err != nil {
 			utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
 			return
 		}
-
-		if user.Photo != "" {
-			err = utils.DeleteFromR2("users", user.Photo)
-			if err != nil {
-				fmt.Println("Warning: failed to delete old photo:", err)
-			}
-		}
 	} else {
 		// No new file provided, use the existing photo URL
 		photoURL = user.Photo
@@ -224,11 +262,14 @@ func EditUser(c *gin.Context) {
 		PhoneNumber:  phoneNumber,
 		IsPublished:  isPublished == "true",
 		CanLogin:     canLogin == "true",
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+		Translations: translations,
 	}
 
-	user, err = services.UpdateUser(id, input)
+	user, err = services.UpdateUser(id, input, currentActor(c))
 	if err != nil {
-		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		respondUserSaveError(c, err)
 		return
 	}
 
@@ -245,7 +286,7 @@ func DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteUser(id)
+	err := services.DeleteUser(id, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -275,6 +316,12 @@ func GetUserByUUID(c *gin.Context) {
 		return
 	}
 
+	translations, err := services.GetTranslations(services.AuditEntityUser, user.ID)
+	if err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	utils.RespondSuccess(c, gin.H{
 		"data": gin.H{
 			"slug":          user.Slug,
@@ -289,6 +336,10 @@ func GetUserByUUID(c *gin.Context) {
 			"url_facebook":  user.URLFacebook,
 			"url_youtube":   user.URLYoutube,
 			"is_published":  user.IsPublished,
+			"publish_at":    user.PublishAt,
+			"unpublish_at":  user.UnpublishAt,
+			"status":        services.ContentStatus(user.IsPublished, user.PublishAt, user.UnpublishAt),
+			"translations":  translations,
 			"can_login": func() interface{} {
 				if user.Password == "" {
 					return false
@@ -315,7 +366,7 @@ func DeleteImageUser(c *gin.Context) {
 		return
 	}
 
-	err := services.DeleteImageUser(id)
+	err := services.DeleteImageUser(id, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -339,7 +390,7 @@ func GetUserOptions(c *gin.Context) {
 }
 
 func GetTeamMembers(c *gin.Context) {
-	teamMembers, err := services.GetTeamMembers()
+	teamMembers, err := services.GetTeamMembers(c.GetString("locale"))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, "failed to get team members")
 		return
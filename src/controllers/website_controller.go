@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
@@ -45,7 +48,7 @@ func CreateWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			ogImage, err = utils.UploadToR2(file, fileHeader, "websites")
+			ogImage, err = services.UploadImage(file, fileHeader, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -62,7 +65,7 @@ func CreateWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			videoWeb, err = utils.UploadToR2(file, fileHeaderVideoWeb, "websites")
+			videoWeb, err = utils.UploadFile(file, fileHeaderVideoWeb, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -79,7 +82,7 @@ func CreateWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			videoMobile, err = utils.UploadToR2(file, fileHeaderVideoMobile, "websites")
+			videoMobile, err = utils.UploadFile(file, fileHeaderVideoMobile, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -115,7 +118,7 @@ func CreateWebsiteInformation(c *gin.Context) {
 		}
 	}
 
-	faq, err := services.CreateWebsiteInformation(input)
+	faq, err := services.CreateWebsiteInformation(input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -156,7 +159,7 @@ func EditWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			ogImage, err = utils.UploadToR2(file, fileHeader, "websites")
+			ogImage, err = services.UploadImage(file, fileHeader, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -173,7 +176,7 @@ func EditWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			videoWeb, err = utils.UploadToR2(file, fileHeaderVideoWeb, "websites")
+			videoWeb, err = utils.UploadFile(file, fileHeaderVideoWeb, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -190,7 +193,7 @@ func EditWebsiteInformation(c *gin.Context) {
 			}
 			defer file.Close()
 
-			videoMobile, err = utils.UploadToR2(file, fileHeaderVideoMobile, "websites")
+			videoMobile, err = utils.UploadFile(file, fileHeaderVideoMobile, "websites")
 
 			if err != nil {
 				utils.RespondError(c, http.StatusInternalServerError, "failed to upload photo")
@@ -226,7 +229,7 @@ func EditWebsiteInformation(c *gin.Context) {
 		}
 	}
 
-	updatedFaq, err := services.EditWebsiteInformation(id, input)
+	updatedFaq, err := services.EditWebsiteInformation(id, input, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -245,7 +248,7 @@ func DeleteWebsiteInformation(c *gin.Context) {
 		return
 	}
 
-	err = services.DeleteWebsiteInformation(data, status)
+	err = services.DeleteWebsiteInformation(data, status, currentActor(c))
 	if err != nil {
 		utils.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -253,3 +256,82 @@ func DeleteWebsiteInformation(c *gin.Context) {
 
 	utils.RespondSuccess(c, gin.H{"message": "Website information deleted successfully"})
 }
+
+// UpdateWebsiteWatermark mengubah watermark global (file "watermark" + enabled, position, opacity, scale).
+// Semua album publik di-render ulang di background.
+func UpdateWebsiteWatermark(c *gin.Context) {
+	id := c.Param("uuid")
+	var input services.WebsiteWatermarkInput
+
+	if strings.HasPrefix(c.GetHeader("Content-Type"), "multipart/form-data") {
+		if v := c.PostForm("enabled"); v != "" {
+			enabled := v == "true"
+			input.Enabled = &enabled
+		}
+		input.Position = c.PostForm("position")
+		for field, target := range map[string]**float64{"opacity": &input.Opacity, "scale": &input.Scale} {
+			v := c.PostForm(field)
+			if v == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				utils.RespondError(c, http.StatusBadRequest, field+" must be a number")
+				return
+			}
+			*target = &value
+		}
+
+		fileHeader, err := c.FormFile("watermark")
+		if err == nil && fileHeader != nil {
+			switch fileHeader.Header.Get("Content-Type") {
+			case "image/png", "image/webp", "image/jpeg":
+			default:
+				utils.RespondError(c, http.StatusBadRequest, "watermark must be a PNG, WebP or JPEG image")
+				return
+			}
+
+			file, openErr := fileHeader.Open()
+			if openErr != nil {
+				utils.RespondError(c, http.StatusInternalServerError, "failed to open uploaded file")
+				return
+			}
+
+			input.ImageURL, err = utils.UploadFile(file, fileHeader, "watermarks")
+			if err != nil {
+				utils.RespondError(c, http.StatusInternalServerError, "failed to upload watermark")
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	watermark, err := services.UpdateWebsiteWatermark(id, input, currentActor(c))
+	if err != nil {
+		if errors.Is(err, services.ErrWatermarkImageRequired) {
+			utils.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"data": watermark})
+}
+
+// RerenderWatermarks mengantrikan ulang render watermark semua album publik
+func RerenderWatermarks(c *gin.Context) {
+	if err := services.RerenderWatermarks(); err != nil {
+		utils.RespondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(c, gin.H{"message": "watermark re-render queued"})
+}
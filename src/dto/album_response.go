@@ -5,26 +5,56 @@ import (
 )
 
 type AlbumResponse struct {
-	UUID         string    `json:"uuid"`
-	Slug         string    `json:"slug"`
-	Title        string    `json:"title"`
-	CategoryId   string    `json:"category_id"`
-	CategoryName string    `json:"category_name"`
-	CategorySlug string    `json:"category_slug"`
-	UserID       string    `json:"user_id"`
-	UserName     string    `json:"user_name"`
-	UserAvatar   string    `json:"user_avatar"`
-	UserSlug     string    `json:"user_slug"`
-	Description  string    `json:"description"`
-	YoutubeURL   string    `json:"youtube_url"`
-	Thumbnail    string    `json:"thumbnail"`
-	Images       []string  `json:"images"` // ubah jadi array string
-	IsPublished  bool      `json:"is_published"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	UUID         string     `json:"uuid"`
+	Slug         string     `json:"slug"`
+	Title        string     `json:"title"`
+	CategoryId   string     `json:"category_id"`
+	CategoryName string     `json:"category_name"`
+	CategorySlug string     `json:"category_slug"`
+	UserID       string     `json:"user_id"`
+	UserName     string     `json:"user_name"`
+	UserAvatar   string     `json:"user_avatar"`
+	UserSlug     string     `json:"user_slug"`
+	Description  string     `json:"description"`
+	YoutubeURL   string     `json:"youtube_url"`
+	Visibility   string     `json:"visibility"` // public, private
+	Thumbnail    string     `json:"thumbnail"`
+	Images       []string   `json:"images"` // ubah jadi array string
+	IsPublished  bool       `json:"is_published"`
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt  *time.Time `json:"unpublish_at,omitempty"`
+	Status       string     `json:"status,omitempty"` // draft, scheduled, published, archived
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+
+	Media []AlbumMediaResponse `json:"media"`
+
+	// Versi responsive dari Images dan Thumbnail (urutan sama dengan Images)
+	ImageSet     []ResponsiveImageResponse `json:"image_set"`
+	ThumbnailSet *ResponsiveImageResponse  `json:"thumbnail_set"`
+
+	UserAvatarPlaceholder *ImagePlaceholderResponse `json:"user_avatar_placeholder"`
 }
 
 type AlbumResponseList struct {
-	Data      []AlbumResponse `json:"data"`
-	NextValue int64           `json:"next"`
+	Data    []AlbumResponse `json:"data"`
+	Next    string          `json:"next"`
+	HasMore bool            `json:"has_more"`
+}
+
+type AlbumMediaResponse struct {
+	UUID      string `json:"uuid"`
+	URL       string `json:"url"`
+	Position  int32  `json:"position"`
+	CaptionEn string `json:"caption_en"`
+	CaptionID string `json:"caption_id"`
+	AltText   string `json:"alt_text"`
+	Width     int32  `json:"width"`
+	Height    int32  `json:"height"`
+	MimeType  string `json:"mime_type"`
+	IsCover   bool   `json:"is_cover"`
+
+	Metadata    *ImageMetadataResponse    `json:"metadata"` // null kalau gambar tidak punya EXIF
+	Placeholder *ImagePlaceholderResponse `json:"placeholder"`
 }
@@ -3,15 +3,21 @@ package dto
 import "time"
 
 type CategoryResponse struct {
-	UUID        string    `json:"uuid"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Slug        string    `json:"slug"`
-	PhotoUrl    string    `json:"photo_url"`
-	YoutubeURL  string    `json:"youtube_url"`
-	IsPublished bool      `json:"is_published"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Slug        string     `json:"slug"`
+	PhotoUrl    string     `json:"photo_url"`
+	YoutubeURL  string     `json:"youtube_url"`
+	IsPublished bool       `json:"is_published"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	Status      string     `json:"status,omitempty"` // draft, scheduled, published, archived
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+
+	PhotoPlaceholder *ImagePlaceholderResponse `json:"photo_placeholder"`
 }
 
 type CategoryBySlugResponse struct {
@@ -21,4 +27,6 @@ type CategoryBySlugResponse struct {
 	Slug        string         `json:"slug"`
 	PhotoUrl    string         `json:"photo_url"`
 	Users       []UserResponse `json:"users"`
+
+	PhotoPlaceholder *ImagePlaceholderResponse `json:"photo_placeholder"`
 }
@@ -0,0 +1,6 @@
+package dto
+
+type CursorPageResponse struct {
+	Next    string `json:"next"` // kosong kalau sudah halaman terakhir
+	HasMore bool   `json:"has_more"`
+}
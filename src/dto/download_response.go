@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type AlbumDownloadLinkResponse struct {
+	UUID             string     `json:"uuid"`
+	Label            string     `json:"label"`
+	Token            string     `json:"token,omitempty"` // hanya dikirim sekali saat link dibuat
+	URL              string     `json:"url,omitempty"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	MaxDownloads     *int32     `json:"max_downloads"`
+	DownloadCount    int32      `json:"download_count"`
+	LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type DuplicateAlbumResponse struct {
+	UUID  string `json:"uuid"`
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+type DuplicateItemResponse struct {
+	AssetUUID string                   `json:"asset_uuid"`
+	MediaUUID string                   `json:"media_uuid,omitempty"` // hanya di laporan per album
+	URL       string                   `json:"url"`
+	Width     int32                    `json:"width"`
+	Height    int32                    `json:"height"`
+	Bytes     int64                    `json:"bytes"`    // original + semua derivative
+	Distance  int                      `json:"distance"` // beda perceptual hash dengan item pertama, 0 = identik
+	Albums    []DuplicateAlbumResponse `json:"albums,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+type DuplicateGroupResponse struct {
+	Type       string                  `json:"type"` // exact, near
+	Hash       string                  `json:"hash"` // sha256 (exact) atau dHash hex item pertama (near)
+	Items      []DuplicateItemResponse `json:"items"`
+	TotalBytes int64                   `json:"total_bytes"`
+	Savings    int64                   `json:"savings"` // bytes yang hemat kalau hanya satu file disimpan
+}
+
+type AlbumDuplicatesResponse struct {
+	Exact     []DuplicateGroupResponse `json:"exact"`
+	Near      []DuplicateGroupResponse `json:"near"`
+	Threshold int                      `json:"threshold"`
+	Pending   int                      `json:"pending"` // gambar yang belum punya hash (belum selesai diproses)
+}
+
+// DuplicateReportResponse laporan duplikat seluruh library
+type DuplicateReportResponse struct {
+	Exact        []DuplicateGroupResponse `json:"exact"`
+	Near         []DuplicateGroupResponse `json:"near"`
+	ExactSavings int64                    `json:"exact_savings"`
+	NearSavings  int64                    `json:"near_savings"` // perkiraan, file terbesar tiap grup dianggap disimpan
+	Threshold    int                      `json:"threshold"`
+	Scanned      int                      `json:"scanned"`
+	Pending      int64                    `json:"pending"` // asset tanpa hash, jalankan image backfill
+}
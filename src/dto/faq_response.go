@@ -3,12 +3,18 @@ package dto
 import "time"
 
 type FaqResponse struct {
-	UUID        string    `json:"uuid"`
-	QuestionID  string    `json:"question_id"`
-	QuestionEn  string    `json:"question_en"`
-	AnswerID    string    `json:"answer_id"`
-	AnswerEn    string    `json:"answer_en"`
-	IsPublished bool      `json:"is_published"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	UUID        string     `json:"uuid"`
+	QuestionID  string     `json:"question_id"`
+	QuestionEn  string     `json:"question_en"`
+	AnswerID    string     `json:"answer_id"`
+	AnswerEn    string     `json:"answer_en"`
+	Question    string     `json:"question,omitempty"` // sesuai locale request
+	Answer      string     `json:"answer,omitempty"`
+	IsPublished bool       `json:"is_published"`
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	Status      string     `json:"status,omitempty"` // draft, scheduled, published, archived
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
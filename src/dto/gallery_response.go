@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type GalleryLinkResponse struct {
+	UUID           string     `json:"uuid"`
+	Label          string     `json:"label"`
+	Token          string     `json:"token,omitempty"` // hanya dikirim sekali saat link dibuat
+	URL            string     `json:"url,omitempty"`
+	HasPassword    bool       `json:"has_password"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	AccessCount    int32      `json:"access_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type GalleryMediaResponse struct {
+	UUID      string                  `json:"uuid"`
+	CaptionEn string                  `json:"caption_en"`
+	CaptionID string                  `json:"caption_id"`
+	AltText   string                  `json:"alt_text"`
+	Image     ResponsiveImageResponse `json:"image"`
+}
+
+// GalleryResponse isi gallery private untuk client. Semua URL sudah signed dan berlaku sampai URLExpiresAt.
+type GalleryResponse struct {
+	Title        string                   `json:"title"`
+	Description  string                   `json:"description"`
+	CategoryName string                   `json:"category_name"`
+	UserName     string                   `json:"user_name"`
+	UserSlug     string                   `json:"user_slug"`
+	ThumbnailSet *ResponsiveImageResponse `json:"thumbnail_set"`
+	Media        []GalleryMediaResponse   `json:"media"`
+	ExpiresAt    *time.Time               `json:"expires_at"`
+	URLExpiresAt time.Time                `json:"url_expires_at"`
+}
+
+type GalleryAccessResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// ImageMetadataResponse data kamera dari EXIF, field null = tidak ada di file
+type ImageMetadataResponse struct {
+	CameraMake      string            `json:"camera_make"`
+	CameraModel     string            `json:"camera_model"`
+	LensModel       string            `json:"lens_model"`
+	FocalLength     *float64          `json:"focal_length"` // mm
+	FocalLength35mm *int32            `json:"focal_length_35mm"`
+	Aperture        *float64          `json:"aperture"`      // f-number
+	ExposureTime    string            `json:"exposure_time"` // contoh: 1/250
+	ISO             *int32            `json:"iso"`
+	CapturedAt      *time.Time        `json:"captured_at"`
+	Orientation     int32             `json:"orientation"`
+	GPS             *ImageGPSResponse `json:"gps"` // hanya untuk admin, null di halaman publik
+}
+
+type ImageGPSResponse struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Altitude  *float64 `json:"altitude"`
+}
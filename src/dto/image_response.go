@@ -0,0 +1,32 @@
+package dto
+
+type ImageVariantResponse struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// ResponsiveImageResponse siap dipakai langsung di <img srcset> / <picture>.
+// Kalau derivative belum selesai diproses, hanya URL original yang terisi.
+type ResponsiveImageResponse struct {
+	URL        string                 `json:"url"`
+	Width      int32                  `json:"width,omitempty"`
+	Height     int32                  `json:"height,omitempty"`
+	Srcset     string                 `json:"srcset,omitempty"`
+	WebpSrcset string                 `json:"webp_srcset,omitempty"`
+	Variants   []ImageVariantResponse `json:"variants"`
+
+	Placeholder *ImagePlaceholderResponse `json:"placeholder,omitempty"`
+}
+
+// ImagePlaceholderResponse ditampilkan selama gambar asli dimuat.
+// Kosong (null) kalau gambar belum selesai diproses.
+type ImagePlaceholderResponse struct {
+	Blurhash      string   `json:"blurhash"`
+	LQIP          string   `json:"lqip"`           // data URI base64, bisa langsung dipakai di src
+	DominantColor string   `json:"dominant_color"` // #rrggbb
+	Palette       []string `json:"palette"`
+}
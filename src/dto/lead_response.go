@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type LeadUserResponse struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type LeadCategoryResponse struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type LeadNoteResponse struct {
+	UUID       string    `json:"uuid"`
+	AuthorUUID string    `json:"author_uuid"`
+	AuthorName string    `json:"author_name"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type LeadResponse struct {
+	UUID          string                `json:"uuid"`
+	Name          string                `json:"name"`
+	Email         string                `json:"email"`
+	PhoneNumber   string                `json:"phone_number"`
+	EventDate     string                `json:"event_date,omitempty"`
+	Category      *LeadCategoryResponse `json:"category"`
+	PreferredUser *LeadUserResponse     `json:"preferred_user"`
+	AssignedUser  *LeadUserResponse     `json:"assigned_user"`
+	Message       string                `json:"message"`
+	Status        string                `json:"status"`
+	Notes         []LeadNoteResponse    `json:"notes,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
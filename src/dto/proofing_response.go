@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+type ProofingLinkResponse struct {
+	UUID          string     `json:"uuid"`
+	Label         string     `json:"label"`
+	Token         string     `json:"token,omitempty"` // hanya dikirim sekali saat link dibuat
+	URL           string     `json:"url,omitempty"`
+	MaxSelections *int32     `json:"max_selections"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	SubmittedAt   *time.Time `json:"submitted_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	Lists []ProofingListResponse `json:"lists,omitempty"`
+}
+
+type ProofingListResponse struct {
+	UUID          string                 `json:"uuid"`
+	Name          string                 `json:"name"`
+	MaxSelections *int32                 `json:"max_selections"` // sudah termasuk batas default dari link
+	Count         int                    `json:"count"`
+	Items         []ProofingItemResponse `json:"items"`
+}
+
+type ProofingItemResponse struct {
+	MediaUUID string    `json:"media_uuid"`
+	Filename  string    `json:"filename"`
+	Comment   string    `json:"comment"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProofingGalleryResponse isi halaman proofing untuk client
+type ProofingGalleryResponse struct {
+	Title         string                 `json:"title"`
+	Description   string                 `json:"description"`
+	UserName      string                 `json:"user_name"`
+	MaxSelections *int32                 `json:"max_selections"`
+	ExpiresAt     *time.Time             `json:"expires_at"`
+	SubmittedAt   *time.Time             `json:"submitted_at"`
+	Media         []GalleryMediaResponse `json:"media"`
+	Lists         []ProofingListResponse `json:"lists"`
+	URLExpiresAt  time.Time              `json:"url_expires_at"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type SearchResultResponse struct {
+	Type      string  `json:"type"` // album, category, user, faq
+	UUID      string  `json:"uuid"`
+	Slug      string  `json:"slug"`
+	Title     string  `json:"title"`
+	Highlight string  `json:"highlight"` // HTML: judul yang sudah di-escape, kata yang cocok dibungkus <mark>
+	Snippet   string  `json:"snippet"`   // HTML, sama seperti highlight
+	Image     string  `json:"image"`
+	Rank      float64 `json:"rank"`
+
+	ImagePlaceholder *ImagePlaceholderResponse `json:"image_placeholder" gorm:"-"`
+}
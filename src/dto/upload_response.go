@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type PresignedUploadResponse struct {
+	UploadID  string            `json:"upload_id"`
+	Filename  string            `json:"filename"`
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type UploadErrorResponse struct {
+	UploadID string `json:"upload_id"`
+	Error    string `json:"error"`
+}
+
+type ConfirmUploadResponse struct {
+	Confirmed []AlbumMediaResponse  `json:"confirmed"`
+	Failed    []UploadErrorResponse `json:"failed"`
+}
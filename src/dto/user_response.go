@@ -3,21 +3,27 @@ package dto
 import "time"
 
 type UserResponse struct {
-	UUID         string    `json:"uuid"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email"`
-	Photo        string    `json:"photo"`
-	Description  string    `json:"description"`
-	Role         string    `json:"role"`
-	Slug         string    `json:"slug"`
-	PhoneNumber  string    `json:"phone_number"`
-	URLInstagram string    `json:"url_instagram"`
-	URLTikTok    string    `json:"url_tiktok"`
-	URLFacebook  string    `json:"url_facebook"`
-	URLYoutube   string    `json:"url_youtube"`
-	IsPublished  bool      `json:"is_published"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	UUID         string     `json:"uuid"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Photo        string     `json:"photo"`
+	Description  string     `json:"description"`
+	Role         string     `json:"role"`
+	Slug         string     `json:"slug"`
+	PhoneNumber  string     `json:"phone_number"`
+	URLInstagram string     `json:"url_instagram"`
+	URLTikTok    string     `json:"url_tiktok"`
+	URLFacebook  string     `json:"url_facebook"`
+	URLYoutube   string     `json:"url_youtube"`
+	IsPublished  bool       `json:"is_published"`
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt  *time.Time `json:"unpublish_at,omitempty"`
+	Status       string     `json:"status,omitempty"` // draft, scheduled, published, archived
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+
+	PhotoPlaceholder *ImagePlaceholderResponse `json:"photo_placeholder"`
 }
 
 type UserPortfolioResponse struct {
@@ -0,0 +1,17 @@
+package dto
+
+type WatermarkSettingsResponse struct {
+	ImageURL string  `json:"image_url"`
+	Enabled  bool    `json:"enabled"`
+	Position string  `json:"position"` // top-left, top-right, bottom-left, bottom-right, center
+	Opacity  float64 `json:"opacity"`
+	Scale    float64 `json:"scale"` // lebar watermark relatif terhadap lebar foto
+}
+
+// WatermarkOverrideResponse override per album/kategori, null = ikut pengaturan di atasnya
+type WatermarkOverrideResponse struct {
+	Mode     string   `json:"mode"` // inherit, enabled, disabled
+	Position *string  `json:"position"`
+	Opacity  *float64 `json:"opacity"`
+	Scale    *float64 `json:"scale"`
+}
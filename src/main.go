@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"strings"
 
 	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/migrations"
 	"github.com/charis16/luminor-golang-be/src/routes"
+	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -20,7 +24,10 @@ func main() {
 		log.Println("⚠️  .env not found, using default PORT 8080")
 	}
 
-	utils.InitR2()
+	utils.InitStorage()
+	utils.InitMailer()
+	utils.InitRevalidator()
+	utils.InitNotifier()
 	ginMode := utils.GetEnvOrDefault("GIN_MODE", "development")
 	if ginMode != "" && ginMode == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -28,6 +35,18 @@ func main() {
 
 	r := gin.Default()
 
+	// ClientIP dipakai rate limiter dan audit, jadi X-Forwarded-For hanya dipercaya dari proxy yang dikenal.
+	// TRUSTED_PROXIES kosong = tidak ada proxy, IP diambil dari koneksi langsung.
+	var trustedProxies []string
+	for _, proxy := range strings.Split(utils.GetEnvOrDefault("TRUSTED_PROXIES", ""), ",") {
+		if trimmed := strings.TrimSpace(proxy); trimmed != "" {
+			trustedProxies = append(trustedProxies, trimmed)
+		}
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatal("❌ Invalid TRUSTED_PROXIES:", err)
+	}
+
 	feUrls := utils.GetEnvOrDefault("FE_URL", "http://localhost:3000")
 	originList := strings.Split(feUrls, ",")
 	allowOrigins := make([]string, 0, len(originList))
@@ -41,19 +60,59 @@ func main() {
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     allowOrigins, // frontend kamu
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Set-Cookie"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Gallery-Access", "Range", "If-Range"},
+		ExposeHeaders:    []string{"Set-Cookie", "Content-Disposition", "Content-Range", "Accept-Ranges", "ETag"},
 		AllowCredentials: true,
 	}))
 
 	config.ConnectDB()
-	v1 := r.Group("/v1/api")
+	if utils.GetEnvOrDefault("MIGRATE_ON_BOOT", "false") == "true" {
+		runMigrations()
+	}
+	if utils.GetEnvOrDefault("IMAGE_WORKER_ENABLED", "true") == "true" {
+		services.StartImageWorker()
+	}
+	if utils.GetEnvOrDefault("WATERMARK_WORKER_ENABLED", "true") == "true" {
+		services.StartWatermarkWorker()
+	}
+	if utils.GetEnvOrDefault("DELETION_WORKER_ENABLED", "true") == "true" {
+		services.StartObjectDeletionWorker()
+	}
+	if utils.GetEnvOrDefault("UPLOAD_CLEANUP_ENABLED", "true") == "true" {
+		services.StartUploadCleanup()
+	}
+	if utils.GetEnvOrDefault("STORAGE_GC_ENABLED", "false") == "true" {
+		services.StartStorageGC()
+	}
+	if utils.GetEnvOrDefault("TRASH_PURGE_ENABLED", "true") == "true" {
+		services.StartTrashPurge()
+	}
+	if utils.GetEnvOrDefault("PUBLISH_SCHEDULER_ENABLED", "true") == "true" {
+		services.StartPublishScheduler()
+	}
+
+	v1 := r.Group("/v1/api", middleware.Locale())
 	routes.UserRoutes(v1)
 	routes.AuthRoutes(v1)
 	routes.FaqRoutes(v1)
 	routes.CategoryRoutes(v1)
 	routes.WebsiteRoutes(v1)
 	routes.AlbumRoutes(v1)
+	routes.RoleRoutes(v1)
+	routes.AuditRoutes(v1)
+	routes.SearchRoutes(v1)
+	routes.LeadRoutes(v1)
+	routes.GalleryRoutes(v1)
+	routes.ProofingRoutes(v1)
+	routes.DownloadRoutes(v1)
+	routes.SeoRoutes(v1)
+
+	if _, ok := utils.Store.(*utils.LocalStorage); ok {
+		routes.StorageRoutes(&r.RouterGroup)
+	}
+	if _, ok := utils.PrivateStore.(*utils.LocalStorage); ok {
+		routes.PrivateStorageRoutes(&r.RouterGroup)
+	}
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{"message": "pong"})
@@ -73,3 +132,24 @@ func main() {
 		}
 	}
 }
+
+// runMigrations menjalankan semua migration yang belum jalan sebelum server start.
+// Advisory lock membuat container lain menunggu sampai migration selesai.
+func runMigrations() {
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		log.Fatal("❌ Failed to get sql.DB:", err)
+	}
+
+	ctx := context.Background()
+	runner, err := migrations.NewRunner(ctx, sqlDB)
+	if err != nil {
+		log.Fatal("❌ Failed to start migration runner:", err)
+	}
+	defer runner.Close()
+
+	if err := runner.Up(ctx, 0, log.Printf); err != nil {
+		runner.Close()
+		log.Fatal("❌ Migration failed:", err)
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/charis16/luminor-golang-be/src/services"
 	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -40,3 +41,29 @@ func RequireRole(role string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePermission lolos jika role user punya salah satu permission yang diminta.
+// Daftar permission milik user juga disimpan ke context sebagai "permissions".
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+
+		granted, err := services.GetRolePermissions(role)
+		if err != nil {
+			utils.RespondError(c, http.StatusInternalServerError, "failed to load permissions")
+			return
+		}
+		c.Set("permissions", granted)
+
+		for _, required := range permissions {
+			for _, p := range granted {
+				if p == required {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		utils.RespondError(c, http.StatusForbidden, "forbidden: insufficient permission")
+	}
+}
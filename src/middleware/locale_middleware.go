@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale memilih bahasa konten dari ?lang= atau header Accept-Language, hasilnya di context "locale"
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := utils.NegotiateLocale(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+		c.Set("locale", locale)
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP CONSTRAINT fk_users_role;
+
+DROP TABLE role_permissions;
+DROP TABLE permissions;
+DROP TABLE roles;
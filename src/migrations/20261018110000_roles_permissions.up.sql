@@ -0,0 +1,51 @@
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE permissions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT
+);
+
+CREATE TABLE role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO permissions (code, description) VALUES
+    ('album:write:own', 'Create and edit albums owned by the user'),
+    ('album:write:any', 'Create and edit every album'),
+    ('category:write', 'Manage categories'),
+    ('faq:write', 'Manage FAQs'),
+    ('website:write', 'Manage website settings'),
+    ('user:manage', 'Manage users, roles and sessions');
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access'),
+    ('photographer', 'Can manage their own albums');
+
+-- Role lama yang sudah dipakai user tetap dibuat supaya FK tidak gagal
+INSERT INTO roles (name)
+SELECT DISTINCT role FROM users
+WHERE role IS NOT NULL AND role <> ''
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.code = 'album:write:own'
+WHERE r.name = 'photographer';
+
+UPDATE users SET role = NULL WHERE role = '';
+
+ALTER TABLE users
+    ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
@@ -0,0 +1,33 @@
+CREATE TABLE image_assets (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    url TEXT UNIQUE NOT NULL,
+    storage_key TEXT NOT NULL,
+    mime_type VARCHAR(100),
+    width INT,
+    height INT,
+    bytes BIGINT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_at TIMESTAMP,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_image_assets_status ON image_assets(status);
+
+CREATE TABLE image_variants (
+    id SERIAL PRIMARY KEY,
+    asset_id INT NOT NULL REFERENCES image_assets(id) ON DELETE CASCADE,
+    name VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    storage_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    bytes BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (asset_id, name, format)
+);
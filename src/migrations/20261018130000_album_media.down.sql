@@ -0,0 +1,10 @@
+ALTER TABLE albums ADD COLUMN images TEXT[];
+
+UPDATE albums a
+SET images = (
+    SELECT array_agg(m.url ORDER BY m.position, m.id)
+    FROM album_media m
+    WHERE m.album_id = a.id
+);
+
+DROP TABLE album_media;
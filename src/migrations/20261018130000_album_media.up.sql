@@ -0,0 +1,48 @@
+CREATE TABLE album_media (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    album_id INT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    caption_en TEXT,
+    caption_id TEXT,
+    alt_text TEXT,
+    width INT,
+    height INT,
+    mime_type VARCHAR(100),
+    is_cover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (album_id, url)
+);
+
+CREATE INDEX idx_album_media_album_position ON album_media(album_id, position);
+
+-- Satu album maksimal punya satu cover
+CREATE UNIQUE INDEX idx_album_media_cover ON album_media(album_id) WHERE is_cover;
+
+-- Pindahkan isi albums.images, urutan array jadi position.
+-- Data lama semuanya di R2, jadi key = path URL tanpa scheme dan host.
+INSERT INTO album_media (album_id, storage_key, url, position, width, height, mime_type, is_cover, created_at, updated_at)
+SELECT
+    a.id,
+    regexp_replace(img.url, '^https?://[^/]+/', ''),
+    img.url,
+    (img.ord - 1)::INT,
+    ia.width,
+    ia.height,
+    ia.mime_type,
+    img.url = a.thumbnail,
+    a.created_at,
+    a.updated_at
+FROM albums a
+CROSS JOIN LATERAL (
+    SELECT DISTINCT ON (trim(both '"' from u)) trim(both '"' from u) AS url, ord
+    FROM unnest(a.images) WITH ORDINALITY AS t(u, ord)
+    WHERE trim(both '"' from u) <> ''
+    ORDER BY trim(both '"' from u), ord
+) img
+LEFT JOIN image_assets ia ON ia.url = img.url;
+
+ALTER TABLE albums DROP COLUMN images;
@@ -0,0 +1,12 @@
+CREATE TABLE pending_object_deletions (
+    id SERIAL PRIMARY KEY,
+    storage_key TEXT NOT NULL,
+    reason VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pending_object_deletions_due ON pending_object_deletions(status, next_attempt_at);
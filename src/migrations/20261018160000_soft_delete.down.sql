@@ -0,0 +1,10 @@
+-- Item di trash dihapus permanen karena kolom penandanya hilang
+DELETE FROM albums WHERE deleted_at IS NOT NULL;
+DELETE FROM faqs WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE albums DROP COLUMN deleted_at;
+ALTER TABLE categories DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
+ALTER TABLE faqs DROP COLUMN deleted_at;
@@ -0,0 +1,9 @@
+ALTER TABLE albums ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE categories ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE faqs ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_albums_deleted_at ON albums(deleted_at);
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at);
+CREATE INDEX idx_users_deleted_at ON users(deleted_at);
+CREATE INDEX idx_faqs_deleted_at ON faqs(deleted_at);
@@ -0,0 +1,25 @@
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    actor_uuid UUID,
+    actor_role VARCHAR(50),
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_uuid UUID,
+    old_values JSONB,
+    new_values JSONB,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at DESC);
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_uuid);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor_uuid);
+
+INSERT INTO permissions (code, description) VALUES
+    ('audit:read', 'Browse the audit log');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.code = 'audit:read'
+WHERE r.name = 'admin';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS revalidation_events;
+DROP TABLE IF EXISTS scheduler_checkpoints;
+
+ALTER TABLE albums DROP COLUMN publish_at, DROP COLUMN unpublish_at;
+ALTER TABLE categories DROP COLUMN publish_at, DROP COLUMN unpublish_at;
+ALTER TABLE faqs DROP COLUMN publish_at, DROP COLUMN unpublish_at;
+ALTER TABLE users DROP COLUMN publish_at, DROP COLUMN unpublish_at;
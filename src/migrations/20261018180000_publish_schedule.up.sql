@@ -0,0 +1,27 @@
+ALTER TABLE albums ADD COLUMN publish_at TIMESTAMP, ADD COLUMN unpublish_at TIMESTAMP;
+ALTER TABLE categories ADD COLUMN publish_at TIMESTAMP, ADD COLUMN unpublish_at TIMESTAMP;
+ALTER TABLE faqs ADD COLUMN publish_at TIMESTAMP, ADD COLUMN unpublish_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN publish_at TIMESTAMP, ADD COLUMN unpublish_at TIMESTAMP;
+
+-- Posisi terakhir scheduler supaya transisi tidak terlewat atau terkirim dua kali setelah restart
+CREATE TABLE scheduler_checkpoints (
+    name VARCHAR(100) PRIMARY KEY,
+    checked_at TIMESTAMP NOT NULL
+);
+
+-- Antrian event revalidation yang dikirim ke frontend
+CREATE TABLE revalidation_events (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_uuid UUID NOT NULL,
+    slug VARCHAR(255),
+    transition VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_revalidation_events_due ON revalidation_events(status, next_attempt_at);
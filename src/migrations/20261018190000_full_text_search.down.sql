@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_faqs_search_vector;
+DROP INDEX IF EXISTS idx_users_search_vector;
+DROP INDEX IF EXISTS idx_categories_search_vector;
+DROP INDEX IF EXISTS idx_albums_search_vector;
+
+ALTER TABLE faqs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE categories DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE albums DROP COLUMN IF EXISTS search_vector;
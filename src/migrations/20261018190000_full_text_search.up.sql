@@ -0,0 +1,35 @@
+-- Kolom pencarian full-text. Konfigurasi simple untuk teks Bahasa Indonesia (tanpa stemming),
+-- english untuk teks Inggris. Judul/nama berbobot A, deskripsi/jawaban berbobot B.
+ALTER TABLE albums ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE categories ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE faqs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(question_id, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(question_en, '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(question_en, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(answer_id, '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(answer_en, '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, coalesce(answer_en, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_albums_search_vector ON albums USING GIN (search_vector);
+CREATE INDEX idx_categories_search_vector ON categories USING GIN (search_vector);
+CREATE INDEX idx_users_search_vector ON users USING GIN (search_vector);
+CREATE INDEX idx_faqs_search_vector ON faqs USING GIN (search_vector);
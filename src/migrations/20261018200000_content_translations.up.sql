@@ -0,0 +1,12 @@
+-- Terjemahan konten untuk locale selain yang disimpan di kolom tabelnya sendiri
+CREATE TABLE content_translations (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INT NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    field VARCHAR(50) NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (entity_type, entity_id, locale, field)
+);
@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE code IN ('lead:manage:own', 'lead:manage:any');
+
+DROP TABLE IF EXISTS lead_notes;
+DROP TABLE IF EXISTS leads;
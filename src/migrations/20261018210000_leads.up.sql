@@ -0,0 +1,45 @@
+CREATE TABLE leads (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255),
+    phone_number VARCHAR(50),
+    event_date DATE,
+    category_id INT REFERENCES categories(id) ON DELETE SET NULL,
+    preferred_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    assigned_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'new'
+        CHECK (status IN ('new', 'contacted', 'quoted', 'booked', 'lost')),
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_leads_status ON leads(status);
+CREATE INDEX idx_leads_assigned_user_id ON leads(assigned_user_id);
+CREATE INDEX idx_leads_created_at ON leads(created_at DESC);
+
+CREATE TABLE lead_notes (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    lead_id INT NOT NULL REFERENCES leads(id) ON DELETE CASCADE,
+    author_uuid UUID,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_lead_notes_lead_id ON lead_notes(lead_id);
+
+INSERT INTO permissions (code, description) VALUES
+    ('lead:manage:own', 'View and follow up leads assigned to the user'),
+    ('lead:manage:any', 'View, assign and follow up every lead');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.code IN ('lead:manage:own', 'lead:manage:any')
+WHERE r.name = 'admin';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.code = 'lead:manage:own'
+WHERE r.name = 'photographer';
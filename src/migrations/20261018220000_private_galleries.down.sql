@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS gallery_links;
+
+ALTER TABLE pending_object_deletions DROP COLUMN IF EXISTS is_private;
+ALTER TABLE pending_uploads DROP COLUMN IF EXISTS is_private;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS is_private;
+
+DROP INDEX IF EXISTS idx_albums_visibility;
+ALTER TABLE albums DROP COLUMN IF EXISTS visibility;
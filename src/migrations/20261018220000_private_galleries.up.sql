@@ -0,0 +1,27 @@
+ALTER TABLE albums ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'private'));
+
+CREATE INDEX idx_albums_visibility ON albums(visibility);
+
+-- File album private disimpan di bucket terpisah
+ALTER TABLE image_assets ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE pending_uploads ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE pending_object_deletions ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE gallery_links (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    album_id INT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    label VARCHAR(100),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    password_hash TEXT,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    last_accessed_at TIMESTAMP,
+    access_count INT NOT NULL DEFAULT 0,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_gallery_links_album_id ON gallery_links(album_id);
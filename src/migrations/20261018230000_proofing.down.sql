@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS proofing_items;
+DROP TABLE IF EXISTS proofing_lists;
+DROP TABLE IF EXISTS proofing_links;
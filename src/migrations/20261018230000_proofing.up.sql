@@ -0,0 +1,40 @@
+CREATE TABLE proofing_links (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    album_id INT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    label VARCHAR(100),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    -- Batas default jumlah foto per daftar pilihan, NULL = tanpa batas
+    max_selections INT CHECK (max_selections > 0),
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    submitted_at TIMESTAMP,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_proofing_links_album_id ON proofing_links(album_id);
+
+CREATE TABLE proofing_lists (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    link_id INT NOT NULL REFERENCES proofing_links(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    max_selections INT CHECK (max_selections > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (link_id, name)
+);
+
+CREATE TABLE proofing_items (
+    id SERIAL PRIMARY KEY,
+    list_id INT NOT NULL REFERENCES proofing_lists(id) ON DELETE CASCADE,
+    media_id INT NOT NULL REFERENCES album_media(id) ON DELETE CASCADE,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (list_id, media_id)
+);
+
+CREATE INDEX idx_proofing_items_media_id ON proofing_items(media_id);
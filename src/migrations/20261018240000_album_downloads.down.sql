@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS album_download_links;
+
+ALTER TABLE album_media DROP COLUMN IF EXISTS crc32;
+ALTER TABLE album_media DROP COLUMN IF EXISTS bytes;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS crc32;
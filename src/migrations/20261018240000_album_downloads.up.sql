@@ -0,0 +1,22 @@
+-- Ukuran dan CRC file original, dipakai untuk membangun ZIP tanpa membaca ulang file
+ALTER TABLE image_assets ADD COLUMN crc32 BIGINT;
+ALTER TABLE album_media ADD COLUMN bytes BIGINT;
+ALTER TABLE album_media ADD COLUMN crc32 BIGINT;
+
+CREATE TABLE album_download_links (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE DEFAULT gen_random_uuid(),
+    album_id INT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    label VARCHAR(100),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    max_downloads INT CHECK (max_downloads IS NULL OR max_downloads > 0),
+    download_count INT NOT NULL DEFAULT 0,
+    last_downloaded_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_album_download_links_album_id ON album_download_links(album_id);
@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS idx_image_assets_watermark_queue;
+
+ALTER TABLE image_assets DROP COLUMN IF EXISTS watermark_error;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS watermark_locked_at;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS watermark_attempts;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS watermark_status;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS watermark;
+
+ALTER TABLE albums DROP COLUMN IF EXISTS watermark_scale;
+ALTER TABLE albums DROP COLUMN IF EXISTS watermark_opacity;
+ALTER TABLE albums DROP COLUMN IF EXISTS watermark_position;
+ALTER TABLE albums DROP COLUMN IF EXISTS watermark_mode;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS watermark_scale;
+ALTER TABLE categories DROP COLUMN IF EXISTS watermark_opacity;
+ALTER TABLE categories DROP COLUMN IF EXISTS watermark_position;
+ALTER TABLE categories DROP COLUMN IF EXISTS watermark_mode;
+
+ALTER TABLE websites DROP COLUMN IF EXISTS watermark_scale;
+ALTER TABLE websites DROP COLUMN IF EXISTS watermark_opacity;
+ALTER TABLE websites DROP COLUMN IF EXISTS watermark_position;
+ALTER TABLE websites DROP COLUMN IF EXISTS watermark_enabled;
+ALTER TABLE websites DROP COLUMN IF EXISTS watermark_url;
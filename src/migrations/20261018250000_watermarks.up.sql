@@ -0,0 +1,33 @@
+-- Watermark default untuk semua gambar album publik
+ALTER TABLE websites ADD COLUMN watermark_url TEXT;
+ALTER TABLE websites ADD COLUMN watermark_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE websites ADD COLUMN watermark_position VARCHAR(20) NOT NULL DEFAULT 'bottom-right'
+    CHECK (watermark_position IN ('top-left', 'top-right', 'bottom-left', 'bottom-right', 'center'));
+ALTER TABLE websites ADD COLUMN watermark_opacity REAL NOT NULL DEFAULT 0.5
+    CHECK (watermark_opacity > 0 AND watermark_opacity <= 1);
+ALTER TABLE websites ADD COLUMN watermark_scale REAL NOT NULL DEFAULT 0.2
+    CHECK (watermark_scale > 0 AND watermark_scale <= 1);
+
+-- Override per kategori dan per album. NULL = ikut level di atasnya (album -> kategori -> website)
+ALTER TABLE categories ADD COLUMN watermark_mode VARCHAR(10) NOT NULL DEFAULT 'inherit'
+    CHECK (watermark_mode IN ('inherit', 'enabled', 'disabled'));
+ALTER TABLE categories ADD COLUMN watermark_position VARCHAR(20)
+    CHECK (watermark_position IN ('top-left', 'top-right', 'bottom-left', 'bottom-right', 'center'));
+ALTER TABLE categories ADD COLUMN watermark_opacity REAL CHECK (watermark_opacity > 0 AND watermark_opacity <= 1);
+ALTER TABLE categories ADD COLUMN watermark_scale REAL CHECK (watermark_scale > 0 AND watermark_scale <= 1);
+
+ALTER TABLE albums ADD COLUMN watermark_mode VARCHAR(10) NOT NULL DEFAULT 'inherit'
+    CHECK (watermark_mode IN ('inherit', 'enabled', 'disabled'));
+ALTER TABLE albums ADD COLUMN watermark_position VARCHAR(20)
+    CHECK (watermark_position IN ('top-left', 'top-right', 'bottom-left', 'bottom-right', 'center'));
+ALTER TABLE albums ADD COLUMN watermark_opacity REAL CHECK (watermark_opacity > 0 AND watermark_opacity <= 1);
+ALTER TABLE albums ADD COLUMN watermark_scale REAL CHECK (watermark_scale > 0 AND watermark_scale <= 1);
+
+-- Antrian render salinan ber-watermark (disimpan sebagai image_variants "wm-*"), original tidak diubah
+ALTER TABLE image_assets ADD COLUMN watermark VARCHAR(64);
+ALTER TABLE image_assets ADD COLUMN watermark_status VARCHAR(20) NOT NULL DEFAULT 'none';
+ALTER TABLE image_assets ADD COLUMN watermark_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE image_assets ADD COLUMN watermark_locked_at TIMESTAMP;
+ALTER TABLE image_assets ADD COLUMN watermark_error TEXT;
+
+CREATE INDEX idx_image_assets_watermark_queue ON image_assets(id) WHERE watermark_status IN ('pending', 'processing');
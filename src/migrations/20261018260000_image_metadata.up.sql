@@ -0,0 +1,23 @@
+-- Metadata kamera (EXIF) per gambar, diisi image worker
+CREATE TABLE image_metadata (
+    id SERIAL PRIMARY KEY,
+    asset_id INT UNIQUE NOT NULL REFERENCES image_assets(id) ON DELETE CASCADE,
+    camera_make VARCHAR(100),
+    camera_model VARCHAR(100),
+    lens_model VARCHAR(150),
+    focal_length REAL,
+    focal_length_35mm INT,
+    aperture REAL,
+    exposure_time VARCHAR(20),
+    iso INT,
+    captured_at TIMESTAMP,
+    orientation SMALLINT NOT NULL DEFAULT 1,
+    -- NULL kalau file tidak punya GPS atau EXIF_REDACT_GPS aktif
+    gps_latitude DOUBLE PRECISION,
+    gps_longitude DOUBLE PRECISION,
+    gps_altitude DOUBLE PRECISION,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_image_metadata_captured_at ON image_metadata(captured_at);
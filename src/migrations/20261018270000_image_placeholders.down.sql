@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_image_assets_missing_placeholder;
+
+ALTER TABLE image_assets DROP COLUMN IF EXISTS palette;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS dominant_color;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS lqip;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS blurhash;
@@ -0,0 +1,8 @@
+-- Placeholder selama gambar dimuat: blurhash, LQIP (data URI kecil) dan palette warna dominan
+ALTER TABLE image_assets ADD COLUMN blurhash VARCHAR(64);
+ALTER TABLE image_assets ADD COLUMN lqip TEXT;
+ALTER TABLE image_assets ADD COLUMN dominant_color VARCHAR(7);
+ALTER TABLE image_assets ADD COLUMN palette JSONB;
+
+-- Dipakai command backfill untuk mencari asset lama yang belum punya placeholder
+CREATE INDEX idx_image_assets_missing_placeholder ON image_assets(id) WHERE blurhash IS NULL AND status = 'ready';
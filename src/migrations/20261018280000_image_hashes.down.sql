@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_image_assets_sha256;
+
+ALTER TABLE image_assets DROP COLUMN IF EXISTS phash;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS sha256;
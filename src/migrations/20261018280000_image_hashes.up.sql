@@ -0,0 +1,5 @@
+-- Hash isi file untuk deteksi duplikat: sha256 = salinan persis, phash (dHash 64-bit) = mirip secara visual
+ALTER TABLE image_assets ADD COLUMN sha256 VARCHAR(64);
+ALTER TABLE image_assets ADD COLUMN phash BIGINT;
+
+CREATE INDEX idx_image_assets_sha256 ON image_assets(sha256);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_content_translations_search_vector;
+
+ALTER TABLE content_translations DROP COLUMN IF EXISTS search_vector;
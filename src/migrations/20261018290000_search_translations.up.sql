@@ -0,0 +1,7 @@
+-- Terjemahan ikut dicari: bobot A untuk judul/nama/pertanyaan, B untuk deskripsi/jawaban
+ALTER TABLE content_translations ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(value, '')), CASE WHEN field IN ('title', 'name', 'question') THEN 'A'::"char" ELSE 'B'::"char" END) ||
+    setweight(to_tsvector('english'::regconfig, coalesce(value, '')), CASE WHEN field IN ('title', 'name', 'question') THEN 'A'::"char" ELSE 'B'::"char" END)
+) STORED;
+
+CREATE INDEX idx_content_translations_search_vector ON content_translations USING GIN (search_vector);
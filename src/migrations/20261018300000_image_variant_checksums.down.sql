@@ -0,0 +1 @@
+ALTER TABLE image_variants DROP COLUMN IF EXISTS crc32;
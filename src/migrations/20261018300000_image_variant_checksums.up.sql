@@ -0,0 +1,2 @@
+-- CRC32 variant supaya salinan ber-watermark bisa di-stream sebagai ZIP tanpa dibaca ulang
+ALTER TABLE image_variants ADD COLUMN crc32 BIGINT;
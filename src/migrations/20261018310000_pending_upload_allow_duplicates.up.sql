@@ -0,0 +1,3 @@
+-- Cek duplikat upload presigned dilakukan image worker setelah sha256 dihitung,
+-- jadi pilihan allow_duplicates saat confirm disimpan di sini
+ALTER TABLE pending_uploads ADD COLUMN allow_duplicates BOOLEAN NOT NULL DEFAULT false;
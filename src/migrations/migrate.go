@@ -0,0 +1,367 @@
+// Package migrations menyimpan file SQL migration (di-embed ke binary) beserta
+// runner-nya. Format tabel schema_migrations (version, dirty) sama dengan
+// golang-migrate, jadi CLI `migrate` lama tetap bisa dipakai di database yang sama.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/models"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// NilVersion berarti belum ada migration yang dijalankan
+const NilVersion int64 = -1
+
+// Sama dengan salt yang dipakai golang-migrate untuk advisory lock
+const advisoryLockIDSalt uint32 = 1486364155
+
+var ErrDirty = errors.New("database is dirty, fix it manually then run force")
+
+var filenamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	DownSQL string
+	hasUp   bool
+}
+
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+// Load membaca semua file migration yang di-embed, urut berdasarkan version
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := filenamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version}
+			byVersion[version] = m
+		}
+
+		if match[3] == "up" {
+			m.Name = match[2]
+			m.hasUp = true
+			m.UpSQL = string(content)
+		} else {
+			if m.Name == "" {
+				m.Name = match[2]
+			}
+			m.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if !m.hasUp {
+			return nil, fmt.Errorf("migration %d has no up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Runner menjalankan migration di satu koneksi yang memegang advisory lock
+type Runner struct {
+	conn       *sql.Conn
+	migrations []Migration
+	lockID     int64
+}
+
+// NewRunner membuka koneksi khusus dan mengambil advisory lock, supaya dua
+// container yang boot bersamaan tidak menjalankan migration yang sama.
+// Panggil Close setelah selesai untuk melepas lock.
+func NewRunner(ctx context.Context, db *sql.DB) (*Runner, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Runner{conn: conn, migrations: migrations}
+
+	if err := r.lock(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := r.ensureVersionTable(ctx); err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Runner) lock(ctx context.Context) error {
+	var databaseName, schemaName string
+	if err := r.conn.QueryRowContext(ctx, "SELECT CURRENT_DATABASE(), CURRENT_SCHEMA()").Scan(&databaseName, &schemaName); err != nil {
+		return fmt.Errorf("failed to read current database: %w", err)
+	}
+
+	// Rumus sama dengan golang-migrate supaya CLI lama dan runner ini saling mengunci
+	name := strings.Join([]string{schemaName, models.TableNameSchemaMigration, databaseName}, "\x00")
+	r.lockID = int64(crc32.ChecksumIEEE([]byte(name)) * advisoryLockIDSalt)
+
+	if _, err := r.conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", r.lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	return nil
+}
+
+func (r *Runner) Close() error {
+	_, err := r.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", r.lockID)
+	r.conn.Close()
+	return err
+}
+
+func (r *Runner) ensureVersionTable(ctx context.Context) error {
+	_, err := r.conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+models.TableNameSchemaMigration+` (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)`)
+	return err
+}
+
+// Version mengembalikan version saat ini dan status dirty
+func (r *Runner) Version(ctx context.Context) (int64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := r.conn.QueryRowContext(ctx, "SELECT version, dirty FROM "+models.TableNameSchemaMigration+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+func setVersion(ctx context.Context, tx *sql.Tx, version int64, dirty bool) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+models.TableNameSchemaMigration); err != nil {
+		return err
+	}
+	if version == NilVersion {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO "+models.TableNameSchemaMigration+" (version, dirty) VALUES ($1, $2)", version, dirty)
+	return err
+}
+
+// apply menjalankan satu file SQL dan update version dalam satu transaksi.
+// Kalau SQL gagal semuanya di-rollback, jadi version tetap bersih (tidak dirty).
+func (r *Runner) apply(ctx context.Context, query string, toVersion int64) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(query) != "" {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := setVersion(ctx, tx, toVersion, false); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Runner) indexOf(version int64) int {
+	for i, m := range r.migrations {
+		if m.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Runner) current(ctx context.Context) (int64, int, error) {
+	version, dirty, err := r.Version(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if dirty {
+		return 0, 0, fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+
+	if version == NilVersion {
+		return version, -1, nil
+	}
+
+	idx := r.indexOf(version)
+	if idx == -1 {
+		return 0, 0, fmt.Errorf("current version %d has no migration file", version)
+	}
+	return version, idx, nil
+}
+
+// Up menjalankan n migration berikutnya (n <= 0 berarti semua)
+func (r *Runner) Up(ctx context.Context, n int, logf func(string, ...any)) error {
+	_, idx, err := r.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	applied := 0
+	for i := idx + 1; i < len(r.migrations); i++ {
+		if n > 0 && applied >= n {
+			break
+		}
+
+		m := r.migrations[i]
+		logf("⬆️  %d_%s\n", m.Version, m.Name)
+		if err := r.apply(ctx, m.UpSQL, m.Version); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+		}
+
+		applied++
+	}
+
+	if applied == 0 {
+		logf("✅ No change\n")
+	}
+	return nil
+}
+
+// Down me-rollback n migration terakhir (n <= 0 berarti semua)
+func (r *Runner) Down(ctx context.Context, n int, logf func(string, ...any)) error {
+	_, idx, err := r.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	reverted := 0
+	for i := idx; i >= 0; i-- {
+		if n > 0 && reverted >= n {
+			break
+		}
+
+		m := r.migrations[i]
+		target := NilVersion
+		if i > 0 {
+			target = r.migrations[i-1].Version
+		}
+
+		logf("⬇️  %d_%s\n", m.Version, m.Name)
+		if err := r.apply(ctx, m.DownSQL, target); err != nil {
+			return fmt.Errorf("rollback %d_%s failed: %w", m.Version, m.Name, err)
+		}
+		reverted++
+	}
+
+	if reverted == 0 {
+		logf("✅ No change\n")
+	}
+	return nil
+}
+
+// Goto migrate naik atau turun sampai version tertentu
+func (r *Runner) Goto(ctx context.Context, target int64, logf func(string, ...any)) error {
+	targetIdx := -1
+	if target != NilVersion {
+		targetIdx = r.indexOf(target)
+		if targetIdx == -1 {
+			return fmt.Errorf("version %d not found", target)
+		}
+	}
+
+	_, idx, err := r.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	if targetIdx > idx {
+		return r.Up(ctx, targetIdx-idx, logf)
+	}
+	if targetIdx < idx {
+		return r.Down(ctx, idx-targetIdx, logf)
+	}
+
+	logf("✅ No change\n")
+	return nil
+}
+
+// Force set version tanpa menjalankan SQL, dipakai untuk membersihkan status dirty
+func (r *Runner) Force(ctx context.Context, version int64) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := setVersion(ctx, tx, version, false); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Status mengembalikan daftar migration beserta status applied-nya
+func (r *Runner) Status(ctx context.Context) ([]MigrationStatus, int64, bool, error) {
+	version, dirty, err := r.Version(ctx)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	statuses := make([]MigrationStatus, len(r.migrations))
+	for i, m := range r.migrations {
+		statuses[i] = MigrationStatus{
+			Version: m.Version,
+			Name:    m.Name,
+			Applied: version != NilVersion && m.Version <= version,
+		}
+	}
+
+	return statuses, version, dirty, nil
+}
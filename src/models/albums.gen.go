@@ -3,7 +3,7 @@ package models
 import (
 	"time"
 
-	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 const TableNameAlbum = "albums"
@@ -17,15 +17,24 @@ type Album struct {
 	CategoryID  int32          `gorm:"column:category_id" json:"category_id"`
 	Description string         `gorm:"column:description" json:"description"`
 	YoutubeURL  string         `gorm:"column:youtube_url" json:"youtube_url"`
-	Images      pq.StringArray `gorm:"type:text[]" json:"images"`
 	Thumbnail   string         `gorm:"column:thumbnail" json:"thumbnail"`
 	IsPublished bool           `gorm:"column:is_published" json:"is_published"`
 	CreatedAt   time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	UserID      int32          `gorm:"column:user_id" json:"user_id"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at"`
+	PublishAt   *time.Time     `gorm:"column:publish_at" json:"publish_at"`
+	UnpublishAt *time.Time     `gorm:"column:unpublish_at" json:"unpublish_at"`
+	Visibility  string         `gorm:"column:visibility;not null;default:public" json:"visibility"`
 
-	User     User     `gorm:"foreignKey:UserID" json:"user"`
-	Category Category `gorm:"foreignKey:CategoryID" json:"category"`
+	WatermarkMode     string   `gorm:"column:watermark_mode;not null;default:inherit" json:"watermark_mode"`
+	WatermarkPosition *string  `gorm:"column:watermark_position" json:"watermark_position"`
+	WatermarkOpacity  *float64 `gorm:"column:watermark_opacity" json:"watermark_opacity"`
+	WatermarkScale    *float64 `gorm:"column:watermark_scale" json:"watermark_scale"`
+
+	User     User         `gorm:"foreignKey:UserID" json:"user"`
+	Category Category     `gorm:"foreignKey:CategoryID" json:"category"`
+	Media    []AlbumMedia `gorm:"foreignKey:AlbumID" json:"media"`
 }
 
 // TableName Album's table name
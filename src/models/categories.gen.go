@@ -2,22 +2,32 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNameCategory = "categories"
 
 // Category mapped from table <categories>
 type Category struct {
-	ID          int32     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UUID        string    `gorm:"column:uuid;default:gen_random_uuid()" json:"uuid"`
-	Name        string    `gorm:"column:name" json:"name"`
-	Description string    `gorm:"column:description" json:"description"`
-	Slug        string    `gorm:"column:slug;uniqueIndex" json:"slug"`
-	YoutubeURL  string    `gorm:"column:youtube_url" json:"youtube_url"`
-	PhotoURL    string    `gorm:"column:photo_url" json:"photo_url"`
-	IsPublished bool      `gorm:"column:is_published" json:"is_published"`
-	CreatedAt   time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID          int32          `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UUID        string         `gorm:"column:uuid;default:gen_random_uuid()" json:"uuid"`
+	Name        string         `gorm:"column:name" json:"name"`
+	Description string         `gorm:"column:description" json:"description"`
+	Slug        string         `gorm:"column:slug;uniqueIndex" json:"slug"`
+	YoutubeURL  string         `gorm:"column:youtube_url" json:"youtube_url"`
+	PhotoURL    string         `gorm:"column:photo_url" json:"photo_url"`
+	IsPublished bool           `gorm:"column:is_published" json:"is_published"`
+	CreatedAt   time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at"`
+	PublishAt   *time.Time     `gorm:"column:publish_at" json:"publish_at"`
+	UnpublishAt *time.Time     `gorm:"column:unpublish_at" json:"unpublish_at"`
+
+	WatermarkMode     string   `gorm:"column:watermark_mode;not null;default:inherit" json:"watermark_mode"`
+	WatermarkPosition *string  `gorm:"column:watermark_position" json:"watermark_position"`
+	WatermarkOpacity  *float64 `gorm:"column:watermark_opacity" json:"watermark_opacity"`
+	WatermarkScale    *float64 `gorm:"column:watermark_scale" json:"watermark_scale"`
 }
 
 // TableName Category's table name
@@ -6,22 +6,27 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNameFaq = "faqs"
 
 // Faq mapped from table <faqs>
 type Faq struct {
-	ID          int32     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UUID        string    `gorm:"column:uuid;default:gen_random_uuid()" json:"uuid"`
-	QuestionID  string    `gorm:"column:question_id" json:"question_id"`
-	QuestionEn  string    `gorm:"column:question_en" json:"question_en"`
-	AnswerID    string    `gorm:"column:answer_id" json:"answer_id"`
-	AnswerEn    string    `gorm:"column:answer_en" json:"answer_en"`
-	OgImage     string    `gorm:"column:og_image" json:"og_image"`
-	IsPublished bool      `gorm:"column:is_published" json:"is_published"`
-	CreatedAt   time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID          int32          `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UUID        string         `gorm:"column:uuid;default:gen_random_uuid()" json:"uuid"`
+	QuestionID  string         `gorm:"column:question_id" json:"question_id"`
+	QuestionEn  string         `gorm:"column:question_en" json:"question_en"`
+	AnswerID    string         `gorm:"column:answer_id" json:"answer_id"`
+	AnswerEn    string         `gorm:"column:answer_en" json:"answer_en"`
+	OgImage     string         `gorm:"column:og_image" json:"og_image"`
+	IsPublished bool           `gorm:"column:is_published" json:"is_published"`
+	CreatedAt   time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at"`
+	PublishAt   *time.Time     `gorm:"column:publish_at" json:"publish_at"`
+	UnpublishAt *time.Time     `gorm:"column:unpublish_at" json:"unpublish_at"`
 }
 
 // TableName Faq's table name
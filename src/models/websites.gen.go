@@ -28,6 +28,11 @@ type Website struct {
 	UpdatedAt          time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	UUID               string    `gorm:"column:uuid;default:gen_random_uuid()" json:"uuid"`
 	URLTiktok          string    `gorm:"column:url_tiktok" json:"url_tiktok"`
+	WatermarkURL       string    `gorm:"column:watermark_url" json:"watermark_url"`
+	WatermarkEnabled   bool      `gorm:"column:watermark_enabled;not null" json:"watermark_enabled"`
+	WatermarkPosition  string    `gorm:"column:watermark_position;not null;default:bottom-right" json:"watermark_position"`
+	WatermarkOpacity   float64   `gorm:"column:watermark_opacity;not null;default:0.5" json:"watermark_opacity"`
+	WatermarkScale     float64   `gorm:"column:watermark_scale;not null;default:0.2" json:"watermark_scale"`
 }
 
 // TableName Website's table name
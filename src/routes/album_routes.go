@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/charis16/luminor-golang-be/src/controllers"
 	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,14 +12,39 @@ func AlbumRoutes(rg *gin.RouterGroup) {
 	albums.GET("/", controllers.GetLatestAlbum)
 	albums.GET("/category/:slug", controllers.GetAlbumByCategorySlug)
 	albums.GET("/detail/:slug", controllers.GetDetailAlbumBySlug)
+	albums.GET("/download/:slug", controllers.DownloadPublicAlbum)
+	albums.HEAD("/download/:slug", controllers.DownloadPublicAlbum)
 	// albums.GET("/portfolio/:slug", controllers.GetAlbumByPortfolioSlug)
-	albums.Use(middleware.AdminRequireAuth(), middleware.RequireRole("admin"))
+	albums.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermAlbumWriteOwn, utils.PermAlbumWriteAny))
 	{
 		albums.GET("/lists", controllers.GetAlbums)
+		albums.GET("/trash", controllers.GetTrashedAlbums)
+		albums.GET("/duplicates", controllers.GetDuplicateReport)
 		albums.GET("/:uuid", controllers.GetAlbumByUUID)
 		albums.PUT("/:uuid", controllers.EditAlbum)
 		albums.POST("/submit", controllers.CreateAlbum)
 		albums.DELETE("/:uuid", controllers.DeleteAlbum)
+		albums.PUT("/:uuid/restore", controllers.RestoreAlbum)
 		albums.PATCH("/images/:uuid", controllers.DeleteImageFromAlbum)
+		albums.PUT("/:uuid/media/order", controllers.ReorderAlbumMedia)
+		albums.PATCH("/:uuid/media/:media_uuid", controllers.EditAlbumMedia)
+		albums.PUT("/:uuid/media/:media_uuid/cover", controllers.SetAlbumCover)
+		albums.POST("/:uuid/uploads", controllers.CreateAlbumUploads)
+		albums.POST("/:uuid/uploads/confirm", controllers.ConfirmAlbumUploads)
+		albums.PUT("/:uuid/visibility", controllers.SetAlbumVisibility)
+		albums.PUT("/:uuid/watermark", controllers.SetAlbumWatermark)
+		albums.GET("/:uuid/duplicates", controllers.GetAlbumDuplicates)
+		albums.GET("/:uuid/links", controllers.GetGalleryLinks)
+		albums.POST("/:uuid/links", controllers.CreateGalleryLink)
+		albums.DELETE("/:uuid/links/:link_uuid", controllers.RevokeGalleryLink)
+		albums.GET("/:uuid/proofing", controllers.GetProofingLinks)
+		albums.POST("/:uuid/proofing", controllers.CreateProofingLink)
+		albums.GET("/:uuid/proofing/:link_uuid", controllers.GetProofingLink)
+		albums.GET("/:uuid/proofing/:link_uuid/export", controllers.ExportProofingSelection)
+		albums.PUT("/:uuid/proofing/:link_uuid/reopen", controllers.ReopenProofingLink)
+		albums.DELETE("/:uuid/proofing/:link_uuid", controllers.RevokeProofingLink)
+		albums.GET("/:uuid/downloads", controllers.GetAlbumDownloadLinks)
+		albums.POST("/:uuid/downloads", controllers.CreateAlbumDownloadLink)
+		albums.DELETE("/:uuid/downloads/:link_uuid", controllers.RevokeAlbumDownloadLink)
 	}
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditRoutes(rg *gin.RouterGroup) {
+	audit := rg.Group("/audit-events")
+	audit.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermAuditRead))
+	{
+		audit.GET("/lists", controllers.GetAuditEvents)
+	}
+}
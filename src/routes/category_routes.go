@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/charis16/luminor-golang-be/src/controllers"
 	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,13 +12,16 @@ func CategoryRoutes(rg *gin.RouterGroup) {
 	category.GET("/", controllers.GetPublishedCategories)
 	category.GET("/options", controllers.GetCategoryOptions)
 	category.GET("/website/:slug", controllers.GetCategoryBySlug)
-	category.Use(middleware.AdminRequireAuth(), middleware.RequireRole("admin"))
+	category.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermCategoryWrite))
 	{
 		category.GET("/lists", controllers.GetCategories)
+		category.GET("/trash", controllers.GetTrashedCategories)
 		category.GET("/:uuid", controllers.GetCategoryByUUID)
 		category.PUT("/:uuid", controllers.EditCategory)
 		category.POST("/submit", controllers.CreateCategory)
 		category.DELETE("/:uuid", controllers.DeleteCategory)
+		category.PUT("/:uuid/restore", controllers.RestoreCategory)
 		category.PATCH("/:uuid", controllers.DeleteImageCategory)
+		category.PUT("/:uuid/watermark", controllers.SetCategoryWatermark)
 	}
 }
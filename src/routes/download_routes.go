@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadRoutes endpoint publik download ZIP album lewat token link download
+func DownloadRoutes(rg *gin.RouterGroup) {
+	downloads := rg.Group("/downloads")
+	downloads.GET("/:token", controllers.DownloadAlbumByLink)
+	downloads.HEAD("/:token", controllers.DownloadAlbumByLink)
+}
@@ -3,18 +3,21 @@ package routes
 import (
 	"github.com/charis16/luminor-golang-be/src/controllers"
 	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
 func FaqRoutes(rg *gin.RouterGroup) {
 	faq := rg.Group("/faqs")
 	faq.GET("/", controllers.GetPublishedFaqs)
-	faq.Use(middleware.AdminRequireAuth(), middleware.RequireRole("admin"))
+	faq.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermFaqWrite))
 	{
 		faq.GET("/lists", controllers.GetFaqs)
+		faq.GET("/trash", controllers.GetTrashedFaqs)
 		faq.GET("/:uuid", controllers.GetFaqByUUID)
 		faq.PUT("/:uuid", controllers.EditFaq)
 		faq.POST("/submit", controllers.CreateFaq)
 		faq.DELETE("/:uuid", controllers.DeleteFaq)
+		faq.PUT("/:uuid/restore", controllers.RestoreFaq)
 	}
 }
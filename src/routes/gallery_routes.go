@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// GalleryRoutes endpoint publik gallery private, akses lewat token link share
+func GalleryRoutes(rg *gin.RouterGroup) {
+	gallery := rg.Group("/galleries")
+	gallery.GET("/:token", controllers.OpenGallery)
+	gallery.POST("/:token/unlock", controllers.UnlockGallery)
+}
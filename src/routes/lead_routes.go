@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func LeadRoutes(rg *gin.RouterGroup) {
+	lead := rg.Group("/leads")
+	lead.POST("/inquiry", controllers.SubmitInquiry)
+	lead.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermLeadManageOwn, utils.PermLeadManageAny))
+	{
+		lead.GET("/lists", controllers.GetLeads)
+		lead.GET("/:uuid", controllers.GetLeadByUUID)
+		lead.PUT("/:uuid/status", controllers.UpdateLeadStatus)
+		lead.PUT("/:uuid/assign", controllers.AssignLead)
+		lead.POST("/:uuid/notes", controllers.AddLeadNote)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// ProofingRoutes endpoint publik untuk client memilih foto, akses lewat token link proofing
+func ProofingRoutes(rg *gin.RouterGroup) {
+	proofing := rg.Group("/proofing")
+	proofing.GET("/:token", controllers.OpenProofing)
+	proofing.POST("/:token/lists", controllers.CreateProofingList)
+	proofing.DELETE("/:token/lists/:list_uuid", controllers.DeleteProofingList)
+	proofing.PUT("/:token/lists/:list_uuid/items", controllers.SetProofingItem)
+	proofing.DELETE("/:token/lists/:list_uuid/items/:media_uuid", controllers.RemoveProofingItem)
+	proofing.POST("/:token/submit", controllers.SubmitProofing)
+}
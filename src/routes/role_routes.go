@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func RoleRoutes(rg *gin.RouterGroup) {
+	roles := rg.Group("/roles")
+	roles.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermUserManage))
+	{
+		roles.GET("/lists", controllers.GetRoles)
+		roles.GET("/permissions", controllers.GetPermissions)
+		roles.POST("/submit", controllers.CreateRole)
+		roles.PUT("/:uuid", controllers.EditRole)
+		roles.DELETE("/:uuid", controllers.DeleteRole)
+	}
+}
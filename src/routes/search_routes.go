@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func SearchRoutes(rg *gin.RouterGroup) {
+	rg.GET("/search", controllers.Search)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// SeoRoutes sitemap, robots.txt dan feed publik untuk di-proxy oleh frontend
+func SeoRoutes(rg *gin.RouterGroup) {
+	rg.GET("/robots.txt", controllers.GetRobotsTxt)
+	rg.GET("/sitemap.xml", controllers.GetSitemapIndex)
+	rg.GET("/sitemaps/:name", controllers.GetSitemap)
+
+	feeds := rg.Group("/feeds")
+	feeds.GET("/:format", controllers.GetAlbumFeed)
+	feeds.GET("/categories/:slug/:format", controllers.GetCategoryAlbumFeed)
+	feeds.GET("/photographers/:slug/:format", controllers.GetPhotographerAlbumFeed)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/charis16/luminor-golang-be/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// StorageRoutes hanya dipasang saat memakai local storage,
+// karena R2 sudah menyajikan file lewat R2_PUBLIC_URL
+func StorageRoutes(rg *gin.RouterGroup) {
+	storage := rg.Group("/storage")
+	{
+		storage.GET("/*key", controllers.ServeStorageObject)
+		storage.PUT("/*key", controllers.UploadStorageObject)
+	}
+}
+
+// PrivateStorageRoutes dipasang saat private storage memakai local disk.
+// Semua request wajib membawa signature (GET dari signed URL, PUT dari presigned upload).
+func PrivateStorageRoutes(rg *gin.RouterGroup) {
+	storage := rg.Group("/private-storage")
+	{
+		storage.GET("/*key", controllers.ServePrivateStorageObject)
+		storage.PUT("/*key", controllers.UploadPrivateStorageObject)
+	}
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/charis16/luminor-golang-be/src/controllers"
 	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,13 +12,18 @@ func UserRoutes(rg *gin.RouterGroup) {
 	users.GET("/team-members", controllers.GetTeamMembers)
 	users.GET("/options", controllers.GetUserOptions)
 	users.GET("/website/:slug", controllers.GetUserPortfolioBySlug)
-	users.Use(middleware.AdminRequireAuth(), middleware.RequireRole("admin"))
+	users.Use(middleware.AdminRequireAuth(), middleware.RequirePermission(utils.PermUserManage))
 	{
 		users.GET("/lists", controllers.GetUsers)
+		users.GET("/trash", controllers.GetTrashedUsers)
 		users.GET("/:uuid", controllers.GetUserByUUID)
 		users.PUT("/:uuid", controllers.EditUser)
 		users.POST("/submit", controllers.CreateUser)
 		users.DELETE("/:uuid", controllers.DeleteUser)
+		users.PUT("/:uuid/restore", controllers.RestoreUser)
 		users.PATCH("/:uuid", controllers.DeleteImageUser)
+		users.GET("/:uuid/sessions", controllers.GetUserSessions)
+		users.DELETE("/:uuid/sessions", controllers.RevokeAllUserSessions)
+		users.DELETE("/:uuid/sessions/:session_uuid", controllers.RevokeUserSession)
 	}
 }
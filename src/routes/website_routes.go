@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/charis16/luminor-golang-be/src/controllers"
 	"github.com/charis16/luminor-golang-be/src/middleware"
+	"github.com/charis16/luminor-golang-be/src/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,11 +16,13 @@ func WebsiteRoutes(rg *gin.RouterGroup) {
 	adminOnly := websites.Group("/")
 	adminOnly.Use(
 		middleware.AdminRequireAuth(),
-		middleware.RequireRole("admin"),
+		middleware.RequirePermission(utils.PermWebsiteWrite),
 	)
 	{
 		adminOnly.POST("/submit", controllers.CreateWebsiteInformation)
 		adminOnly.PUT("/:uuid", controllers.EditWebsiteInformation)
 		adminOnly.PATCH("/:status/:uuid", controllers.DeleteWebsiteInformation)
+		adminOnly.PUT("/:uuid/watermark", controllers.UpdateWebsiteWatermark)
+		adminOnly.POST("/watermark/rerender", controllers.RerenderWatermarks)
 	}
 }
@@ -0,0 +1,25 @@
+package services
+
+import "errors"
+
+var ErrForbidden = errors.New("forbidden: insufficient permission")
+
+// Actor adalah user yang sedang melakukan request admin
+type Actor struct {
+	UserUUID    string
+	Role        string
+	Permissions []string
+
+	// Dicatat di audit log
+	IPAddress string
+	UserAgent string
+}
+
+func (a Actor) Can(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
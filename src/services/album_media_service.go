@@ -0,0 +1,331 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAlbumMediaNotFound   = errors.New("media not found")
+	ErrMediaVisibilityMatch = errors.New("media storage does not match album visibility")
+)
+
+type AlbumMediaOrderInput struct {
+	Media []string `json:"media" validate:"required,min=1,dive,required"`
+}
+
+// Field nil berarti tidak diubah
+type AlbumMediaInput struct {
+	CaptionEn *string `json:"caption_en"`
+	CaptionID *string `json:"caption_id"`
+	AltText   *string `json:"alt_text"`
+}
+
+func orderAlbumMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+func AlbumMediaURLs(album models.Album) []string {
+	urls := make([]string, len(album.Media))
+	for i, m := range album.Media {
+		urls[i] = m.URL
+	}
+	return urls
+}
+
+func MapAlbumMediaListToDTO(media []models.AlbumMedia) []dto.AlbumMediaResponse {
+	response := make([]dto.AlbumMediaResponse, len(media))
+	for i, m := range media {
+		response[i] = mapAlbumMediaToDTO(m)
+	}
+	return response
+}
+
+func albumCoverURL(album models.Album) string {
+	for _, m := range album.Media {
+		if m.IsCover {
+			return m.URL
+		}
+	}
+	return ""
+}
+
+func mapAlbumMediaToDTO(m models.AlbumMedia) dto.AlbumMediaResponse {
+	return dto.AlbumMediaResponse{
+		UUID:      m.UUID,
+		URL:       m.URL,
+		Position:  m.Position,
+		CaptionEn: m.CaptionEn,
+		CaptionID: m.CaptionID,
+		AltText:   m.AltText,
+		Width:     m.Width,
+		Height:    m.Height,
+		MimeType:  m.MimeType,
+		IsCover:   m.IsCover,
+	}
+}
+
+// addAlbumMedia menambahkan URL baru ke belakang album. URL yang sudah ada dilewati.
+func addAlbumMedia(tx *gorm.DB, album models.Album, urls []string) ([]models.AlbumMedia, error) {
+	existing := map[string]bool{}
+	var maxPosition int32 = -1
+	for _, m := range album.Media {
+		existing[m.URL] = true
+		if m.Position > maxPosition {
+			maxPosition = m.Position
+		}
+	}
+
+	var newURLs []string
+	for _, url := range urls {
+		url = strings.Trim(strings.TrimSpace(url), `"`)
+		if url == "" || existing[url] {
+			continue
+		}
+		existing[url] = true
+		newURLs = append(newURLs, url)
+	}
+
+	if len(newURLs) == 0 {
+		return nil, nil
+	}
+
+	// Ukuran dan mime type diambil dari antrian derivative kalau sudah ada
+	var assets []models.ImageAsset
+	if err := tx.Where("url IN ?", newURLs).Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get image assets: %v", err)
+	}
+	assetByURL := map[string]models.ImageAsset{}
+	for _, asset := range assets {
+		assetByURL[asset.URL] = asset
+	}
+
+	media := make([]models.AlbumMedia, 0, len(newURLs))
+	for i, url := range newURLs {
+		key, private, err := utils.ResolveObjectURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid media url %s: %v", url, err)
+		}
+		// File album private harus ada di private storage, begitu juga sebaliknya
+		if private != IsPrivateAlbum(album) {
+			return nil, fmt.Errorf("%w: %s", ErrMediaVisibilityMatch, url)
+		}
+
+		asset := assetByURL[url]
+		media = append(media, models.AlbumMedia{
+			AlbumID:    album.ID,
+			StorageKey: key,
+			URL:        url,
+			Position:   maxPosition + 1 + int32(i),
+			Width:      asset.Width,
+			Height:     asset.Height,
+			MimeType:   asset.MimeType,
+			Bytes:      asset.Bytes,
+			Crc32:      asset.Crc32,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+
+	if err := tx.Create(&media).Error; err != nil {
+		return nil, fmt.Errorf("failed to save album media: %v", err)
+	}
+
+	// Gambar album publik dicek worker watermark
+	if !IsPrivateAlbum(album) {
+		if err := queueWatermarkForURLs(tx, newURLs); err != nil {
+			return nil, err
+		}
+	}
+
+	return media, nil
+}
+
+// getAuthorizedAlbum mengambil album beserta media-nya dan cek hak akses actor
+func getAuthorizedAlbum(tx *gorm.DB, albumUUID string, actor Actor) (models.Album, error) {
+	var album models.Album
+	if err := tx.
+		Preload("User").
+		Preload("Media", orderAlbumMedia).
+		Where("uuid = ?", albumUUID).
+		First(&album).Error; err != nil {
+		return models.Album{}, fmt.Errorf("album not found")
+	}
+
+	if err := AuthorizeAlbum(album, actor); err != nil {
+		return models.Album{}, err
+	}
+
+	return album, nil
+}
+
+// ReorderAlbumMedia menyimpan urutan baru. Daftar harus berisi semua media album.
+func ReorderAlbumMedia(albumUUID string, input AlbumMediaOrderInput, actor Actor) ([]dto.AlbumMediaResponse, error) {
+	var response []dto.AlbumMediaResponse
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock album supaya dua reorder bersamaan tidak saling menimpa
+		var locked models.Album
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", albumUUID).First(&locked).Error; err != nil {
+			return fmt.Errorf("album not found")
+		}
+
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		if len(input.Media) != len(album.Media) {
+			return fmt.Errorf("media list must contain all %d album media", len(album.Media))
+		}
+
+		byUUID := map[string]models.AlbumMedia{}
+		for _, m := range album.Media {
+			byUUID[m.UUID] = m
+		}
+
+		seen := map[string]bool{}
+		ordered := make([]models.AlbumMedia, 0, len(input.Media))
+		for i, mediaUUID := range input.Media {
+			m, ok := byUUID[mediaUUID]
+			if !ok || seen[mediaUUID] {
+				return fmt.Errorf("invalid media in list: %s", mediaUUID)
+			}
+			seen[mediaUUID] = true
+
+			if m.Position != int32(i) {
+				if err := tx.Model(&models.AlbumMedia{}).
+					Where("id = ?", m.ID).
+					Updates(map[string]interface{}{"position": i, "updated_at": time.Now()}).Error; err != nil {
+					return fmt.Errorf("failed to update media position: %v", err)
+				}
+				m.Position = int32(i)
+			}
+			ordered = append(ordered, m)
+		}
+
+		reordered := album
+		reordered.Media = ordered
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, album.UUID, albumAuditSnapshot(album), albumAuditSnapshot(reordered)); err != nil {
+			return err
+		}
+
+		response = MapAlbumMediaListToDTO(ordered)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func findAlbumMedia(album models.Album, mediaUUID string) (models.AlbumMedia, error) {
+	for _, m := range album.Media {
+		if m.UUID == mediaUUID {
+			return m, nil
+		}
+	}
+	return models.AlbumMedia{}, ErrAlbumMediaNotFound
+}
+
+// UpdateAlbumMedia mengubah caption dan alt text tanpa upload ulang
+func UpdateAlbumMedia(albumUUID string, mediaUUID string, input AlbumMediaInput, actor Actor) (dto.AlbumMediaResponse, error) {
+	var response dto.AlbumMediaResponse
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		media, err := findAlbumMedia(album, mediaUUID)
+		if err != nil {
+			return err
+		}
+		before := auditSnapshot(media)
+
+		updates := map[string]interface{}{"updated_at": time.Now()}
+		if input.CaptionEn != nil {
+			media.CaptionEn = strings.TrimSpace(*input.CaptionEn)
+			updates["caption_en"] = media.CaptionEn
+		}
+		if input.CaptionID != nil {
+			media.CaptionID = strings.TrimSpace(*input.CaptionID)
+			updates["caption_id"] = media.CaptionID
+		}
+		if input.AltText != nil {
+			media.AltText = strings.TrimSpace(*input.AltText)
+			updates["alt_text"] = media.AltText
+		}
+
+		if err := tx.Model(&models.AlbumMedia{}).Where("id = ?", media.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update media: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbumMedia, media.UUID, before, auditSnapshot(media)); err != nil {
+			return err
+		}
+
+		response = mapAlbumMediaToDTO(media)
+		return nil
+	})
+
+	return response, err
+}
+
+// SetAlbumCover menjadikan satu media sebagai cover album
+func SetAlbumCover(albumUUID string, mediaUUID string, actor Actor) (dto.AlbumMediaResponse, error) {
+	var response dto.AlbumMediaResponse
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		media, err := findAlbumMedia(album, mediaUUID)
+		if err != nil {
+			return err
+		}
+
+		// Lepas cover lama dulu karena ada unique index per album
+		if err := tx.Model(&models.AlbumMedia{}).
+			Where("album_id = ? AND is_cover AND id != ?", album.ID, media.ID).
+			Updates(map[string]interface{}{"is_cover": false, "updated_at": time.Now()}).Error; err != nil {
+			return fmt.Errorf("failed to reset cover: %v", err)
+		}
+
+		if err := tx.Model(&models.AlbumMedia{}).
+			Where("id = ?", media.ID).
+			Updates(map[string]interface{}{"is_cover": true, "updated_at": time.Now()}).Error; err != nil {
+			return fmt.Errorf("failed to set cover: %v", err)
+		}
+
+		media.IsCover = true
+
+		updated := album
+		updated.Media = make([]models.AlbumMedia, len(album.Media))
+		for i, m := range album.Media {
+			m.IsCover = m.ID == media.ID
+			updated.Media[i] = m
+		}
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, album.UUID, albumAuditSnapshot(album), albumAuditSnapshot(updated)); err != nil {
+			return err
+		}
+
+		response = mapAlbumMediaToDTO(media)
+		return nil
+	})
+
+	return response, err
+}
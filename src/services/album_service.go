@@ -1,7 +1,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -9,8 +11,16 @@ import (
 	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/models"
 	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
 )
 
+const (
+	AlbumVisibilityPublic  = "public"
+	AlbumVisibilityPrivate = "private"
+)
+
+var ErrInvalidAlbumVisibility = errors.New("visibility must be public or private")
+
 type AlbumInput struct {
 	Slug        string   `form:"slug"`
 	Title       string   `form:"title" binding:"required"`
@@ -19,15 +29,137 @@ type AlbumInput struct {
 	UserID      string   `form:"user_id" binding:"required"`
 	IsPublished string   `form:"is_published" binding:"required"`
 	YoutubeURL  string   `form:"youtube_url"`
-	Images      []string `form:"-"` // handled manually
-	Thumbnail   string   `form:"-"` // handled manually
+	Visibility  string   `form:"visibility"` // hanya dipakai saat create, ubah lewat SetAlbumVisibility
+	Images      []string `form:"-"`          // handled manually
+	Thumbnail   string   `form:"-"`          // handled manually
+
+	PublishAt   *time.Time `form:"-"` // handled manually
+	UnpublishAt *time.Time `form:"-"` // handled manually
+
+	Translations Translations `form:"-"` // handled manually, nil = tidak diubah
 }
 
 type DeleteImageRequest struct {
 	ImageURL string `json:"image_url" binding:"required"`
 }
 
-func mapAlbumToDTO(album models.Album) dto.AlbumResponse {
+func IsPrivateAlbum(album models.Album) bool {
+	return album.Visibility == AlbumVisibilityPrivate
+}
+
+// ParseAlbumVisibility: kosong = public. Private butuh private storage yang sudah dikonfigurasi.
+func ParseAlbumVisibility(value string) (string, error) {
+	switch value {
+	case "", AlbumVisibilityPublic:
+		return AlbumVisibilityPublic, nil
+	case AlbumVisibilityPrivate:
+		if !utils.PrivateStorageEnabled() {
+			return "", utils.ErrPrivateStorageDisabled
+		}
+		return AlbumVisibilityPrivate, nil
+	}
+	return "", ErrInvalidAlbumVisibility
+}
+
+// publicAlbums: album yang tampil di halaman publik (sudah publish dan bukan gallery private)
+func publicAlbums(table string) func(db *gorm.DB) *gorm.DB {
+	prefix := ""
+	if table != "" {
+		prefix = table + "."
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(publishedNow(table)).Where(prefix+"visibility = ?", AlbumVisibilityPublic)
+	}
+}
+
+// checkAlbumFileVisibility memastikan file album ada di storage yang sesuai visibility-nya
+func checkAlbumFileVisibility(album models.Album, fileURL string) error {
+	if fileURL == "" {
+		return nil
+	}
+	_, private, err := utils.ResolveObjectURL(fileURL)
+	if err != nil {
+		// URL eksternal hanya boleh untuk album public
+		private = false
+	}
+	if private != IsPrivateAlbum(album) {
+		return fmt.Errorf("%w: %s", ErrMediaVisibilityMatch, fileURL)
+	}
+	return nil
+}
+
+// mapAlbumsToDTO mengambil derivative semua gambar album dalam satu query
+func mapAlbumsToDTO(albums []models.Album) []dto.AlbumResponse {
+	var urls []string
+	for _, album := range albums {
+		urls = append(urls, AlbumMediaURLs(album)...)
+		if album.Thumbnail != "" {
+			urls = append(urls, album.Thumbnail)
+		}
+	}
+
+	images, err := GetResponsiveImages(urls)
+	if err != nil {
+		// Tetap kirim URL original kalau derivative gagal diambil
+		log.Printf("⚠️ %v\n", err)
+	}
+
+	metadata, err := GetImageMetadata(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+	}
+
+	var avatars []string
+	for _, album := range albums {
+		if album.User.Photo != "" {
+			avatars = append(avatars, album.User.Photo)
+		}
+	}
+	placeholders, err := GetImagePlaceholders(avatars)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+	}
+
+	response := make([]dto.AlbumResponse, len(albums))
+	for i, album := range albums {
+		response[i] = mapAlbumToDTO(album, images, metadata)
+		response[i].UserAvatarPlaceholder = ImagePlaceholder(placeholders, album.User.Photo)
+	}
+	return response
+}
+
+func mapAlbumToDTO(album models.Album, images map[string]dto.ResponsiveImageResponse, metadata map[string]dto.ImageMetadataResponse) dto.AlbumResponse {
+	imageSet := make([]dto.ResponsiveImageResponse, len(album.Media))
+	media := MapAlbumMediaListToDTO(album.Media)
+	for i, m := range album.Media {
+		imageSet[i] = ResponsiveImage(images, m.URL)
+		media[i].Placeholder = imageSet[i].Placeholder
+		if data, ok := metadata[m.URL]; ok {
+			media[i].Metadata = &data
+		}
+	}
+
+	// Thumbnail yang di-upload khusus tetap dipakai, kalau kosong pakai cover
+	thumbnailURL := album.Thumbnail
+	if thumbnailURL == "" {
+		thumbnailURL = albumCoverURL(album)
+	}
+
+	var thumbnailSet *dto.ResponsiveImageResponse
+	if thumbnailURL != "" {
+		thumbnail := ResponsiveImage(images, thumbnailURL)
+		thumbnailSet = &thumbnail
+	}
+
+	// Album private: URL tampilan diganti signed URL, Images/Thumbnail tetap private:// untuk edit
+	if IsPrivateAlbum(album) {
+		var err error
+		if imageSet, thumbnailSet, err = signAlbumImages(imageSet, thumbnailSet); err != nil {
+			log.Printf("⚠️ Failed to sign album %s images: %v\n", album.UUID, err)
+		}
+	}
+
 	return dto.AlbumResponse{
 		UUID:         album.UUID,
 		Slug:         album.Slug,
@@ -37,57 +169,83 @@ func mapAlbumToDTO(album models.Album) dto.AlbumResponse {
 		CategorySlug: album.Category.Slug,
 		Description:  album.Description,
 		YoutubeURL:   album.YoutubeURL,
-		Images:       album.Images,
-		Thumbnail:    album.Thumbnail,
+		Visibility:   album.Visibility,
+		Images:       AlbumMediaURLs(album),
+		Media:        media,
+		Thumbnail:    thumbnailURL,
 		IsPublished:  album.IsPublished,
+		PublishAt:    album.PublishAt,
+		UnpublishAt:  album.UnpublishAt,
+		Status:       ContentStatus(album.IsPublished, album.PublishAt, album.UnpublishAt),
 		CreatedAt:    album.CreatedAt,
 		UpdatedAt:    album.UpdatedAt,
 		UserID:       album.User.UUID,
 		UserName:     album.User.Name,
 		UserAvatar:   album.User.Photo,
 		UserSlug:     album.User.Slug,
+		ImageSet:     imageSet,
+		ThumbnailSet: thumbnailSet,
 	}
 }
 
-func GetLatestAlbums() ([]dto.AlbumResponse, error) {
+func signAlbumImages(imageSet []dto.ResponsiveImageResponse, thumbnailSet *dto.ResponsiveImageResponse) ([]dto.ResponsiveImageResponse, *dto.ResponsiveImageResponse, error) {
+	signed := make([]dto.ResponsiveImageResponse, len(imageSet))
+	for i, img := range imageSet {
+		s, err := signResponsiveImage(img)
+		if err != nil {
+			return imageSet, thumbnailSet, err
+		}
+		signed[i] = s
+	}
+
+	if thumbnailSet == nil {
+		return signed, nil, nil
+	}
+	thumbnail, err := signResponsiveImage(*thumbnailSet)
+	if err != nil {
+		return imageSet, thumbnailSet, err
+	}
+	return signed, &thumbnail, nil
+}
+
+func GetLatestAlbums(locale string) ([]dto.AlbumResponse, error) {
 	var albums []models.Album
 	if err := config.DB.
 		Preload("User").
 		Preload("Category").
+		Preload("Media", orderAlbumMedia).
 		Order("created_at DESC").
-		Where("is_published = ?", true).
+		Scopes(publicAlbums("")).
 		Limit(20).
 		Find(&albums).Error; err != nil {
 		return []dto.AlbumResponse{}, err
 	}
 
-	var items []dto.AlbumResponse
-	for _, album := range albums {
-		items = append(items, mapAlbumToDTO(album))
+	if err := localizeAlbums(albums, locale); err != nil {
+		return []dto.AlbumResponse{}, err
 	}
 
-	return items, nil
+	return mapPublicAlbumsToDTO(albums), nil
 }
 
-func GetAlbumByCategorySlug(slug string, nextTime int,
-	limit int, filter string) (dto.AlbumResponseList, error) {
-	var albums []models.Album
-	var nextTimestamp int64
+func albumCursorKey(album models.Album, column string) (interface{}, int32) {
+	return modelCursorKey(album.ID, album.CreatedAt, album.UpdatedAt, album.Title, column)
+}
+
+// GetAlbumByCategorySlug: feed publik dengan keyset pagination, filter = slug fotografer
+func GetAlbumByCategorySlug(slug string, params CursorParams, filter string, locale string) (dto.AlbumResponseList, error) {
+	empty := dto.AlbumResponseList{Data: []dto.AlbumResponse{}}
 
 	query := config.DB.
 		Preload("User").
 		Preload("Category").
-		Where("is_published = ?", true).
-		Order("created_at DESC").
-		Limit(limit)
+		Preload("Media", orderAlbumMedia).
+		Scopes(publicAlbums(""))
 
 	if slug != "" && slug != "all" {
 		var category models.Category
 		if err := config.DB.Where("slug = ?", slug).First(&category).Error; err != nil {
-			return dto.AlbumResponseList{
-				Data:      []dto.AlbumResponse{},
-				NextValue: 999999999,
-			}, err
+			return empty, err
 		}
 		query = query.Where("category_id = ?", category.ID)
 	}
@@ -95,78 +253,110 @@ func GetAlbumByCategorySlug(slug string, nextTime int,
 	if filter != "" && filter != "all" {
 		var user models.User
 		if err := config.DB.Where("slug = ?", filter).First(&user).Error; err != nil {
-			return dto.AlbumResponseList{
-				Data:      []dto.AlbumResponse{},
-				NextValue: 999999999,
-			}, err
+			return empty, err
 		}
 		query = query.Where("user_id = ?", user.ID)
 	}
 
-	if nextTime != 0 {
-		query = query.Where("UNIX_TIMESTAMP(created_at) < ?", nextTime)
-	}
-
-	if err := query.Find(&albums).Error; err != nil {
-		return dto.AlbumResponseList{
-			Data:      []dto.AlbumResponse{},
-			NextValue: 999999999,
-		}, err
-	}
-
-	if len(albums) == 0 {
-		return dto.AlbumResponseList{
-			Data:      []dto.AlbumResponse{},
-			NextValue: 999999999,
-		}, nil
+	albums, page, err := paginateByCursor(query, params, cursorSorts("title"), albumCursorKey)
+	if err != nil {
+		return empty, err
 	}
-
-	nextTimestamp = albums[len(albums)-1].CreatedAt.Unix()
-
-	var items []dto.AlbumResponse
-	for _, album := range albums {
-		items = append(items, mapAlbumToDTO(album))
+	if err := localizeAlbums(albums, locale); err != nil {
+		return empty, err
 	}
 
 	return dto.AlbumResponseList{
-		Data:      items,
-		NextValue: nextTimestamp,
+		Data:    mapPublicAlbumsToDTO(albums),
+		Next:    page.Next,
+		HasMore: page.HasMore,
 	}, nil
 }
 
-func GetDetailAlbumBySlug(slug string) (dto.AlbumResponse, error) {
+func GetDetailAlbumBySlug(slug string, locale string) (dto.AlbumResponse, error) {
 	var album models.Album
 	if err := config.DB.
 		Preload("User").
 		Preload("Category").
-		Where("slug = ? AND is_published = ?", slug, true).
+		Preload("Media", orderAlbumMedia).
+		Where("slug = ?", slug).
+		Scopes(publicAlbums("")).
 		First(&album).Error; err != nil {
 		return dto.AlbumResponse{}, err
 	}
 
-	return mapAlbumToDTO(album), nil
+	albums := []models.Album{album}
+	if err := localizeAlbums(albums, locale); err != nil {
+		return dto.AlbumResponse{}, err
+	}
+	return mapPublicAlbumsToDTO(albums)[0], nil
 }
 
-func GetAllAlbums(page int, limit int, search string) ([]dto.AlbumResponse, int64, error) {
-	var albums []models.Album
-	var total int64
+// AuthorizeAlbum memastikan actor boleh mengubah album ini
+func AuthorizeAlbum(album models.Album, actor Actor) error {
+	if actor.Can(utils.PermAlbumWriteAny) {
+		return nil
+	}
+	if actor.Can(utils.PermAlbumWriteOwn) && album.User.UUID == actor.UserUUID {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// AuthorizeAlbumOwner memastikan actor boleh menaruh album atas nama userUUID
+func AuthorizeAlbumOwner(userUUID string, actor Actor) error {
+	if actor.Can(utils.PermAlbumWriteAny) {
+		return nil
+	}
+	if actor.Can(utils.PermAlbumWriteOwn) && userUUID == actor.UserUUID {
+		return nil
+	}
+	return ErrForbidden
+}
 
+// AlbumListFilter filter list album admin. ShotYear/Camera/Lens dicocokkan dengan EXIF foto album.
+type AlbumListFilter struct {
+	Search   string `form:"search"`
+	ShotYear int    `form:"shot_year" binding:"omitempty,min=1900,max=2100"`
+	Camera   string `form:"camera"`
+	Lens     string `form:"lens"`
+}
+
+// albumListQuery filter yang sama untuk list admin (offset maupun cursor)
+func albumListQuery(filter AlbumListFilter, actor Actor) *gorm.DB {
 	query := config.DB.Model(&models.Album{})
 
-	// Apply search filter if search term is provided
-	if search != "" {
-		searchTerm := "%" + search + "%"
-		query = query.Where("title LIKE ?", searchTerm)
+	// Photographer hanya melihat album miliknya sendiri
+	if !actor.Can(utils.PermAlbumWriteAny) {
+		query = query.Where("user_id = (?)", config.DB.Table("users").Select("id").Where("uuid = ?", actor.UserUUID))
+	}
+
+	// Full-text search, ILIKE untuk kata yang belum lengkap
+	if filter.Search != "" {
+		query = query.Scopes(adminSearch(filter.Search, "title"))
 	}
+	return query.Scopes(albumMetadataFilter(filter))
+}
+
+func GetAllAlbums(page int, limit int, filter AlbumListFilter, actor Actor) ([]dto.AlbumResponse, int64, error) {
+	var albums []models.Album
+	var total int64
+
+	query := albumListQuery(filter, actor)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if filter.Search != "" {
+		query = query.Order(adminSearchOrder(filter.Search))
+	}
+
 	offset := (page - 1) * limit
 	if err := query.
 		Preload("User").
 		Preload("Category").
+		Preload("Media", orderAlbumMedia).
 		Limit(limit).
 		Offset(offset).
 		Find(&albums).Error; err != nil {
@@ -174,15 +364,27 @@ func GetAllAlbums(page int, limit int, search string) ([]dto.AlbumResponse, int6
 	}
 
 	// Mapping ke response DTO
-	response := make([]dto.AlbumResponse, len(albums))
-	for i, album := range albums {
-		response[i] = mapAlbumToDTO(album)
-	}
+	return mapAlbumsToDTO(albums), total, nil
+}
 
-	return response, total, nil
+func GetAlbumsByCursor(params CursorParams, filter AlbumListFilter, actor Actor) ([]dto.AlbumResponse, dto.CursorPageResponse, error) {
+	query := albumListQuery(filter, actor).
+		Preload("User").
+		Preload("Category").
+		Preload("Media", orderAlbumMedia)
+
+	albums, page, err := paginateByCursor(query, params, cursorSorts("title"), albumCursorKey)
+	if err != nil {
+		return nil, page, err
+	}
+	return mapAlbumsToDTO(albums), page, nil
 }
 
-func CreateAlbum(input AlbumInput) (*models.Album, error) {
+func CreateAlbum(input AlbumInput, actor Actor) (*models.Album, error) {
+	if err := AuthorizeAlbumOwner(input.UserID, actor); err != nil {
+		return nil, err
+	}
+
 	tx := config.DB.Begin() // Mulai transaksi
 
 	category, err := GetCategoryByUUID(input.CategoryId)
@@ -197,6 +399,12 @@ func CreateAlbum(input AlbumInput) (*models.Album, error) {
 		return nil, err
 	}
 
+	visibility, err := ParseAlbumVisibility(input.Visibility)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	slug := input.Slug
 	if slug == "" {
 		slug = utils.GenerateSlug(input.Title)
@@ -215,20 +423,51 @@ func CreateAlbum(input AlbumInput) (*models.Album, error) {
 		Title:       input.Title,
 		CategoryID:  category.ID,
 		Description: input.Description,
-		Images:      input.Images,
 		Thumbnail:   input.Thumbnail,
 		YoutubeURL:  input.YoutubeURL,
+		Visibility:  visibility,
 		UserID:      user.ID,
 		IsPublished: input.IsPublished == "true",
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := tx.Create(&album).Error; err != nil {
+	if err := checkAlbumFileVisibility(album, album.Thumbnail); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Omit("Media").Create(&album).Error; err != nil {
 		tx.Rollback() // rollback jika error
 		return nil, err
 	}
 
+	added, err := addAlbumMedia(tx, album, input.Images)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	album.Media = added
+
+	if album.Thumbnail != "" && !IsPrivateAlbum(album) {
+		if err := queueWatermarkForURLs(tx, []string{album.Thumbnail}); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := saveTranslations(tx, AuditEntityAlbum, album.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityAlbum, album.UUID, nil, withTranslations(tx, albumAuditSnapshot(album), AuditEntityAlbum, album.ID)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, err // commit gagal
 	}
@@ -241,13 +480,14 @@ func GetAlbumByUUID(uuid string) (models.Album, error) {
 	if err := config.DB.
 		Preload("User").
 		Preload("Category").
+		Preload("Media", orderAlbumMedia).
 		Where("uuid = ?", uuid).First(&album).Error; err != nil {
 		return models.Album{}, err
 	}
 	return album, nil
 }
 
-func UpdateAlbum(uuid string, input AlbumInput) (models.Album, error) {
+func UpdateAlbum(uuid string, input AlbumInput, actor Actor) (models.Album, error) {
 	tx := config.DB.Begin()
 	var album models.Album
 
@@ -257,6 +497,18 @@ func UpdateAlbum(uuid string, input AlbumInput) (models.Album, error) {
 		return models.Album{}, err
 	}
 
+	if err := AuthorizeAlbum(album, actor); err != nil {
+		tx.Rollback()
+		return models.Album{}, err
+	}
+
+	if err := AuthorizeAlbumOwner(input.UserID, actor); err != nil {
+		tx.Rollback()
+		return models.Album{}, err
+	}
+
+	before := withTranslations(tx, albumAuditSnapshot(album), AuditEntityAlbum, album.ID)
+
 	slug := utils.GenerateSlug(input.Slug)
 	if slug != album.Slug {
 		var existingAlbum models.Album
@@ -283,76 +535,109 @@ func UpdateAlbum(uuid string, input AlbumInput) (models.Album, error) {
 	album.Description = input.Description
 	album.YoutubeURL = input.YoutubeURL
 
+	// Kategori atau thumbnail baru bisa mengubah hasil watermark
+	var watermarkURLs []string
+
 	// Update Category if changed
 	if input.CategoryId != "" && category.ID != album.CategoryID {
 		album.CategoryID = category.ID
-	}
-
-	if input.Images != nil {
-		combined := append(album.Images, input.Images...)
-		album.Images = utils.RemoveDuplicateStrings(combined)
+		watermarkURLs = append(watermarkURLs, AlbumMediaURLs(album)...)
+		watermarkURLs = append(watermarkURLs, album.Thumbnail)
 	}
 
 	if input.Thumbnail != "" && input.Thumbnail != "undefined" {
+		if err := checkAlbumFileVisibility(album, input.Thumbnail); err != nil {
+			tx.Rollback()
+			return models.Album{}, err
+		}
 		album.Thumbnail = input.Thumbnail
+		watermarkURLs = append(watermarkURLs, album.Thumbnail)
 	}
 
 	album.UserID = user.ID
 	album.IsPublished = input.IsPublished == "true"
+	album.PublishAt = input.PublishAt
+	album.UnpublishAt = input.UnpublishAt
 	album.UpdatedAt = time.Now()
 
-	if err := tx.Save(&album).Error; err != nil {
+	if err := tx.Omit("User", "Category", "Media").Save(&album).Error; err != nil {
 		tx.Rollback()
 		return models.Album{}, err
 	}
 
-	if err := tx.Commit().Error; err != nil {
+	// Gambar lama diabaikan, yang baru ditaruh di urutan paling belakang
+	added, err := addAlbumMedia(tx, album, input.Images)
+	if err != nil {
+		tx.Rollback()
 		return models.Album{}, err
 	}
+	album.Media = append(album.Media, added...)
 
-	return album, nil
-}
+	if !IsPrivateAlbum(album) {
+		if err := queueWatermarkForURLs(tx, watermarkURLs); err != nil {
+			tx.Rollback()
+			return models.Album{}, err
+		}
+	}
 
-func DeleteAlbum(uuid string) error {
-	tx := config.DB.Begin()
+	if err := saveTranslations(tx, AuditEntityAlbum, album.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return models.Album{}, err
+	}
 
-	// Ambil album untuk mendapatkan daftar images dan thumbnail
-	album, err := GetAlbumByUUID(uuid)
-	if err != nil {
-		return err
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, album.UUID, before, withTranslations(tx, albumAuditSnapshot(album), AuditEntityAlbum, album.ID)); err != nil {
+		tx.Rollback()
+		return models.Album{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.Album{}, err
 	}
 
-	// Hapus images dari MinIO
-	for _, img := range album.Images {
-		img = strings.Trim(img, `"`)
-		if img != "" {
-			if err := utils.DeleteFromR2("albums", img); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("failed to delete album image: %v", err)
-			}
+	return album, nil
+}
+
+// queueAlbumFilesDeletion mengantrikan semua gambar dan thumbnail album (butuh Media di-preload)
+func queueAlbumFilesDeletion(tx *gorm.DB, album models.Album, reason string) error {
+	for _, img := range AlbumMediaURLs(album) {
+		if err := queueStoredFileDeletion(tx, img, reason); err != nil {
+			return err
 		}
 	}
 
-	// Hapus thumbnail dari MinIO
 	if album.Thumbnail != "" {
-		if err := utils.DeleteFromR2("albums", album.Thumbnail); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to delete album image: %v", err)
+		if err := queueStoredFileDeletion(tx, album.Thumbnail, reason); err != nil {
+			return err
 		}
 	}
 
-	// Hapus album dari database
-	if err := config.DB.Where("uuid = ?", uuid).Delete(&models.Album{}).Error; err != nil {
-		return err
-	}
 	return nil
 }
 
-func DeleteImageFromAlbum(uuid string, imageURL string) error {
+// DeleteAlbum memindahkan album ke trash. File baru dihapus saat trash di-purge.
+func DeleteAlbum(uuid string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, uuid, actor)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&album).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditActionDelete, AuditEntityAlbum, album.UUID, albumAuditSnapshot(album), nil)
+	})
+}
+
+func DeleteImageFromAlbum(uuid string, imageURL string, actor Actor) error {
 	tx := config.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %v", tx.Error)
+	}
 
 	// Ambil album
-	album, err := GetAlbumByUUID(uuid)
+	album, err := getAuthorizedAlbum(tx, uuid, actor)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -361,34 +646,39 @@ func DeleteImageFromAlbum(uuid string, imageURL string) error {
 	// Trim input
 	imageURL = strings.Trim(imageURL, `"`)
 
-	// Ambil nama file dari URL
-	parts := strings.Split(imageURL, "/")
-	imageFilename := parts[len(parts)-1]
+	before := albumAuditSnapshot(album)
 
-	// Hapus dari MinIO
-	if imageFilename != "" {
-		if err := utils.DeleteFromR2("albums", imageFilename); err != nil {
+	// Cek apakah imageURL adalah thumbnail
+	if imageURL != "" && album.Thumbnail == imageURL {
+		if err := tx.Model(&album).Update("thumbnail", "").Error; err != nil {
 			tx.Rollback()
-			return fmt.Errorf("failed to delete album image from MinIO: %v", err)
+			return err
 		}
-	}
-
-	// Cek apakah imageURL adalah thumbnail
-	if album.Thumbnail == imageURL {
-		album.Thumbnail = ""
 	} else {
-		// Kalau bukan thumbnail, hapus dari album.Images
-		var updatedImages []string
-		for _, img := range album.Images {
-			if img != imageURL {
-				updatedImages = append(updatedImages, img)
-			}
+		// Kalau bukan thumbnail, hapus dari album_media
+		result := tx.Where("album_id = ? AND url = ?", album.ID, imageURL).Delete(&models.AlbumMedia{})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		// Jangan hapus file yang bukan milik album ini
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return fmt.Errorf("image not found in album")
 		}
-		album.Images = updatedImages
 	}
 
-	// Simpan perubahan
-	if err := tx.Save(&album).Error; err != nil {
+	updated, err := getAuthorizedAlbum(tx, uuid, actor)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, album.UUID, before, albumAuditSnapshot(updated)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := queueStoredFileDeletion(tx, imageURL, "album_image_deleted"); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionCreate    = "create"
+	AuditActionUpdate    = "update"
+	AuditActionDelete    = "delete"
+	AuditActionRestore   = "restore"
+	AuditActionPublish   = "publish"
+	AuditActionUnpublish = "unpublish"
+
+	AuditEntityAlbum        = "album"
+	AuditEntityAlbumMedia   = "album_media"
+	AuditEntityCategory     = "category"
+	AuditEntityUser         = "user"
+	AuditEntityFaq          = "faq"
+	AuditEntityWebsite      = "website"
+	AuditEntityLead         = "lead"
+	AuditEntityGalleryLink  = "gallery_link"
+	AuditEntityProofingLink = "proofing_link"
+	AuditEntityDownloadLink = "download_link"
+)
+
+// Field yang tidak dicatat: internal, timestamp otomatis, relasi, dan rahasia
+var auditIgnoredFields = map[string]bool{
+	"id":                  true,
+	"password":            true,
+	"password_changed_at": true,
+	"created_at":          true,
+	"updated_at":          true,
+	"deleted_at":          true,
+	"user":                true,
+	"category":            true,
+	"media":               true,
+	"album":               true,
+	"token_hash":          true,
+	"password_hash":       true,
+}
+
+type AuditEventFilter struct {
+	ActorUUID  string     `form:"actor_uuid" binding:"omitempty,uuid"`
+	Action     string     `form:"action"`
+	EntityType string     `form:"entity_type"`
+	EntityUUID string     `form:"entity_uuid" binding:"omitempty,uuid"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// auditSnapshot mengubah model menjadi map field → value (berdasarkan tag json)
+func auditSnapshot(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	snapshot := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+
+	for field := range snapshot {
+		if auditIgnoredFields[field] {
+			delete(snapshot, field)
+		}
+	}
+	return snapshot
+}
+
+// albumAuditSnapshot: urutan gambar dan cover ikut dicatat (butuh Media di-preload)
+func albumAuditSnapshot(album models.Album) map[string]interface{} {
+	snapshot := auditSnapshot(album)
+	snapshot["images"] = AlbumMediaURLs(album)
+	snapshot["cover"] = albumCoverURL(album)
+	return snapshot
+}
+
+// auditDiff hanya menyisakan field yang berubah
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	oldValues := map[string]interface{}{}
+	newValues := map[string]interface{}{}
+
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			oldValues[field] = before[field]
+			newValues[field] = value
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			oldValues[field] = value
+			newValues[field] = nil
+		}
+	}
+
+	return oldValues, newValues
+}
+
+// auditUpdateAction: perubahan is_published dicatat sebagai publish/unpublish
+func auditUpdateAction(newValues map[string]interface{}) string {
+	switch newValues["is_published"] {
+	case true:
+		return AuditActionPublish
+	case false:
+		return AuditActionUnpublish
+	}
+	return AuditActionUpdate
+}
+
+func marshalAuditValues(values map[string]interface{}) (json.RawMessage, error) {
+	if values == nil {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+// recordAudit menyimpan audit event di tx yang sama dengan perubahannya.
+// before nil = create, after nil = delete, selain itu hanya field yang berubah yang disimpan.
+func recordAudit(tx *gorm.DB, actor Actor, action string, entityType string, entityUUID string, before, after map[string]interface{}) error {
+	oldValues, newValues := before, after
+	if before != nil && after != nil {
+		oldValues, newValues = auditDiff(before, after)
+		if len(newValues) == 0 && action == AuditActionUpdate {
+			// Tidak ada yang berubah
+			return nil
+		}
+		if action == AuditActionUpdate {
+			action = auditUpdateAction(newValues)
+		}
+	}
+
+	oldJSON, err := marshalAuditValues(oldValues)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit values: %v", err)
+	}
+	newJSON, err := marshalAuditValues(newValues)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit values: %v", err)
+	}
+
+	event := models.AuditEvent{
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		OldValues:  oldJSON,
+		NewValues:  newJSON,
+		IPAddress:  actor.IPAddress,
+		UserAgent:  actor.UserAgent,
+		CreatedAt:  time.Now(),
+	}
+	if actor.UserUUID != "" {
+		event.ActorUUID = &actor.UserUUID
+	}
+	if entityUUID != "" {
+		event.EntityUUID = &entityUUID
+	}
+
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to record audit event: %v", err)
+	}
+	return nil
+}
+
+func GetAuditEvents(page int, limit int, filter AuditEventFilter) ([]dto.AuditEventResponse, int64, error) {
+	var events []models.AuditEvent
+	var total int64
+
+	query := config.DB.Model(&models.AuditEvent{})
+
+	if filter.ActorUUID != "" {
+		query = query.Where("actor_uuid = ?", filter.ActorUUID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityUUID != "" {
+		query = query.Where("entity_uuid = ?", filter.EntityUUID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Nama actor diambil sekaligus, termasuk user yang sudah di trash
+	var actorUUIDs []string
+	for _, event := range events {
+		if event.ActorUUID != nil {
+			actorUUIDs = append(actorUUIDs, *event.ActorUUID)
+		}
+	}
+
+	names := map[string]string{}
+	if len(actorUUIDs) > 0 {
+		var users []models.User
+		if err := config.DB.Unscoped().Select("uuid", "name").Where("uuid IN ?", actorUUIDs).Find(&users).Error; err != nil {
+			return nil, 0, err
+		}
+		for _, user := range users {
+			names[user.UUID] = user.Name
+		}
+	}
+
+	response := make([]dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		response[i] = dto.AuditEventResponse{
+			UUID:       event.UUID,
+			ActorRole:  event.ActorRole,
+			Action:     event.Action,
+			EntityType: event.EntityType,
+			OldValues:  event.OldValues,
+			NewValues:  event.NewValues,
+			IPAddress:  event.IPAddress,
+			UserAgent:  event.UserAgent,
+			CreatedAt:  event.CreatedAt,
+		}
+		if event.ActorUUID != nil {
+			response[i].ActorUUID = *event.ActorUUID
+			response[i].ActorName = names[*event.ActorUUID]
+		}
+		if event.EntityUUID != nil {
+			response[i].EntityUUID = *event.EntityUUID
+		}
+	}
+
+	return response, total, nil
+}
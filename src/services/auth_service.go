@@ -23,13 +23,14 @@ func AuthenticateAdminUser(email, password string) (*models.User, error) {
 	return &user, nil
 }
 
-func Login(UUID, role string) (string, string, error) {
-	accessToken, err := utils.GenerateAccessToken(UUID, role)
+// Login membuat access token dan session baru untuk user
+func Login(user *models.User, meta SessionMeta) (string, string, error) {
+	accessToken, err := utils.GenerateAccessToken(user.UUID, user.Role)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(UUID, role)
+	refreshToken, _, err := createSession(config.DB, user, nil, meta)
 	if err != nil {
 		return "", "", err
 	}
@@ -37,21 +38,6 @@ func Login(UUID, role string) (string, string, error) {
 	return accessToken, refreshToken, nil
 }
 
-func RefreshToken(refreshToken string) (string, error) {
-	_, claims, err := utils.ValidateRefreshToken(refreshToken)
-	if err != nil {
-		return "", errors.New("invalid refresh token")
-	}
-
-	// Ambil ulang access token berdasarkan claim
-	newAccessToken, err := utils.GenerateAccessToken(claims.UserID, claims.Role)
-	if err != nil {
-		return "", errors.New("failed to generate new access token")
-	}
-
-	return newAccessToken, nil
-}
-
 func VerifyAccessToken(token string) (*utils.CustomClaims, error) {
 	_, claims, err := utils.ValidateAccessToken(token)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/models"
 	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
 )
 
 type CategoryInput struct {
@@ -17,18 +18,27 @@ type CategoryInput struct {
 	IsPublished string `form:"is_published" validate:"required"`
 	YoutubeURL  string `form:"youtube_url"`
 	PhotoUrl    string `form:"-"` // handled manually
+
+	PublishAt   *time.Time `form:"-"` // handled manually
+	UnpublishAt *time.Time `form:"-"` // handled manually
+
+	Translations Translations `form:"-"` // handled manually, nil = tidak diubah
 }
 
-func GetPublishedCategories() ([]dto.CategoryResponse, error) {
+func GetPublishedCategories(locale string) ([]dto.CategoryResponse, error) {
 	var categories []models.Category
 
-	if err := config.DB.Where("is_published = ?", true).
-		Select("uuid", "name", "is_published", "slug", "description", "photo_url", "created_at", "updated_at", "youtube_url").
+	if err := config.DB.Scopes(publishedNow("")).
+		Select("id", "uuid", "name", "is_published", "slug", "description", "photo_url", "created_at", "updated_at", "youtube_url").
 		Order("created_at DESC").
 		Find(&categories).Error; err != nil {
 		return nil, err
 	}
 
+	if err := localizeCategories(categories, locale); err != nil {
+		return nil, err
+	}
+
 	// Mapping ke response DTO
 	response := make([]dto.CategoryResponse, len(categories))
 	for i, category := range categories {
@@ -44,26 +54,36 @@ func GetPublishedCategories() ([]dto.CategoryResponse, error) {
 			YoutubeURL:  category.YoutubeURL,
 		}
 	}
+	applyCategoryPlaceholders(response)
 
 	return response, nil
 }
 
-func GetAllCategories(page int, limit int, search string) ([]dto.CategoryResponse, int64, error) {
-	var categories []models.Category
-	var total int64
-
+// categoryListQuery filter yang sama untuk list admin (offset maupun cursor)
+func categoryListQuery(search string) *gorm.DB {
 	query := config.DB.Model(&models.Category{})
 
-	// Apply search filter if search term is provided
+	// Full-text search, ILIKE untuk kata yang belum lengkap
 	if search != "" {
-		searchTerm := "%" + search + "%"
-		query = query.Where("name LIKE ?", searchTerm)
+		query = query.Scopes(adminSearch(search, "name"))
 	}
+	return query
+}
+
+func GetAllCategories(page int, limit int, search string) ([]dto.CategoryResponse, int64, error) {
+	var categories []models.Category
+	var total int64
+
+	query := categoryListQuery(search)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if search != "" {
+		query = query.Order(adminSearchOrder(search))
+	}
+
 	offset := (page - 1) * limit
 	if err := query.
 		Limit(limit).
@@ -72,6 +92,20 @@ func GetAllCategories(page int, limit int, search string) ([]dto.CategoryRespons
 		return nil, 0, err
 	}
 
+	return mapCategoryListToDTO(categories), total, nil
+}
+
+func GetCategoriesByCursor(params CursorParams, search string) ([]dto.CategoryResponse, dto.CursorPageResponse, error) {
+	categories, page, err := paginateByCursor(categoryListQuery(search), params, cursorSorts("name"), func(category models.Category, column string) (interface{}, int32) {
+		return modelCursorKey(category.ID, category.CreatedAt, category.UpdatedAt, category.Name, column)
+	})
+	if err != nil {
+		return nil, page, err
+	}
+	return mapCategoryListToDTO(categories), page, nil
+}
+
+func mapCategoryListToDTO(categories []models.Category) []dto.CategoryResponse {
 	// Mapping ke response DTO
 	response := make([]dto.CategoryResponse, len(categories))
 	for i, category := range categories {
@@ -79,16 +113,19 @@ func GetAllCategories(page int, limit int, search string) ([]dto.CategoryRespons
 			UUID:        category.UUID,
 			Name:        category.Name,
 			IsPublished: category.IsPublished,
+			PublishAt:   category.PublishAt,
+			UnpublishAt: category.UnpublishAt,
+			Status:      ContentStatus(category.IsPublished, category.PublishAt, category.UnpublishAt),
 			CreatedAt:   category.CreatedAt,
 			YoutubeURL:  category.YoutubeURL,
 			UpdatedAt:   category.UpdatedAt,
 		}
 	}
 
-	return response, total, nil
+	return response
 }
 
-func CreateCategory(input CategoryInput) (*models.Category, error) {
+func CreateCategory(input CategoryInput, actor Actor) (*models.Category, error) {
 	tx := config.DB.Begin() // Mulai transaksi
 
 	if tx.Error != nil {
@@ -102,8 +139,9 @@ func CreateCategory(input CategoryInput) (*models.Category, error) {
 		slug = utils.GenerateSlug(slug)
 	}
 
+	// Category di trash ikut dicek karena slug tetap unique di DB
 	var count int64
-	if err := tx.Model(&models.Category{}).
+	if err := tx.Unscoped().Model(&models.Category{}).
 		Where("slug = ? ", slug).
 		Count(&count).Error; err != nil {
 		tx.Rollback()
@@ -121,6 +159,8 @@ func CreateCategory(input CategoryInput) (*models.Category, error) {
 		Description: input.Description,
 		Slug:        slug,
 		PhotoURL:    input.PhotoUrl,
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -130,6 +170,16 @@ func CreateCategory(input CategoryInput) (*models.Category, error) {
 		return nil, err
 	}
 
+	if err := saveTranslations(tx, AuditEntityCategory, category.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityCategory, category.UUID, nil, withTranslations(tx, auditSnapshot(category), AuditEntityCategory, category.ID)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, err // commit gagal
 	}
@@ -145,7 +195,7 @@ func GetCategoryByUUID(uuid string) (models.Category, error) {
 	return category, nil
 }
 
-func UpdateCategory(uuid string, input CategoryInput) (models.Category, error) {
+func UpdateCategory(uuid string, input CategoryInput, actor Actor) (models.Category, error) {
 	tx := config.DB.Begin()
 
 	slug := input.Slug
@@ -156,7 +206,7 @@ func UpdateCategory(uuid string, input CategoryInput) (models.Category, error) {
 	}
 
 	var count int64
-	if err := tx.Model(&models.Category{}).Where("slug = ? AND uuid != ?", slug, uuid).
+	if err := tx.Unscoped().Model(&models.Category{}).Where("slug = ? AND uuid != ?", slug, uuid).
 		Count(&count).Error; err != nil {
 		tx.Rollback()
 		return models.Category{}, fmt.Errorf("failed to check slug uniqueness: %v", err)
@@ -173,11 +223,15 @@ func UpdateCategory(uuid string, input CategoryInput) (models.Category, error) {
 		return models.Category{}, err
 	}
 
+	before := withTranslations(tx, auditSnapshot(category), AuditEntityCategory, category.ID)
+
 	category.Name = input.Name
 	category.YoutubeURL = input.YoutubeURL
 	category.IsPublished = input.IsPublished == "1"
 	category.Description = input.Description
 	category.Slug = slug
+	category.PublishAt = input.PublishAt
+	category.UnpublishAt = input.UnpublishAt
 
 	if input.PhotoUrl != "" && input.PhotoUrl != "undefined" {
 		category.PhotoURL = input.PhotoUrl
@@ -190,6 +244,16 @@ func UpdateCategory(uuid string, input CategoryInput) (models.Category, error) {
 		return models.Category{}, err
 	}
 
+	if err := saveTranslations(tx, AuditEntityCategory, category.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return models.Category{}, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityCategory, category.UUID, before, withTranslations(tx, auditSnapshot(category), AuditEntityCategory, category.ID)); err != nil {
+		tx.Rollback()
+		return models.Category{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return models.Category{}, err
 	}
@@ -197,7 +261,9 @@ func UpdateCategory(uuid string, input CategoryInput) (models.Category, error) {
 	return category, nil
 }
 
-func DeleteCategory(uuid string) error {
+// DeleteCategory memindahkan category dan album-albumnya ke trash.
+// File baru dihapus saat trash di-purge (lihat PurgeTrash).
+func DeleteCategory(uuid string, actor Actor) error {
 	tx := config.DB.Begin()
 	var category models.Category
 
@@ -207,39 +273,22 @@ func DeleteCategory(uuid string) error {
 		return err
 	}
 
-	// Ambil semua album terkait category ini
-	var albums []models.Album
-	if err := tx.Where("category_id = ?", category.ID).Find(&albums).Error; err != nil {
+	// Timestamp yang sama dipakai supaya restore hanya mengembalikan album yang ikut terhapus di sini
+	now := time.Now()
+
+	// Pindahkan album-album terkait ke trash
+	if err := tx.Model(&models.Album{}).Where("category_id = ?", category.ID).Update("deleted_at", now).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	// Hapus image album dari MinIO
-
-	for _, album := range albums {
-		var images []string
-		if len(album.Images) > 0 {
-			images = album.Images
-
-			for _, image := range images {
-				if image != "" {
-					if err := utils.DeleteFromR2("albums", image); err != nil {
-						tx.Rollback()
-						return fmt.Errorf("failed to delete album image: %v", err)
-					}
-				}
-			}
-		}
-	}
-
-	// Hapus album-album terkait
-	if err := tx.Where("category_id = ?", category.ID).Delete(&models.Album{}).Error; err != nil {
+	// Pindahkan category-nya ke trash
+	if err := tx.Model(&category).Update("deleted_at", now).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	// Hapus category-nya
-	if err := tx.Delete(&category).Error; err != nil {
+	if err := recordAudit(tx, actor, AuditActionDelete, AuditEntityCategory, category.UUID, auditSnapshot(category), nil); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -252,7 +301,7 @@ func DeleteCategory(uuid string) error {
 	return nil
 }
 
-func DeleteImageCategory(uuid string) error {
+func DeleteImageCategory(uuid string, actor Actor) error {
 	tx := config.DB.Begin()
 	var category models.Category
 
@@ -262,13 +311,13 @@ func DeleteImageCategory(uuid string) error {
 		return err
 	}
 
-	if category.PhotoURL != "" {
-		if err := utils.DeleteFromR2("categories", category.PhotoURL); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to delete category image: %v", err)
-		}
+	if err := queueStoredFileDeletion(tx, category.PhotoURL, "category_image_deleted"); err != nil {
+		tx.Rollback()
+		return err
 	}
 
+	before := auditSnapshot(category)
+
 	// Set PhotoURL ke kosong
 	category.PhotoURL = ""
 	if err := tx.Save(&category).Error; err != nil {
@@ -276,12 +325,17 @@ func DeleteImageCategory(uuid string) error {
 		return err
 	}
 
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityCategory, category.UUID, before, auditSnapshot(category)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
 func GetCategoryOptions() ([]dto.CategoryResponse, error) {
 	var categories []models.Category
-	if err := config.DB.Where("is_published = ?", true).
+	if err := config.DB.Scopes(publishedNow("")).
 		Order("name ASC").
 		Find(&categories).Error; err != nil {
 		return nil, err
@@ -300,13 +354,14 @@ func GetCategoryOptions() ([]dto.CategoryResponse, error) {
 			UpdatedAt:   category.UpdatedAt,
 		}
 	}
+	applyCategoryPlaceholders(options)
 
 	return options, nil
 }
 
-func GetCategoryBySlug(slug string) (dto.CategoryBySlugResponse, error) {
+func GetCategoryBySlug(slug string, locale string) (dto.CategoryBySlugResponse, error) {
 	var category models.Category
-	if err := config.DB.Where("slug = ?", slug).First(&category).Error; err != nil {
+	if err := config.DB.Where("slug = ?", slug).Scopes(publishedNow("")).First(&category).Error; err != nil {
 		return dto.CategoryBySlugResponse{}, err
 	}
 
@@ -314,6 +369,12 @@ func GetCategoryBySlug(slug string) (dto.CategoryBySlugResponse, error) {
 		return dto.CategoryBySlugResponse{}, fmt.Errorf("category not found")
 	}
 
+	categories := []models.Category{category}
+	if err := localizeCategories(categories, locale); err != nil {
+		return dto.CategoryBySlugResponse{}, err
+	}
+	category = categories[0]
+
 	var users []struct {
 		UUID string
 		Slug string
@@ -323,12 +384,14 @@ func GetCategoryBySlug(slug string) (dto.CategoryBySlugResponse, error) {
 	subQuery := config.DB.
 		Table("albums").
 		Select("user_id").
-		Where("category_id = ? AND is_published = ?", category.ID, true)
+		Where("category_id = ? AND deleted_at IS NULL", category.ID).
+		Scopes(publicAlbums(""))
 
 	if err := config.DB.
 		Table("users").
 		Select("uuid, slug, name").
-		Where("id IN (?) AND is_published = ?", subQuery, true).
+		Where("id IN (?) AND deleted_at IS NULL", subQuery).
+		Scopes(publishedNow("")).
 		Scan(&users).Error; err != nil {
 		return dto.CategoryBySlugResponse{}, err
 	}
@@ -352,5 +415,7 @@ func GetCategoryBySlug(slug string) (dto.CategoryBySlugResponse, error) {
 		Slug:        category.Slug,
 		PhotoUrl:    category.PhotoURL,
 		Users:       usersResp,
+
+		PhotoPlaceholder: GetImagePlaceholder(category.PhotoURL),
 	}, nil
 }
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	SortNewest    = "newest"
+	SortOldest    = "oldest"
+	SortUpdated   = "updated"
+	SortTitleAsc  = "title_asc"
+	SortTitleDesc = "title_desc"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// CursorParams: Cursor kosong = halaman pertama
+type CursorParams struct {
+	Cursor string
+	Sort   string
+	Limit  int
+}
+
+type cursorSort struct {
+	Column string // id selalu dipakai sebagai tie-breaker
+	Desc   bool
+	Time   bool // nilai di cursor disimpan sebagai RFC3339Nano
+}
+
+// cursorSorts: titleColumn adalah kolom judul/nama tiap tabel
+func cursorSorts(titleColumn string) map[string]cursorSort {
+	// NULL tidak bisa dibandingkan di row comparison
+	titleColumn = "coalesce(" + titleColumn + ", '')"
+
+	return map[string]cursorSort{
+		SortNewest:    {Column: "created_at", Desc: true, Time: true},
+		SortOldest:    {Column: "created_at", Time: true},
+		SortUpdated:   {Column: "updated_at", Desc: true, Time: true},
+		SortTitleAsc:  {Column: titleColumn},
+		SortTitleDesc: {Column: titleColumn, Desc: true},
+	}
+}
+
+// cursorKey mengembalikan nilai kolom sort dan id dari baris terakhir
+type cursorKey[T any] func(row T, column string) (interface{}, int32)
+
+// paginateByCursor menjalankan keyset pagination pada (kolom sort, id).
+// Diambil limit+1 baris untuk mengetahui apakah masih ada halaman berikutnya.
+func paginateByCursor[T any](query *gorm.DB, params CursorParams, sorts map[string]cursorSort, key cursorKey[T]) ([]T, dto.CursorPageResponse, error) {
+	var rows []T
+	page := dto.CursorPageResponse{}
+
+	if params.Sort == "" {
+		params.Sort = SortNewest
+	}
+	sort, ok := sorts[params.Sort]
+	if !ok {
+		return nil, page, ErrInvalidSort
+	}
+
+	direction, operator := "ASC", ">"
+	if sort.Desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if params.Cursor != "" {
+		cursor, err := utils.DecodeCursor(params.Cursor)
+		if err != nil || cursor.Sort != params.Sort {
+			return nil, page, utils.ErrInvalidCursor
+		}
+
+		var value interface{} = cursor.Value
+		if sort.Time {
+			t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				return nil, page, utils.ErrInvalidCursor
+			}
+			value = t
+		}
+
+		query = query.Where("("+sort.Column+", id) "+operator+" (?, ?)", value, cursor.ID)
+	}
+
+	if err := query.
+		Order(sort.Column + " " + direction).
+		Order("id " + direction).
+		Limit(params.Limit + 1).
+		Find(&rows).Error; err != nil {
+		return nil, page, err
+	}
+
+	if len(rows) > params.Limit {
+		rows = rows[:params.Limit]
+		page.HasMore = true
+
+		value, id := key(rows[len(rows)-1], sort.Column)
+		cursor := utils.Cursor{Sort: params.Sort, ID: id}
+		switch v := value.(type) {
+		case time.Time:
+			cursor.Value = v.Format(time.RFC3339Nano)
+		case string:
+			cursor.Value = v
+		}
+
+		next, err := utils.EncodeCursor(cursor)
+		if err != nil {
+			return nil, page, err
+		}
+		page.Next = next
+	}
+
+	return rows, page, nil
+}
+
+// modelCursorKey untuk model dengan kolom created_at/updated_at standar
+func modelCursorKey(id int32, createdAt time.Time, updatedAt time.Time, title string, column string) (interface{}, int32) {
+	switch column {
+	case "created_at":
+		return createdAt, id
+	case "updated_at":
+		return updatedAt, id
+	default:
+		return title, id
+	}
+}
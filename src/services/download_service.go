@@ -0,0 +1,513 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDownloadLinkNotFound = errors.New("download link not found")
+	ErrDownloadNotFound     = errors.New("download not found or link has expired")
+	ErrDownloadLimitReached = errors.New("download limit reached for this link")
+	ErrDownloadNotStarted   = errors.New("download must start from the beginning of the file")
+	ErrDownloadSession      = errors.New("download session expired or album changed, start the download again")
+	ErrDownloadRateLimited  = errors.New("too many downloads, please try again later")
+	ErrDownloadEmpty        = errors.New("album has no media to download")
+	ErrDownloadLinkExpiry   = errors.New("expires_at must be in the future")
+)
+
+// Resume download juga dihitung, jadi batasnya longgar: 60 request per IP per jam
+var downloadLimiter = utils.NewRateLimiter(60, time.Hour)
+
+type AlbumDownloadLinkInput struct {
+	Label        string     `json:"label" validate:"max=100"`
+	ExpiresAt    *time.Time `json:"expires_at"` // kosong = sekarang + DOWNLOAD_LINK_EXPIRATION
+	MaxDownloads *int32     `json:"max_downloads" validate:"omitempty,min=1"`
+}
+
+// AlbumDownload ZIP yang siap dikirim lewat http.ServeContent.
+// Session terisi kalau request ini dihitung sebagai download baru, dikirim ke client sebagai cookie untuk resume.
+type AlbumDownload struct {
+	Filename         string
+	ETag             string
+	Modified         time.Time
+	Stream           *utils.ZipStream
+	Session          string
+	SessionExpiresAt time.Time
+}
+
+func mapDownloadLinkToDTO(link models.AlbumDownloadLink) dto.AlbumDownloadLinkResponse {
+	return dto.AlbumDownloadLinkResponse{
+		UUID:             link.UUID,
+		Label:            link.Label,
+		ExpiresAt:        link.ExpiresAt,
+		MaxDownloads:     link.MaxDownloads,
+		DownloadCount:    link.DownloadCount,
+		LastDownloadedAt: link.LastDownloadedAt,
+		RevokedAt:        link.RevokedAt,
+		CreatedAt:        link.CreatedAt,
+	}
+}
+
+func downloadURL(token string) string {
+	baseURL := utils.GetEnvOrDefault("DOWNLOAD_URL", "http://localhost:"+utils.GetEnvOrDefault("PORT", "8080")+"/v1/api/downloads")
+	return strings.TrimRight(baseURL, "/") + "/" + token
+}
+
+// downloadLinkExpiration masa berlaku default link download dari DOWNLOAD_LINK_EXPIRATION (default 72h)
+func downloadLinkExpiration() time.Duration {
+	duration, err := time.ParseDuration(utils.GetEnvOrDefault("DOWNLOAD_LINK_EXPIRATION", "72h"))
+	if err != nil || duration <= 0 {
+		return 72 * time.Hour
+	}
+	return duration
+}
+
+// CreateAlbumDownloadLink membuat link download ZIP. Token hanya dikembalikan sekali, DB menyimpan hash-nya.
+func CreateAlbumDownloadLink(albumUUID string, input AlbumDownloadLinkInput, actor Actor) (dto.AlbumDownloadLinkResponse, error) {
+	expiresAt := time.Now().Add(downloadLinkExpiration())
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return dto.AlbumDownloadLinkResponse{}, ErrDownloadLinkExpiry
+		}
+		expiresAt = *input.ExpiresAt
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return dto.AlbumDownloadLinkResponse{}, fmt.Errorf("failed to generate download token: %v", err)
+	}
+
+	var response dto.AlbumDownloadLinkResponse
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		link := models.AlbumDownloadLink{
+			AlbumID:      album.ID,
+			Label:        strings.TrimSpace(input.Label),
+			TokenHash:    hashSecretToken(token),
+			ExpiresAt:    expiresAt,
+			MaxDownloads: input.MaxDownloads,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if actor.UserUUID != "" {
+			link.CreatedBy = &actor.UserUUID
+		}
+
+		if err := tx.Omit("Album").Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to create download link: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityDownloadLink, link.UUID, nil, auditSnapshot(link)); err != nil {
+			return err
+		}
+
+		response = mapDownloadLinkToDTO(link)
+		response.Token = token
+		response.URL = downloadURL(token)
+		return nil
+	})
+
+	return response, err
+}
+
+func GetAlbumDownloadLinks(albumUUID string, actor Actor) ([]dto.AlbumDownloadLinkResponse, error) {
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []models.AlbumDownloadLink
+	if err := config.DB.Where("album_id = ?", album.ID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get download links: %v", err)
+	}
+
+	response := make([]dto.AlbumDownloadLinkResponse, len(links))
+	for i, link := range links {
+		response[i] = mapDownloadLinkToDTO(link)
+	}
+	return response, nil
+}
+
+func RevokeAlbumDownloadLink(albumUUID string, linkUUID string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		var link models.AlbumDownloadLink
+		if err := tx.Where("uuid = ? AND album_id = ?", linkUUID, album.ID).First(&link).Error; err != nil {
+			return ErrDownloadLinkNotFound
+		}
+		if link.RevokedAt != nil {
+			return nil
+		}
+
+		before := auditSnapshot(link)
+		now := time.Now()
+		link.RevokedAt = &now
+		link.UpdatedAt = now
+
+		if err := tx.Model(&models.AlbumDownloadLink{}).Where("id = ?", link.ID).
+			Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to revoke download link: %v", err)
+		}
+
+		return recordAudit(tx, actor, AuditActionUpdate, AuditEntityDownloadLink, link.UUID, before, auditSnapshot(link))
+	})
+}
+
+// DownloadPublicAlbum ZIP album yang sedang tayang di halaman publik, tanpa link
+func DownloadPublicAlbum(ctx context.Context, slug string, ipAddress string) (AlbumDownload, error) {
+	if !downloadLimiter.Allow(ipAddress) {
+		return AlbumDownload{}, ErrDownloadRateLimited
+	}
+
+	var album models.Album
+	err := config.DB.
+		Preload("Category").
+		Preload("Media", orderAlbumMedia).
+		Where("slug = ?", slug).
+		Scopes(publicAlbums("")).
+		First(&album).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return AlbumDownload{}, ErrDownloadNotFound
+	}
+	if err != nil {
+		return AlbumDownload{}, fmt.Errorf("failed to get album: %v", err)
+	}
+
+	variant, err := publicDownloadVariant(album)
+	if err != nil {
+		return AlbumDownload{}, err
+	}
+	return buildAlbumDownload(ctx, album, variant)
+}
+
+// publicDownloadVariant file yang sama dengan halaman publik: versi watermark kalau album ber-watermark,
+// salinan tanpa metadata kalau EXIF_REDACT_GPS aktif
+func publicDownloadVariant(album models.Album) (string, error) {
+	website, err := watermarkWebsite(config.DB)
+	if err != nil {
+		return "", fmt.Errorf("failed to get watermark settings: %v", err)
+	}
+	if _, enabled := resolveWatermark(website, album.Category, album); enabled {
+		return VariantWatermarkFull, nil
+	}
+	if exifRedactGPS() {
+		return VariantStripped, nil
+	}
+	return "", nil
+}
+
+// DownloadAlbumByLink ZIP album lewat link download. start = request dari awal file (bukan resume),
+// hanya request ini yang dihitung ke max_downloads dan mendapat sesi download.
+// Resume harus membawa sesi tersebut dan ETag ZIP harus masih sama.
+func DownloadAlbumByLink(ctx context.Context, token string, start bool, session string, ipAddress string) (AlbumDownload, error) {
+	if !downloadLimiter.Allow(ipAddress) {
+		return AlbumDownload{}, ErrDownloadRateLimited
+	}
+	if token == "" {
+		return AlbumDownload{}, ErrDownloadNotFound
+	}
+
+	now := time.Now()
+	var link models.AlbumDownloadLink
+	err := config.DB.
+		Joins("JOIN albums ON albums.id = album_download_links.album_id").
+		Where("album_download_links.token_hash = ?", hashSecretToken(token)).
+		Where("album_download_links.revoked_at IS NULL AND album_download_links.expires_at > ?", now).
+		Where("albums.deleted_at IS NULL").
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return AlbumDownload{}, ErrDownloadNotFound
+	}
+	if err != nil {
+		return AlbumDownload{}, fmt.Errorf("failed to get download link: %v", err)
+	}
+
+	if start {
+		// Batas dicek di WHERE supaya dua download bersamaan tidak bisa melewati max_downloads
+		result := config.DB.Model(&models.AlbumDownloadLink{}).
+			Where("id = ? AND (max_downloads IS NULL OR download_count < max_downloads)", link.ID).
+			Updates(map[string]interface{}{
+				"download_count":     gorm.Expr("download_count + 1"),
+				"last_downloaded_at": now,
+				"updated_at":         now,
+			})
+		if result.Error != nil {
+			return AlbumDownload{}, fmt.Errorf("failed to update download count: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return AlbumDownload{}, ErrDownloadLimitReached
+		}
+	} else if session == "" {
+		return AlbumDownload{}, ErrDownloadNotStarted
+	}
+
+	var album models.Album
+	if err := config.DB.Preload("Media", orderAlbumMedia).Where("id = ?", link.AlbumID).First(&album).Error; err != nil {
+		return AlbumDownload{}, ErrDownloadNotFound
+	}
+
+	download, err := buildAlbumDownload(ctx, album, "")
+	if err != nil {
+		return AlbumDownload{}, err
+	}
+
+	if !start {
+		if err := utils.VerifyDownloadSession(session, link.UUID, download.ETag); err != nil {
+			download.Stream.Close()
+			return AlbumDownload{}, ErrDownloadSession
+		}
+		return download, nil
+	}
+
+	// Sesi tidak boleh berlaku lebih lama dari link-nya
+	expiresAt := now.Add(utils.DownloadSessionExpiration())
+	if link.ExpiresAt.Before(expiresAt) {
+		expiresAt = link.ExpiresAt
+	}
+	download.Session, err = utils.EncodeDownloadSession(link.UUID, download.ETag, expiresAt)
+	if err != nil {
+		download.Stream.Close()
+		return AlbumDownload{}, fmt.Errorf("failed to create download session: %v", err)
+	}
+	download.SessionExpiresAt = expiresAt
+	return download, nil
+}
+
+// downloadFile satu file di dalam ZIP
+type downloadFile struct {
+	Name     string
+	Key      string
+	Size     int64
+	CRC32    uint32
+	Modified time.Time
+	Updated  time.Time
+}
+
+// buildAlbumDownload menyusun ZIP sesuai urutan media. variant kosong = file original,
+// selain itu variant dengan nama tersebut (media yang belum punya variant-nya dilewati).
+func buildAlbumDownload(ctx context.Context, album models.Album, variant string) (AlbumDownload, error) {
+	if len(album.Media) == 0 {
+		return AlbumDownload{}, ErrDownloadEmpty
+	}
+
+	store := utils.StoreFor(IsPrivateAlbum(album))
+	if store == nil {
+		return AlbumDownload{}, utils.ErrPrivateStorageDisabled
+	}
+
+	var files []downloadFile
+	var err error
+	if variant == "" {
+		files, err = mediaDownloadFiles(ctx, store, album.Media)
+	} else {
+		files, err = variantDownloadFiles(ctx, store, album.Media, variant)
+	}
+	if err != nil {
+		return AlbumDownload{}, err
+	}
+	if len(files) == 0 {
+		return AlbumDownload{}, ErrDownloadEmpty
+	}
+
+	folder := album.Slug
+	if folder == "" {
+		folder = album.UUID
+	}
+
+	// Nomor urut di depan nama file: urutan sama dengan album dan nama tidak bisa bentrok
+	width := max(3, len(strconv.Itoa(len(files))))
+	entries := make([]utils.ZipEntry, len(files))
+	var modified time.Time
+	for i, f := range files {
+		entries[i] = utils.ZipEntry{
+			Name:     fmt.Sprintf("%s/%0*d-%s", folder, width, i+1, f.Name),
+			Key:      f.Key,
+			Size:     f.Size,
+			CRC32:    f.CRC32,
+			Modified: f.Modified,
+		}
+		if f.Updated.After(modified) {
+			modified = f.Updated
+		}
+	}
+
+	stream, err := utils.NewZipStream(ctx, store, entries)
+	if err != nil {
+		return AlbumDownload{}, err
+	}
+
+	return AlbumDownload{
+		Filename: folder + ".zip",
+		ETag:     utils.ZipETag(entries),
+		Modified: modified,
+		Stream:   stream,
+	}, nil
+}
+
+func mediaDownloadFiles(ctx context.Context, store utils.Storage, media []models.AlbumMedia) ([]downloadFile, error) {
+	if err := ensureMediaChecksums(ctx, store, media); err != nil {
+		return nil, err
+	}
+
+	files := make([]downloadFile, len(media))
+	for i, m := range media {
+		files[i] = downloadFile{
+			Name:     zipFileName(m.StorageKey),
+			Key:      m.StorageKey,
+			Size:     m.Bytes,
+			CRC32:    uint32(*m.Crc32),
+			Modified: m.CreatedAt,
+			Updated:  m.UpdatedAt,
+		}
+	}
+	return files, nil
+}
+
+// variantDownloadFiles file variant tiap media, nama file tetap nama original dengan ekstensi variant
+func variantDownloadFiles(ctx context.Context, store utils.Storage, media []models.AlbumMedia, variant string) ([]downloadFile, error) {
+	urls := make([]string, len(media))
+	for i, m := range media {
+		urls[i] = m.URL
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Preload("Variants", "name = ?", variant).
+		Where("url IN ?", urls).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get image variants: %v", err)
+	}
+
+	variants := map[string]*models.ImageVariant{}
+	for i := range assets {
+		if len(assets[i].Variants) > 0 {
+			variants[assets[i].URL] = &assets[i].Variants[0]
+		}
+	}
+
+	var files []downloadFile
+	for _, m := range media {
+		v, ok := variants[m.URL]
+		if !ok {
+			continue
+		}
+		if err := ensureVariantChecksum(ctx, store, v); err != nil {
+			return nil, err
+		}
+
+		name := zipFileName(m.StorageKey)
+		name = strings.TrimSuffix(name, path.Ext(name)) + "." + variantExtension(v.Format)
+		files = append(files, downloadFile{
+			Name:     name,
+			Key:      v.StorageKey,
+			Size:     v.Bytes,
+			CRC32:    uint32(*v.Crc32),
+			Modified: v.CreatedAt,
+			Updated:  v.CreatedAt,
+		})
+	}
+	return files, nil
+}
+
+// ensureVariantChecksum untuk variant lama yang dibuat sebelum kolom crc32 ada
+func ensureVariantChecksum(ctx context.Context, store utils.Storage, v *models.ImageVariant) error {
+	if v.Crc32 != nil {
+		return nil
+	}
+
+	body, _, err := store.Get(ctx, v.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", v.StorageKey, err)
+	}
+	hash := crc32.NewIEEE()
+	size, err := io.Copy(hash, body)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", v.StorageKey, err)
+	}
+
+	checksum := int64(hash.Sum32())
+	v.Bytes = size
+	v.Crc32 = &checksum
+
+	if err := config.DB.Model(&models.ImageVariant{}).Where("id = ?", v.ID).
+		Updates(map[string]interface{}{"bytes": size, "crc32": checksum}).Error; err != nil {
+		return fmt.Errorf("failed to save variant checksum: %v", err)
+	}
+	return nil
+}
+
+// zipFileName nama file asli dari key storage, karakter yang mengganggu path ZIP diganti
+func zipFileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r == ':':
+			return '_'
+		case r < 0x20:
+			return -1
+		}
+		return r
+	}, utils.OriginalFilename(key))
+
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	return name
+}
+
+// ensureMediaChecksums mengisi ukuran dan CRC media yang belum diproses worker, lalu disimpan supaya tidak dihitung ulang
+func ensureMediaChecksums(ctx context.Context, store utils.Storage, media []models.AlbumMedia) error {
+	for i := range media {
+		m := &media[i]
+		if m.Crc32 != nil && m.Bytes > 0 {
+			continue
+		}
+
+		body, _, err := store.Get(ctx, m.StorageKey)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", m.StorageKey, err)
+		}
+		hash := crc32.NewIEEE()
+		size, err := io.Copy(hash, body)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", m.StorageKey, err)
+		}
+
+		checksum := int64(hash.Sum32())
+		m.Bytes = size
+		m.Crc32 = &checksum
+
+		if err := config.DB.Model(&models.AlbumMedia{}).Where("id = ?", m.ID).
+			Updates(map[string]interface{}{"bytes": size, "crc32": checksum}).Error; err != nil {
+			return fmt.Errorf("failed to save media checksum: %v", err)
+		}
+		if err := config.DB.Model(&models.ImageAsset{}).Where("url = ?", m.URL).
+			Update("crc32", checksum).Error; err != nil {
+			return fmt.Errorf("failed to save asset checksum: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	DuplicateTypeExact = "exact"
+	DuplicateTypeNear  = "near"
+
+	duplicateMaxThreshold = 16
+)
+
+var (
+	ErrDuplicateImage            = errors.New("image already exists in this album")
+	ErrInvalidDuplicateThreshold = fmt.Errorf("threshold must be between 0 and %d", duplicateMaxThreshold)
+)
+
+// duplicateAsset data image_assets yang dibutuhkan untuk pengelompokan duplikat
+type duplicateAsset struct {
+	ID         int32
+	UUID       string
+	URL        string
+	Width      int32
+	Height     int32
+	CreatedAt  time.Time
+	Sha256     string
+	Phash      *int64
+	TotalBytes int64
+
+	MediaUUID string `gorm:"-"`
+}
+
+// DuplicateThreshold jarak dHash maksimum (bit berbeda) untuk dianggap mirip, default 6 dari 64 bit
+func DuplicateThreshold() int {
+	threshold, err := strconv.Atoi(utils.GetEnvOrDefault("DUPLICATE_PHASH_THRESHOLD", "6"))
+	if err != nil || threshold < 0 || threshold > duplicateMaxThreshold {
+		return 6
+	}
+	return threshold
+}
+
+// ParseDuplicateThreshold: kosong = default dari env
+func ParseDuplicateThreshold(value string) (int, error) {
+	if value == "" {
+		return DuplicateThreshold(), nil
+	}
+	threshold, err := strconv.Atoi(value)
+	if err != nil || threshold < 0 || threshold > duplicateMaxThreshold {
+		return 0, ErrInvalidDuplicateThreshold
+	}
+	return threshold, nil
+}
+
+// CheckAlbumDuplicates menolak file yang isinya (SHA-256) sudah ada di album atau dobel di upload yang sama.
+// Dipanggil sebelum upload supaya tidak ada file yatim. album nil untuk album baru.
+func CheckAlbumDuplicates(album *models.Album, files []*multipart.FileHeader) error {
+	filenames := map[string]string{}
+	var problems []string
+
+	for _, fileHeader := range files {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %v", fileHeader.Filename, err)
+		}
+		checksum, err := utils.FileSHA256(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %v", fileHeader.Filename, err)
+		}
+
+		if previous, ok := filenames[checksum]; ok {
+			problems = append(problems, fmt.Sprintf("%s (same file as %s)", fileHeader.Filename, previous))
+			continue
+		}
+		filenames[checksum] = fileHeader.Filename
+	}
+
+	if album != nil && len(filenames) > 0 {
+		checksums := make([]string, 0, len(filenames))
+		for checksum := range filenames {
+			checksums = append(checksums, checksum)
+		}
+
+		var existing []struct {
+			Sha256 string
+			URL    string
+		}
+		if err := config.DB.Table("album_media am").
+			Select("ia.sha256, am.url").
+			Joins("JOIN image_assets ia ON ia.url = am.url").
+			Where("am.album_id = ? AND ia.sha256 IN ?", album.ID, checksums).
+			Scan(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check duplicates: %v", err)
+		}
+
+		for _, e := range existing {
+			problems = append(problems, fmt.Sprintf("%s (already in album as %s)", filenames[e.Sha256], path.Base(e.URL)))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrDuplicateImage, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// removeDuplicateUpload dipanggil image worker setelah sha256 asset tersimpan. Upload presigned tidak di-hash
+// saat confirm (file bisa sangat besar), jadi media yang isinya sudah ada di album dihapus lagi di sini,
+// kecuali confirm dikirim dengan allow_duplicates=true.
+func removeDuplicateUpload(asset models.ImageAsset) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		var upload models.PendingUpload
+		err := tx.Where("storage_key = ? AND status = ? AND allow_duplicates = ?", asset.StorageKey, UploadStatusConfirmed, false).
+			First(&upload).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get upload: %v", err)
+		}
+
+		// Media lain di album dengan isi yang sama, termasuk upload lain di batch yang sama yang sudah diproses
+		var existing []string
+		if err := tx.Table("album_media am").
+			Select("am.url").
+			Joins("JOIN image_assets ia ON ia.url = am.url").
+			Joins("JOIN image_assets self ON self.id = ?", asset.ID).
+			Where("am.album_id = ? AND am.url <> self.url AND ia.sha256 = self.sha256", upload.AlbumID).
+			Order("am.id").
+			Limit(1).
+			Pluck("am.url", &existing).Error; err != nil {
+			return fmt.Errorf("failed to check duplicates: %v", err)
+		}
+		if len(existing) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("album_id = ? AND url = ?", upload.AlbumID, asset.URL).Delete(&models.AlbumMedia{}).Error; err != nil {
+			return fmt.Errorf("failed to remove duplicate media: %v", err)
+		}
+		if err := tx.Model(&upload).Update("status", UploadStatusDuplicate).Error; err != nil {
+			return fmt.Errorf("failed to update upload: %v", err)
+		}
+		if err := queueStoredFileDeletion(tx, asset.URL, "duplicate_upload"); err != nil {
+			return err
+		}
+
+		log.Printf("⚠️ Removed duplicate upload %s from album %d (same file as %s)\n", upload.Filename, upload.AlbumID, path.Base(existing[0]))
+		return nil
+	})
+}
+
+func duplicateAssetQuery() *gorm.DB {
+	return config.DB.Table("image_assets ia").Select(`ia.id, ia.uuid, ia.url, ia.width, ia.height, ia.created_at, ia.sha256, ia.phash,
+		COALESCE(ia.bytes, 0) + COALESCE((SELECT SUM(iv.bytes) FROM image_variants iv WHERE iv.asset_id = ia.id), 0) AS total_bytes`)
+}
+
+// GetAlbumDuplicates menandai foto album yang sama persis atau mirip (mis. frame yang di-upload ulang setelah diedit)
+func GetAlbumDuplicates(albumUUID string, threshold int, actor Actor) (dto.AlbumDuplicatesResponse, error) {
+	response := dto.AlbumDuplicatesResponse{
+		Exact:     []dto.DuplicateGroupResponse{},
+		Near:      []dto.DuplicateGroupResponse{},
+		Threshold: threshold,
+	}
+
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return response, err
+	}
+	if len(album.Media) == 0 {
+		return response, nil
+	}
+
+	var rows []duplicateAsset
+	if err := duplicateAssetQuery().
+		Where("ia.url IN ? AND ia.sha256 IS NOT NULL", AlbumMediaURLs(album)).
+		Scan(&rows).Error; err != nil {
+		return response, fmt.Errorf("failed to get album images: %v", err)
+	}
+
+	byURL := map[string]duplicateAsset{}
+	for _, row := range rows {
+		byURL[row.URL] = row
+	}
+
+	// Urutan mengikuti urutan media di album
+	assets := make([]duplicateAsset, 0, len(album.Media))
+	for _, m := range album.Media {
+		asset, ok := byURL[m.URL]
+		if !ok || asset.Phash == nil {
+			response.Pending++
+		}
+		if ok {
+			asset.MediaUUID = m.UUID
+			assets = append(assets, asset)
+		}
+	}
+
+	response.Exact, response.Near = groupDuplicates(assets, threshold)
+	return response, nil
+}
+
+// GetDuplicateReport laporan duplikat seluruh library beserta perkiraan storage yang bisa dihemat
+func GetDuplicateReport(threshold int, actor Actor) (dto.DuplicateReportResponse, error) {
+	response := dto.DuplicateReportResponse{
+		Exact:     []dto.DuplicateGroupResponse{},
+		Near:      []dto.DuplicateGroupResponse{},
+		Threshold: threshold,
+	}
+
+	if !actor.Can(utils.PermAlbumWriteAny) {
+		return response, ErrForbidden
+	}
+
+	var assets []duplicateAsset
+	if err := duplicateAssetQuery().
+		Where("ia.sha256 IS NOT NULL").
+		Order("ia.id ASC").
+		Scan(&assets).Error; err != nil {
+		return response, fmt.Errorf("failed to get image assets: %v", err)
+	}
+
+	if err := config.DB.Table("image_assets").
+		Where("status <> ? AND (sha256 IS NULL OR phash IS NULL)", ImageStatusFailed).
+		Count(&response.Pending).Error; err != nil {
+		return response, fmt.Errorf("failed to count pending assets: %v", err)
+	}
+
+	response.Scanned = len(assets)
+	response.Exact, response.Near = groupDuplicates(assets, threshold)
+
+	if err := fillDuplicateAlbums(append(append([]dto.DuplicateGroupResponse{}, response.Exact...), response.Near...)); err != nil {
+		return response, err
+	}
+
+	for _, group := range response.Exact {
+		response.ExactSavings += group.Savings
+	}
+	for _, group := range response.Near {
+		response.NearSavings += group.Savings
+	}
+
+	return response, nil
+}
+
+// groupDuplicates: exact = SHA-256 sama. Near dihitung antar file yang berbeda isi
+// (satu wakil per SHA-256), jadi salinan persis tidak muncul dua kali.
+func groupDuplicates(assets []duplicateAsset, threshold int) ([]dto.DuplicateGroupResponse, []dto.DuplicateGroupResponse) {
+	exact := []dto.DuplicateGroupResponse{}
+	near := []dto.DuplicateGroupResponse{}
+
+	bySha := map[string][]duplicateAsset{}
+	var order []string
+	for _, asset := range assets {
+		if _, ok := bySha[asset.Sha256]; !ok {
+			order = append(order, asset.Sha256)
+		}
+		bySha[asset.Sha256] = append(bySha[asset.Sha256], asset)
+	}
+
+	var representatives []duplicateAsset
+	for _, checksum := range order {
+		copies := bySha[checksum]
+		if len(copies) > 1 {
+			exact = append(exact, buildDuplicateGroup(DuplicateTypeExact, checksum, copies))
+		}
+		if copies[0].Phash != nil {
+			representatives = append(representatives, copies[0])
+		}
+	}
+
+	hashes := make([]uint64, len(representatives))
+	for i, asset := range representatives {
+		hashes[i] = uint64(*asset.Phash)
+	}
+	for _, indexes := range groupNearDuplicates(hashes, threshold) {
+		members := make([]duplicateAsset, len(indexes))
+		for i, index := range indexes {
+			members[i] = representatives[index]
+		}
+		near = append(near, buildDuplicateGroup(DuplicateTypeNear, fmt.Sprintf("%016x", hashes[indexes[0]]), members))
+	}
+
+	sortDuplicateGroups(exact)
+	sortDuplicateGroups(near)
+	return exact, near
+}
+
+func buildDuplicateGroup(groupType string, hash string, assets []duplicateAsset) dto.DuplicateGroupResponse {
+	group := dto.DuplicateGroupResponse{
+		Type:  groupType,
+		Hash:  hash,
+		Items: make([]dto.DuplicateItemResponse, len(assets)),
+	}
+
+	var largest int64
+	for i, asset := range assets {
+		distance := 0
+		if asset.Phash != nil && assets[0].Phash != nil {
+			distance = utils.HammingDistance(uint64(*asset.Phash), uint64(*assets[0].Phash))
+		}
+
+		group.Items[i] = dto.DuplicateItemResponse{
+			AssetUUID: asset.UUID,
+			MediaUUID: asset.MediaUUID,
+			URL:       asset.URL,
+			Width:     asset.Width,
+			Height:    asset.Height,
+			Bytes:     asset.TotalBytes,
+			Distance:  distance,
+			CreatedAt: asset.CreatedAt,
+		}
+		group.TotalBytes += asset.TotalBytes
+		largest = max(largest, asset.TotalBytes)
+	}
+
+	// Yang dipertahankan: satu salinan (exact) atau file terbesar/kualitas terbaik (near)
+	group.Savings = group.TotalBytes - largest
+	return group
+}
+
+func sortDuplicateGroups(groups []dto.DuplicateGroupResponse) {
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Savings > groups[j].Savings })
+}
+
+// groupNearDuplicates mengelompokkan hash yang jaraknya <= threshold.
+// Hash dibagi threshold+1 potongan: dua hash dengan jarak <= threshold pasti punya minimal satu
+// potongan yang sama persis, jadi yang dibandingkan hanya kandidat satu bucket (bukan semua pasangan).
+func groupNearDuplicates(hashes []uint64, threshold int) [][]int {
+	parent := make([]int, len(hashes))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	chunks := threshold + 1
+	start := 0
+	for c := 0; c < chunks; c++ {
+		size := (64 - start) / (chunks - c)
+		mask := uint64(1)<<size - 1
+
+		buckets := map[uint64][]int{}
+		for i, hash := range hashes {
+			key := (hash >> start) & mask
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, members := range buckets {
+			for a := 0; a < len(members); a++ {
+				for b := a + 1; b < len(members); b++ {
+					i, j := members[a], members[b]
+					if utils.HammingDistance(hashes[i], hashes[j]) <= threshold {
+						parent[find(i)] = find(j)
+					}
+				}
+			}
+		}
+		start += size
+	}
+
+	groups := map[int][]int{}
+	var roots []int
+	for i := range hashes {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+
+	var result [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			result = append(result, groups[root])
+		}
+	}
+	return result
+}
+
+// fillDuplicateAlbums menambahkan album yang memakai tiap gambar
+func fillDuplicateAlbums(groups []dto.DuplicateGroupResponse) error {
+	var urls []string
+	for _, group := range groups {
+		for _, item := range group.Items {
+			urls = append(urls, item.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		URL   string
+		UUID  string
+		Slug  string
+		Title string
+	}
+	if err := config.DB.Table("album_media am").
+		Select("am.url, a.uuid, a.slug, a.title").
+		Joins("JOIN albums a ON a.id = am.album_id AND a.deleted_at IS NULL").
+		Where("am.url IN ?", urls).
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to get duplicate albums: %v", err)
+	}
+
+	albums := map[string][]dto.DuplicateAlbumResponse{}
+	for _, row := range rows {
+		albums[row.URL] = append(albums[row.URL], dto.DuplicateAlbumResponse{UUID: row.UUID, Slug: row.Slug, Title: row.Title})
+	}
+
+	for _, group := range groups {
+		for i := range group.Items {
+			group.Items[i].Albums = albums[group.Items[i].URL]
+		}
+	}
+	return nil
+}
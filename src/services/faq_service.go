@@ -6,6 +6,7 @@ import (
 	"github.com/charis16/luminor-golang-be/src/config"
 	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/models"
+	"gorm.io/gorm"
 )
 
 type FaqInput struct {
@@ -14,33 +15,62 @@ type FaqInput struct {
 	AnswerID    string `json:"answer_id" validate:"required"`
 	AnswerEn    string `json:"answer_en" validate:"required"`
 	IsPublished bool   `json:"is_published" validate:"required"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	// Locale selain id/en, nil = tidak diubah
+	Translations Translations `json:"translations"`
+}
+
+// faqListQuery filter yang sama untuk list admin (offset maupun cursor)
+func faqListQuery(search string) *gorm.DB {
+	query := config.DB.Model(&models.Faq{}).
+		Select("id", "uuid", "question_id", "question_en", "answer_id", "answer_en", "is_published", "publish_at", "unpublish_at", "created_at", "updated_at")
+
+	// Full-text search, ILIKE untuk kata yang belum lengkap
+	if search != "" {
+		query = query.Scopes(adminSearch(search, "question_id", "question_en"))
+	}
+	return query
 }
 
 func GetAllFaqs(page int, limit int, search string) ([]dto.FaqResponse, int64, error) {
 	var faqs []models.Faq
 	var total int64
 
-	query := config.DB.Model(&models.Faq{})
-
-	// Apply search filter if search term is provided
-	if search != "" {
-		searchTerm := "%" + search + "%"
-		query = query.Where("question_id LIKE ? or question_en LIKE ? or answer_id LIKE ? or answer_en LIKE ?", searchTerm)
-	}
+	query := faqListQuery(search)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if search != "" {
+		query = query.Order(adminSearchOrder(search))
+	}
+
 	offset := (page - 1) * limit
 	if err := query.
-		Select("uuid", "question_id", "question_en", "answer_id", "answer_en", "is_published", "created_at", "updated_at").
 		Limit(limit).
 		Offset(offset).
 		Find(&faqs).Error; err != nil {
 		return nil, 0, err
 	}
 
+	return mapFaqListToDTO(faqs), total, nil
+}
+
+func GetFaqsByCursor(params CursorParams, search string) ([]dto.FaqResponse, dto.CursorPageResponse, error) {
+	faqs, page, err := paginateByCursor(faqListQuery(search), params, cursorSorts("question_id"), func(faq models.Faq, column string) (interface{}, int32) {
+		return modelCursorKey(faq.ID, faq.CreatedAt, faq.UpdatedAt, faq.QuestionID, column)
+	})
+	if err != nil {
+		return nil, page, err
+	}
+	return mapFaqListToDTO(faqs), page, nil
+}
+
+func mapFaqListToDTO(faqs []models.Faq) []dto.FaqResponse {
 	// Mapping ke response DTO
 	response := make([]dto.FaqResponse, len(faqs))
 	for i, faq := range faqs {
@@ -51,30 +81,45 @@ func GetAllFaqs(page int, limit int, search string) ([]dto.FaqResponse, int64, e
 			QuestionID:  faq.QuestionID,
 			QuestionEn:  faq.QuestionEn,
 			IsPublished: faq.IsPublished,
+			PublishAt:   faq.PublishAt,
+			UnpublishAt: faq.UnpublishAt,
+			Status:      ContentStatus(faq.IsPublished, faq.PublishAt, faq.UnpublishAt),
 			CreatedAt:   faq.CreatedAt,
 			UpdatedAt:   faq.UpdatedAt,
 		}
 	}
 
-	return response, total, nil
+	return response
 }
 
-func GetPublishedFaqs() ([]dto.FaqResponse, error) {
+func GetPublishedFaqs(locale string) ([]dto.FaqResponse, error) {
 	var faqs []models.Faq
 
 	query := config.DB.Model(&models.Faq{})
 
 	if err := query.
-		Select("uuid", "question_id", "question_en", "answer_id", "answer_en", "is_published", "created_at", "updated_at").
-		Where("is_published = ?", true).
+		Select("id", "uuid", "question_id", "question_en", "answer_id", "answer_en", "is_published", "created_at", "updated_at").
+		Scopes(publishedNow("")).
 		Find(&faqs).Error; err != nil {
 		return nil, err
 	}
 
+	ids := make([]int32, len(faqs))
+	for i, faq := range faqs {
+		ids[i] = faq.ID
+	}
+	translations, err := loadTranslations(config.DB, AuditEntityFaq, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	// Mapping ke response DTO
 	response := make([]dto.FaqResponse, len(faqs))
 	for i, faq := range faqs {
+		question, answer := localizedFaq(faq, translations[faq.ID], locale)
 		response[i] = dto.FaqResponse{
+			Question:    question,
+			Answer:      answer,
 			UUID:        faq.UUID,
 			AnswerID:    faq.AnswerID,
 			AnswerEn:    faq.AnswerEn,
@@ -89,7 +134,7 @@ func GetPublishedFaqs() ([]dto.FaqResponse, error) {
 	return response, nil
 }
 
-func CreateFaq(input FaqInput) (*models.Faq, error) {
+func CreateFaq(input FaqInput, actor Actor) (*models.Faq, error) {
 	tx := config.DB.Begin() // Mulai transaksi
 
 	faq := models.Faq{
@@ -98,6 +143,8 @@ func CreateFaq(input FaqInput) (*models.Faq, error) {
 		QuestionEn:  input.QuestionEn,
 		QuestionID:  input.QuestionID,
 		IsPublished: input.IsPublished,
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -107,6 +154,16 @@ func CreateFaq(input FaqInput) (*models.Faq, error) {
 		return nil, err
 	}
 
+	if err := saveTranslations(tx, AuditEntityFaq, faq.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityFaq, faq.UUID, nil, withTranslations(tx, auditSnapshot(faq), AuditEntityFaq, faq.ID)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, err // commit gagal
 	}
@@ -122,7 +179,7 @@ func GetFaqByUUID(uuid string) (models.Faq, error) {
 	return faq, nil
 }
 
-func UpdateFaq(uuid string, input FaqInput) (models.Faq, error) {
+func UpdateFaq(uuid string, input FaqInput, actor Actor) (models.Faq, error) {
 	tx := config.DB.Begin()
 	var faq models.Faq
 
@@ -131,11 +188,15 @@ func UpdateFaq(uuid string, input FaqInput) (models.Faq, error) {
 		return models.Faq{}, err
 	}
 
+	before := withTranslations(tx, auditSnapshot(faq), AuditEntityFaq, faq.ID)
+
 	faq.AnswerEn = input.AnswerEn
 	faq.AnswerID = input.AnswerID
 	faq.QuestionEn = input.QuestionEn
 	faq.QuestionID = input.QuestionID
 	faq.IsPublished = input.IsPublished
+	faq.PublishAt = input.PublishAt
+	faq.UnpublishAt = input.UnpublishAt
 	faq.UpdatedAt = time.Now()
 
 	if err := tx.Save(&faq).Error; err != nil {
@@ -143,6 +204,16 @@ func UpdateFaq(uuid string, input FaqInput) (models.Faq, error) {
 		return models.Faq{}, err
 	}
 
+	if err := saveTranslations(tx, AuditEntityFaq, faq.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return models.Faq{}, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityFaq, faq.UUID, before, withTranslations(tx, auditSnapshot(faq), AuditEntityFaq, faq.ID)); err != nil {
+		tx.Rollback()
+		return models.Faq{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return models.Faq{}, err
 	}
@@ -150,9 +221,17 @@ func UpdateFaq(uuid string, input FaqInput) (models.Faq, error) {
 	return faq, nil
 }
 
-func DeleteFaq(uuid string) error {
-	if err := config.DB.Where("uuid = ?", uuid).Delete(&models.Faq{}).Error; err != nil {
-		return err
-	}
-	return nil
+func DeleteFaq(uuid string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		var faq models.Faq
+		if err := tx.Where("uuid = ?", uuid).First(&faq).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&faq).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditActionDelete, AuditEntityFaq, faq.UUID, auditSnapshot(faq), nil)
+	})
 }
@@ -0,0 +1,528 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAlbumNotPrivate         = errors.New("gallery links are only available for private albums")
+	ErrGalleryLinkNotFound     = errors.New("gallery link not found")
+	ErrGalleryNotFound         = errors.New("gallery not found or link has expired")
+	ErrGalleryPasswordRequired = errors.New("gallery password required")
+	ErrGalleryPasswordInvalid  = errors.New("invalid gallery password")
+	ErrGalleryRateLimited      = errors.New("too many attempts, please try again later")
+	ErrGalleryLinkExpiry       = errors.New("expires_at must be in the future")
+)
+
+// Maksimal 10 percobaan password per link per 15 menit
+var galleryUnlockLimiter = utils.NewRateLimiter(10, 15*time.Minute)
+
+type AlbumVisibilityInput struct {
+	Visibility string `json:"visibility" validate:"required,oneof=public private"`
+}
+
+type GalleryLinkInput struct {
+	Label     string     `json:"label" validate:"max=100"`
+	Password  string     `json:"password" validate:"omitempty,min=6"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type GalleryUnlockInput struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func mapGalleryLinkToDTO(link models.GalleryLink) dto.GalleryLinkResponse {
+	return dto.GalleryLinkResponse{
+		UUID:           link.UUID,
+		Label:          link.Label,
+		HasPassword:    link.PasswordHash != "",
+		ExpiresAt:      link.ExpiresAt,
+		RevokedAt:      link.RevokedAt,
+		LastAccessedAt: link.LastAccessedAt,
+		AccessCount:    link.AccessCount,
+		CreatedAt:      link.CreatedAt,
+	}
+}
+
+func galleryShareURL(token string) string {
+	shareURL := utils.GetEnvOrDefault("GALLERY_SHARE_URL", "http://localhost:3000/gallery")
+	return strings.TrimRight(shareURL, "/") + "/" + token
+}
+
+// albumObjects semua file album (media + thumbnail) dan derivative-nya
+type albumObjects struct {
+	URLs     []string
+	Keys     []string
+	Variants []models.ImageVariant
+}
+
+func collectAlbumObjects(db *gorm.DB, album models.Album) (albumObjects, error) {
+	var objects albumObjects
+
+	urls := AlbumMediaURLs(album)
+	if album.Thumbnail != "" && !slices.Contains(urls, album.Thumbnail) {
+		urls = append(urls, album.Thumbnail)
+	}
+	objects.URLs = urls
+
+	seen := map[string]bool{}
+	addKey := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			objects.Keys = append(objects.Keys, key)
+		}
+	}
+
+	for _, url := range urls {
+		key, _, err := utils.ResolveObjectURL(url)
+		if err != nil {
+			return objects, fmt.Errorf("cannot move %s: %v", url, err)
+		}
+		addKey(key)
+	}
+
+	if len(urls) > 0 {
+		if err := db.
+			Joins("JOIN image_assets ON image_assets.id = image_variants.asset_id").
+			Where("image_assets.url IN ?", urls).
+			Find(&objects.Variants).Error; err != nil {
+			return objects, fmt.Errorf("failed to get image variants: %v", err)
+		}
+	}
+	for _, v := range objects.Variants {
+		addKey(v.StorageKey)
+	}
+
+	return objects, nil
+}
+
+// removeCopiedObjects membersihkan salinan kalau pindah storage gagal di tengah jalan
+func removeCopiedObjects(store utils.Storage, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(context.TODO(), key); err != nil && !errors.Is(err, utils.ErrObjectNotFound) {
+			log.Printf("⚠️ Failed to remove copied object %s: %v\n", key, err)
+		}
+	}
+}
+
+func convertObjectURL(url string, private bool) string {
+	key, _, err := utils.ResolveObjectURL(url)
+	if err != nil {
+		return url
+	}
+	return utils.ObjectURL(key, private)
+}
+
+// SetAlbumVisibility memindahkan semua file album ke storage public/private lalu
+// mengganti URL-nya. File lama dihapus worker setelah tx commit.
+func SetAlbumVisibility(albumUUID string, input AlbumVisibilityInput, actor Actor) (dto.AlbumResponse, error) {
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return dto.AlbumResponse{}, err
+	}
+
+	visibility, err := ParseAlbumVisibility(input.Visibility)
+	if err != nil {
+		return dto.AlbumResponse{}, err
+	}
+	if visibility == album.Visibility {
+		return mapAlbumsToDTO([]models.Album{album})[0], nil
+	}
+
+	fromPrivate := IsPrivateAlbum(album)
+	toPrivate := visibility == AlbumVisibilityPrivate
+	from, to := utils.StoreFor(fromPrivate), utils.StoreFor(toPrivate)
+	if from == nil || to == nil {
+		return dto.AlbumResponse{}, utils.ErrPrivateStorageDisabled
+	}
+
+	objects, err := collectAlbumObjects(config.DB, album)
+	if err != nil {
+		return dto.AlbumResponse{}, err
+	}
+
+	// Salin dulu di luar tx, DB baru diubah setelah semua file ada di storage tujuan
+	var copied []string
+	for _, key := range objects.Keys {
+		err := utils.CopyObject(context.TODO(), from, to, key)
+		if errors.Is(err, utils.ErrObjectNotFound) {
+			log.Printf("⚠️ Skip moving missing object %s\n", key)
+			continue
+		}
+		if err != nil {
+			removeCopiedObjects(to, copied)
+			return dto.AlbumResponse{}, fmt.Errorf("failed to copy %s: %v", key, err)
+		}
+		copied = append(copied, key)
+	}
+
+	var updated models.Album
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		var locked models.Album
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", album.ID).First(&locked).Error; err != nil {
+			return fmt.Errorf("album not found")
+		}
+
+		current, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		// Media yang ditambah selama proses copy belum ikut tersalin
+		currentObjects, err := collectAlbumObjects(tx, current)
+		if err != nil {
+			return err
+		}
+		if current.Visibility != album.Visibility || !slices.Equal(currentObjects.URLs, objects.URLs) {
+			return fmt.Errorf("album was changed while moving files, please try again")
+		}
+
+		updated = current
+		updated.Visibility = visibility
+		updated.Thumbnail = ""
+		if current.Thumbnail != "" {
+			updated.Thumbnail = convertObjectURL(current.Thumbnail, toPrivate)
+		}
+		updated.UpdatedAt = time.Now()
+
+		if err := tx.Model(&models.Album{}).Where("id = ?", current.ID).Updates(map[string]interface{}{
+			"visibility": updated.Visibility,
+			"thumbnail":  updated.Thumbnail,
+			"updated_at": updated.UpdatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update album: %v", err)
+		}
+
+		updated.Media = make([]models.AlbumMedia, len(current.Media))
+		for i, m := range current.Media {
+			m.URL = convertObjectURL(m.URL, toPrivate)
+			if err := tx.Model(&models.AlbumMedia{}).Where("id = ?", m.ID).
+				Updates(map[string]interface{}{"url": m.URL, "updated_at": time.Now()}).Error; err != nil {
+				return fmt.Errorf("failed to update media url: %v", err)
+			}
+			updated.Media[i] = m
+		}
+
+		for _, url := range currentObjects.URLs {
+			if err := tx.Model(&models.ImageAsset{}).Where("url = ?", url).Updates(map[string]interface{}{
+				"url":        convertObjectURL(url, toPrivate),
+				"is_private": toPrivate,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("failed to update image asset: %v", err)
+			}
+		}
+
+		for _, v := range currentObjects.Variants {
+			if err := tx.Model(&models.ImageVariant{}).Where("id = ?", v.ID).
+				Update("url", utils.ObjectURL(v.StorageKey, toPrivate)).Error; err != nil {
+				return fmt.Errorf("failed to update image variant: %v", err)
+			}
+		}
+
+		if err := queueObjectDeletions(tx, "album_visibility", fromPrivate, currentObjects.Keys...); err != nil {
+			return err
+		}
+
+		// Album yang jadi publik perlu watermark, yang jadi private dibersihkan watermark-nya
+		if err := queueWatermarkForAlbums(tx, albumIDQuery(tx, "id = ?", current.ID)); err != nil {
+			return err
+		}
+
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, current.UUID, albumAuditSnapshot(current), albumAuditSnapshot(updated)); err != nil {
+			return err
+		}
+
+		// Album yang sedang tayang hilang/muncul di halaman publik
+		if ContentStatus(current.IsPublished, current.PublishAt, current.UnpublishAt) == ContentStatusPublished {
+			transition := TransitionPublished
+			if toPrivate {
+				transition = TransitionUnpublished
+			}
+			event := newRevalidationEvent(AuditEntityAlbum, current.UUID, current.Slug, transition, time.Now())
+			if err := tx.Create(&event).Error; err != nil {
+				return fmt.Errorf("failed to queue revalidation event: %v", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		removeCopiedObjects(to, copied)
+		return dto.AlbumResponse{}, err
+	}
+
+	return mapAlbumsToDTO([]models.Album{updated})[0], nil
+}
+
+func getPrivateAlbum(db *gorm.DB, albumUUID string, actor Actor) (models.Album, error) {
+	album, err := getAuthorizedAlbum(db, albumUUID, actor)
+	if err != nil {
+		return models.Album{}, err
+	}
+	if !IsPrivateAlbum(album) {
+		return models.Album{}, ErrAlbumNotPrivate
+	}
+	return album, nil
+}
+
+// CreateGalleryLink membuat link share. Token hanya dikembalikan sekali, DB menyimpan hash-nya.
+func CreateGalleryLink(albumUUID string, input GalleryLinkInput, actor Actor) (dto.GalleryLinkResponse, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return dto.GalleryLinkResponse{}, ErrGalleryLinkExpiry
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return dto.GalleryLinkResponse{}, fmt.Errorf("failed to generate gallery token: %v", err)
+	}
+
+	var response dto.GalleryLinkResponse
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getPrivateAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		link := models.GalleryLink{
+			AlbumID:   album.ID,
+			Label:     strings.TrimSpace(input.Label),
+			TokenHash: hashSecretToken(token),
+			ExpiresAt: input.ExpiresAt,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if actor.UserUUID != "" {
+			link.CreatedBy = &actor.UserUUID
+		}
+		if input.Password != "" {
+			link.PasswordHash = utils.HashPassword(input.Password)
+		}
+
+		if err := tx.Omit("Album").Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to create gallery link: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityGalleryLink, link.UUID, nil, auditSnapshot(link)); err != nil {
+			return err
+		}
+
+		response = mapGalleryLinkToDTO(link)
+		response.Token = token
+		response.URL = galleryShareURL(token)
+		return nil
+	})
+
+	return response, err
+}
+
+func GetGalleryLinks(albumUUID string, actor Actor) ([]dto.GalleryLinkResponse, error) {
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []models.GalleryLink
+	if err := config.DB.Where("album_id = ?", album.ID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get gallery links: %v", err)
+	}
+
+	response := make([]dto.GalleryLinkResponse, len(links))
+	for i, link := range links {
+		response[i] = mapGalleryLinkToDTO(link)
+	}
+	return response, nil
+}
+
+func RevokeGalleryLink(albumUUID string, linkUUID string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		var link models.GalleryLink
+		if err := tx.Where("uuid = ? AND album_id = ?", linkUUID, album.ID).First(&link).Error; err != nil {
+			return ErrGalleryLinkNotFound
+		}
+		if link.RevokedAt != nil {
+			return nil
+		}
+
+		before := auditSnapshot(link)
+		now := time.Now()
+		link.RevokedAt = &now
+		link.UpdatedAt = now
+
+		if err := tx.Model(&models.GalleryLink{}).Where("id = ?", link.ID).
+			Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to revoke gallery link: %v", err)
+		}
+
+		return recordAudit(tx, actor, AuditActionUpdate, AuditEntityGalleryLink, link.UUID, before, auditSnapshot(link))
+	})
+}
+
+// findActiveGalleryLink mencari link yang belum dicabut/kadaluarsa untuk album private
+func findActiveGalleryLink(token string) (models.GalleryLink, error) {
+	var link models.GalleryLink
+	if token == "" {
+		return link, ErrGalleryNotFound
+	}
+
+	err := config.DB.
+		Joins("JOIN albums ON albums.id = gallery_links.album_id").
+		Where("gallery_links.token_hash = ?", hashSecretToken(token)).
+		Where("gallery_links.revoked_at IS NULL").
+		Where("gallery_links.expires_at IS NULL OR gallery_links.expires_at > ?", time.Now()).
+		Where("albums.deleted_at IS NULL AND albums.visibility = ?", AlbumVisibilityPrivate).
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return link, ErrGalleryNotFound
+	}
+	if err != nil {
+		return link, fmt.Errorf("failed to get gallery link: %v", err)
+	}
+	return link, nil
+}
+
+// UnlockGallery mengecek password link lalu mengembalikan token akses (header X-Gallery-Access)
+func UnlockGallery(token string, input GalleryUnlockInput) (dto.GalleryAccessResponse, error) {
+	if !galleryUnlockLimiter.Allow(hashSecretToken(token)) {
+		return dto.GalleryAccessResponse{}, ErrGalleryRateLimited
+	}
+
+	link, err := findActiveGalleryLink(token)
+	if err != nil {
+		return dto.GalleryAccessResponse{}, err
+	}
+
+	if link.PasswordHash != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(input.Password)); err != nil {
+			return dto.GalleryAccessResponse{}, ErrGalleryPasswordInvalid
+		}
+	}
+
+	// Token akses tidak boleh berlaku lebih lama dari link-nya
+	expiresAt := time.Now().Add(utils.GalleryAccessExpiration())
+	if link.ExpiresAt != nil && link.ExpiresAt.Before(expiresAt) {
+		expiresAt = *link.ExpiresAt
+	}
+
+	access, err := utils.EncodeGalleryAccess(link.UUID, expiresAt)
+	if err != nil {
+		return dto.GalleryAccessResponse{}, fmt.Errorf("failed to create gallery access: %v", err)
+	}
+
+	return dto.GalleryAccessResponse{AccessToken: access, ExpiresAt: expiresAt}, nil
+}
+
+// OpenGallery mengembalikan isi gallery private dengan signed URL
+func OpenGallery(token string, access string, locale string) (dto.GalleryResponse, error) {
+	link, err := findActiveGalleryLink(token)
+	if err != nil {
+		return dto.GalleryResponse{}, err
+	}
+
+	if link.PasswordHash != "" && utils.VerifyGalleryAccess(access, link.UUID) != nil {
+		return dto.GalleryResponse{}, ErrGalleryPasswordRequired
+	}
+
+	var album models.Album
+	if err := config.DB.
+		Preload("User").
+		Preload("Category").
+		Preload("Media", orderAlbumMedia).
+		Where("id = ?", link.AlbumID).
+		First(&album).Error; err != nil {
+		return dto.GalleryResponse{}, ErrGalleryNotFound
+	}
+
+	albums := []models.Album{album}
+	if err := localizeAlbums(albums, locale); err != nil {
+		return dto.GalleryResponse{}, err
+	}
+	album = albums[0]
+
+	media, thumbnail, err := signedGalleryMedia(album)
+	if err != nil {
+		return dto.GalleryResponse{}, err
+	}
+
+	response := dto.GalleryResponse{
+		Title:        album.Title,
+		Description:  album.Description,
+		CategoryName: album.Category.Name,
+		UserName:     album.User.Name,
+		UserSlug:     album.User.Slug,
+		ThumbnailSet: thumbnail,
+		Media:        media,
+		ExpiresAt:    link.ExpiresAt,
+		URLExpiresAt: time.Now().Add(utils.PrivateURLExpiration()),
+	}
+
+	// Statistik akses cukup best effort
+	if err := config.DB.Model(&models.GalleryLink{}).Where("id = ?", link.ID).Updates(map[string]interface{}{
+		"last_accessed_at": time.Now(),
+		"access_count":     gorm.Expr("access_count + 1"),
+	}).Error; err != nil {
+		log.Printf("⚠️ Failed to update gallery link %s stats: %v\n", link.UUID, err)
+	}
+
+	return response, nil
+}
+
+// signedGalleryMedia menyiapkan media dan thumbnail album untuk client, URL private sudah signed
+func signedGalleryMedia(album models.Album) ([]dto.GalleryMediaResponse, *dto.ResponsiveImageResponse, error) {
+	urls := AlbumMediaURLs(album)
+	thumbnailURL := album.Thumbnail
+	if thumbnailURL == "" {
+		thumbnailURL = albumCoverURL(album)
+	}
+	if thumbnailURL != "" {
+		urls = append(urls, thumbnailURL)
+	}
+
+	images, err := GetResponsiveImages(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+	}
+
+	media := make([]dto.GalleryMediaResponse, len(album.Media))
+	for i, m := range album.Media {
+		image, err := signResponsiveImage(ResponsiveImage(images, m.URL))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign gallery image: %v", err)
+		}
+		media[i] = dto.GalleryMediaResponse{
+			UUID:      m.UUID,
+			CaptionEn: m.CaptionEn,
+			CaptionID: m.CaptionID,
+			AltText:   m.AltText,
+			Image:     image,
+		}
+	}
+
+	if thumbnailURL == "" {
+		return media, nil, nil
+	}
+	thumbnail, err := signResponsiveImage(ResponsiveImage(images, thumbnailURL))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign gallery thumbnail: %v", err)
+	}
+	return media, &thumbnail, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+)
+
+// Kolom yang menyimpan URL gambar yang perlu didaftarkan ke image_assets (dipakai command backfill)
+var imageAssetReferences = []storageReference{
+	{Table: "album_media", Column: "url"},
+	{Table: "albums", Column: "thumbnail"},
+	{Table: "categories", Column: "photo_url"},
+	{Table: "users", Column: "photo"},
+	{Table: "websites", Column: "og_image"},
+}
+
+type ImageBackfillReport struct {
+	Registered int // gambar lama yang belum punya image_assets, masuk antrian image worker
+	Processed  int
+	Failed     int
+}
+
+// BackfillImageAssets mengisi data turunan (placeholder, hash duplikat) untuk gambar yang di-upload
+// sebelum fitur tersebut ada. Gambar tanpa image_assets didaftarkan ke antrian image worker,
+// asset yang sudah ready tapi datanya belum lengkap dihitung langsung.
+func BackfillImageAssets(batch int, logf func(string, ...any)) (ImageBackfillReport, error) {
+	var report ImageBackfillReport
+
+	registered, err := registerLegacyImages(logf)
+	report.Registered = registered
+	if err != nil {
+		return report, err
+	}
+
+	var lastID int32
+	for {
+		var assets []models.ImageAsset
+		if err := config.DB.
+			Where("id > ? AND status = ?", lastID, ImageStatusReady).
+			Where("blurhash IS NULL OR sha256 IS NULL OR phash IS NULL").
+			Order("id ASC").
+			Limit(batch).
+			Find(&assets).Error; err != nil {
+			return report, fmt.Errorf("failed to get image assets: %v", err)
+		}
+		if len(assets) == 0 {
+			break
+		}
+
+		for _, asset := range assets {
+			lastID = asset.ID
+			if err := backfillImageAsset(asset); err != nil {
+				report.Failed++
+				logf("❌ %s: %v\n", asset.URL, err)
+				continue
+			}
+			report.Processed++
+		}
+		logf("🖼️  %d asset diproses, %d gagal\n", report.Processed, report.Failed)
+	}
+
+	return report, nil
+}
+
+func backfillImageAsset(asset models.ImageAsset) error {
+	store := utils.StoreFor(asset.IsPrivate)
+	if store == nil {
+		return utils.ErrPrivateStorageDisabled
+	}
+
+	data, _, err := readStorageObject(store, asset.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	img, _, _, err := decodeOrientedImage(data)
+	if err != nil {
+		return err
+	}
+
+	columns, err := imagePlaceholderColumns(img)
+	if err != nil {
+		return err
+	}
+	columns["sha256"] = utils.SHA256Hex(data)
+	columns["phash"] = int64(utils.DifferenceHash(img))
+	columns["updated_at"] = time.Now()
+
+	return config.DB.Model(&models.ImageAsset{}).Where("id = ?", asset.ID).Updates(columns).Error
+}
+
+// registerLegacyImages mendaftarkan URL gambar yang tersimpan di DB tapi belum punya image_assets
+func registerLegacyImages(logf func(string, ...any)) (int, error) {
+	registered := 0
+
+	for _, ref := range imageAssetReferences {
+		var urls []string
+		if err := config.DB.Table(ref.Table).
+			Distinct(ref.Column).
+			Where(ref.Column+" IS NOT NULL AND "+ref.Column+" <> ''").
+			Where(ref.Column+" NOT IN (?)", config.DB.Model(&models.ImageAsset{}).Select("url")).
+			Pluck(ref.Column, &urls).Error; err != nil {
+			return registered, fmt.Errorf("failed to read %s.%s: %v", ref.Table, ref.Column, err)
+		}
+
+		for _, url := range urls {
+			// Link eksternal dan file selain gambar (mis. SVG) dilewati
+			contentType := mime.TypeByExtension(strings.ToLower(path.Ext(url)))
+			if !strings.HasPrefix(contentType, "image/") || contentType == "image/svg+xml" {
+				continue
+			}
+			if _, _, err := utils.ResolveObjectURL(url); err != nil {
+				continue
+			}
+
+			if err := registerImageAsset(url, contentType, 0, ""); err != nil {
+				logf("⚠️ Failed to register %s: %v\n", url, err)
+				continue
+			}
+			registered++
+		}
+	}
+
+	return registered, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// decodeOrientedImage decode gambar lalu memutarnya sesuai EXIF Orientation.
+// exif nil kalau file tidak punya EXIF (atau EXIF-nya rusak).
+func decodeOrientedImage(data []byte) (image.Image, string, *utils.ExifData, error) {
+	img, format, err := utils.DecodeImage(data)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	exif, err := utils.ParseExif(data)
+	if err != nil {
+		if !errors.Is(err, utils.ErrNoExif) {
+			log.Printf("⚠️ Failed to parse EXIF: %v\n", err)
+		}
+		return img, format, nil, nil
+	}
+
+	return utils.ApplyOrientation(img, exif.Orientation), format, exif, nil
+}
+
+// exifRedactGPS default true: lokasi foto tidak disimpan kecuali EXIF_REDACT_GPS=false
+func exifRedactGPS() bool {
+	redact, err := strconv.ParseBool(utils.GetEnvOrDefault("EXIF_REDACT_GPS", "true"))
+	return err != nil || redact
+}
+
+func buildImageMetadata(assetID int32, exif *utils.ExifData) models.ImageMetadata {
+	now := time.Now()
+	metadata := models.ImageMetadata{
+		AssetID:      assetID,
+		CameraMake:   exif.CameraMake,
+		CameraModel:  exif.CameraModel,
+		LensModel:    exif.LensModel,
+		FocalLength:  exif.FocalLength,
+		Aperture:     exif.Aperture,
+		ExposureTime: exif.ExposureTime,
+		CapturedAt:   exif.CapturedAt,
+		Orientation:  int32(exif.Orientation),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if exif.FocalLength35mm != nil {
+		v := int32(*exif.FocalLength35mm)
+		metadata.FocalLength35mm = &v
+	}
+	if exif.ISO != nil {
+		v := int32(*exif.ISO)
+		metadata.Iso = &v
+	}
+	if exif.GPS != nil && !exifRedactGPS() {
+		metadata.GpsLatitude = &exif.GPS.Latitude
+		metadata.GpsLongitude = &exif.GPS.Longitude
+		metadata.GpsAltitude = exif.GPS.Altitude
+	}
+
+	return metadata
+}
+
+// saveImageMetadata menyimpan ulang metadata asset (dipanggil tiap kali worker memproses asset)
+func saveImageMetadata(tx *gorm.DB, assetID int32, exif *utils.ExifData) error {
+	if exif == nil {
+		return tx.Where("asset_id = ?", assetID).Delete(&models.ImageMetadata{}).Error
+	}
+
+	metadata := buildImageMetadata(assetID, exif)
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "asset_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"camera_make", "camera_model", "lens_model", "focal_length", "focal_length_35mm",
+			"aperture", "exposure_time", "iso", "captured_at", "orientation",
+			"gps_latitude", "gps_longitude", "gps_altitude", "updated_at",
+		}),
+	}).Create(&metadata).Error
+}
+
+// GetImageMetadata mengambil metadata banyak URL sekaligus. URL tanpa metadata tidak ada di map.
+func GetImageMetadata(urls []string) (map[string]dto.ImageMetadataResponse, error) {
+	result := map[string]dto.ImageMetadataResponse{}
+	if len(urls) == 0 {
+		return result, nil
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Joins("Metadata").
+		Where("image_assets.url IN ?", urls).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get image metadata: %v", err)
+	}
+
+	for _, asset := range assets {
+		if asset.Metadata != nil {
+			result[asset.URL] = mapImageMetadataToDTO(*asset.Metadata)
+		}
+	}
+
+	return result, nil
+}
+
+func mapImageMetadataToDTO(m models.ImageMetadata) dto.ImageMetadataResponse {
+	response := dto.ImageMetadataResponse{
+		CameraMake:      m.CameraMake,
+		CameraModel:     m.CameraModel,
+		LensModel:       m.LensModel,
+		FocalLength:     m.FocalLength,
+		FocalLength35mm: m.FocalLength35mm,
+		Aperture:        m.Aperture,
+		ExposureTime:    m.ExposureTime,
+		ISO:             m.Iso,
+		CapturedAt:      m.CapturedAt,
+		Orientation:     m.Orientation,
+	}
+
+	if m.GpsLatitude != nil && m.GpsLongitude != nil {
+		response.GPS = &dto.ImageGPSResponse{
+			Latitude:  *m.GpsLatitude,
+			Longitude: *m.GpsLongitude,
+			Altitude:  m.GpsAltitude,
+		}
+	}
+
+	return response
+}
+
+// GetStrippedImages salinan tanpa metadata (variant stripped) untuk banyak URL sekaligus.
+// URL yang belum punya salinan tidak ada di map.
+func GetStrippedImages(urls []string) (map[string]dto.ResponsiveImageResponse, error) {
+	result := map[string]dto.ResponsiveImageResponse{}
+	if len(urls) == 0 {
+		return result, nil
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Preload("Variants", "name NOT LIKE ?", VariantWatermarkPrefix+"%").
+		Where("url IN ? AND status = ?", urls, ImageStatusReady).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stripped images: %v", err)
+	}
+
+	for _, asset := range assets {
+		img := mapImageAssetToDTO(asset)
+		for _, v := range img.Variants {
+			if v.Name == VariantStripped {
+				img.URL = v.URL
+				result[asset.URL] = img
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// applyStrippedImages: kalau EXIF_REDACT_GPS aktif, file original (masih ada GPS di EXIF) tidak pernah
+// dikirim ke halaman publik. Album ber-watermark dilewati, gambar yang belum diproses disembunyikan.
+func applyStrippedImages(responses []dto.AlbumResponse, watermarked []bool) {
+	if !exifRedactGPS() {
+		return
+	}
+
+	var urls []string
+	for i, response := range responses {
+		if watermarked[i] {
+			continue
+		}
+		urls = append(urls, response.Images...)
+		urls = append(urls, response.Thumbnail)
+		for _, m := range response.Media {
+			urls = append(urls, m.URL)
+		}
+	}
+
+	images, err := GetStrippedImages(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		images = map[string]dto.ResponsiveImageResponse{}
+	}
+
+	for i := range responses {
+		if !watermarked[i] {
+			replacePublicImages(&responses[i], images)
+		}
+	}
+}
+
+// redactPublicMetadata: lokasi foto tidak pernah dikirim ke halaman publik
+func redactPublicMetadata(responses []dto.AlbumResponse) {
+	for i := range responses {
+		for j := range responses[i].Media {
+			if metadata := responses[i].Media[j].Metadata; metadata != nil {
+				redacted := *metadata
+				redacted.GPS = nil
+				responses[i].Media[j].Metadata = &redacted
+			}
+		}
+	}
+}
+
+// albumMetadataFilter: album yang punya minimal satu foto sesuai filter kamera/lensa/tahun
+func albumMetadataFilter(filter AlbumListFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ShotYear == 0 && filter.Camera == "" && filter.Lens == "" {
+			return db
+		}
+
+		sub := config.DB.Table("album_media am").
+			Select("am.album_id").
+			Joins("JOIN image_assets ia ON ia.url = am.url").
+			Joins("JOIN image_metadata im ON im.asset_id = ia.id")
+
+		if filter.ShotYear != 0 {
+			from := time.Date(filter.ShotYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+			sub = sub.Where("im.captured_at >= ? AND im.captured_at < ?", from, from.AddDate(1, 0, 0))
+		}
+		if filter.Camera != "" {
+			sub = sub.Where("CONCAT_WS(' ', im.camera_make, im.camera_model) ILIKE ?", "%"+filter.Camera+"%")
+		}
+		if filter.Lens != "" {
+			sub = sub.Where("im.lens_model ILIKE ?", "%"+filter.Lens+"%")
+		}
+
+		return db.Where("albums.id IN (?)", sub)
+	}
+}
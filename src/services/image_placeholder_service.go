@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+)
+
+// imagePlaceholderColumns menghitung placeholder untuk disimpan ke image_assets
+func imagePlaceholderColumns(img image.Image) (map[string]interface{}, error) {
+	placeholder, err := utils.BuildImagePlaceholder(img)
+	if err != nil {
+		return nil, err
+	}
+
+	palette, err := json.Marshal(placeholder.Palette)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"blurhash":       placeholder.Blurhash,
+		"lqip":           placeholder.LQIP,
+		"dominant_color": placeholder.DominantColor,
+		"palette":        string(palette),
+	}, nil
+}
+
+func mapImagePlaceholderToDTO(asset models.ImageAsset) *dto.ImagePlaceholderResponse {
+	if asset.Blurhash == nil {
+		return nil
+	}
+
+	response := &dto.ImagePlaceholderResponse{
+		Blurhash: *asset.Blurhash,
+		Palette:  []string{},
+	}
+	if asset.Lqip != nil {
+		response.LQIP = *asset.Lqip
+	}
+	if asset.DominantColor != nil {
+		response.DominantColor = *asset.DominantColor
+	}
+	if len(asset.Palette) > 0 {
+		_ = json.Unmarshal(asset.Palette, &response.Palette)
+	}
+	return response
+}
+
+// GetImagePlaceholders mengambil placeholder banyak URL sekaligus. URL yang belum diproses tidak ada di map.
+func GetImagePlaceholders(urls []string) (map[string]dto.ImagePlaceholderResponse, error) {
+	result := map[string]dto.ImagePlaceholderResponse{}
+	if len(urls) == 0 {
+		return result, nil
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Select("url", "blurhash", "lqip", "dominant_color", "palette").
+		Where("url IN ? AND blurhash IS NOT NULL", urls).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get image placeholders: %v", err)
+	}
+
+	for _, asset := range assets {
+		if placeholder := mapImagePlaceholderToDTO(asset); placeholder != nil {
+			result[asset.URL] = *placeholder
+		}
+	}
+
+	return result, nil
+}
+
+// GetImagePlaceholder versi satu URL dari GetImagePlaceholders
+func GetImagePlaceholder(url string) *dto.ImagePlaceholderResponse {
+	if url == "" {
+		return nil
+	}
+	placeholders, err := GetImagePlaceholders([]string{url})
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		return nil
+	}
+	return ImagePlaceholder(placeholders, url)
+}
+
+// ImagePlaceholder mengambil hasil GetImagePlaceholders, nil kalau belum ada
+func ImagePlaceholder(placeholders map[string]dto.ImagePlaceholderResponse, url string) *dto.ImagePlaceholderResponse {
+	if placeholder, ok := placeholders[url]; ok {
+		return &placeholder
+	}
+	return nil
+}
+
+// applyUserPlaceholders mengisi placeholder foto user di response list (satu query)
+func applyUserPlaceholders(responses []dto.UserResponse) {
+	urls := make([]string, 0, len(responses))
+	for _, r := range responses {
+		if r.Photo != "" {
+			urls = append(urls, r.Photo)
+		}
+	}
+
+	placeholders, err := GetImagePlaceholders(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		return
+	}
+	for i := range responses {
+		responses[i].PhotoPlaceholder = ImagePlaceholder(placeholders, responses[i].Photo)
+	}
+}
+
+// applyCategoryPlaceholders mengisi placeholder foto kategori di response list (satu query)
+func applyCategoryPlaceholders(responses []dto.CategoryResponse) {
+	urls := make([]string, 0, len(responses))
+	for _, r := range responses {
+		if r.PhotoUrl != "" {
+			urls = append(urls, r.PhotoUrl)
+		}
+	}
+
+	placeholders, err := GetImagePlaceholders(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		return
+	}
+	for i := range responses {
+		responses[i].PhotoPlaceholder = ImagePlaceholder(placeholders, responses[i].PhotoUrl)
+	}
+}
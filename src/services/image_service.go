@@ -0,0 +1,565 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"io"
+	"log"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ImageStatusPending    = "pending"
+	ImageStatusProcessing = "processing"
+	ImageStatusReady      = "ready"
+	ImageStatusFailed     = "failed"
+
+	VariantStripped = "stripped"
+
+	imageJPEGQuality   = 82
+	imageMaxAttempts   = 5
+	imageWorkerBatch   = 5
+	imageLockTimeout   = 10 * time.Minute
+	imageMaxSourceSize = 50 << 20
+)
+
+type imageSize struct {
+	Name  string
+	Width int
+}
+
+// Lebar derivative, dari kecil ke besar. Ukuran yang >= lebar original dilewati.
+var imageSizes = []imageSize{
+	{Name: "small", Width: 480},
+	{Name: "medium", Width: 1024},
+	{Name: "large", Width: 1920},
+}
+
+type imageOutput struct {
+	Name   string
+	Format string
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// checksum CRC32 output, disimpan di image_variants untuk ZIP download
+func (o imageOutput) checksum() *int64 {
+	checksum := int64(crc32.ChecksumIEEE(o.Data))
+	return &checksum
+}
+
+// UploadImage sama seperti utils.UploadFile, tapi gambar juga didaftarkan
+// ke antrian derivative (thumbnail, ukuran responsive, WebP, salinan tanpa EXIF)
+func UploadImage(file multipart.File, fileHeader *multipart.FileHeader, prefix string) (string, error) {
+	// Checksum dihitung sebelum upload supaya duplikat bisa langsung dikenali
+	checksum, err := utils.FileSHA256(file)
+	if err != nil {
+		return "", err
+	}
+
+	fileURL, err := utils.UploadFile(file, fileHeader, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return queueUploadedImage(fileURL, fileHeader, checksum), nil
+}
+
+// UploadAlbumImage meng-upload gambar album ke storage sesuai visibility album
+func UploadAlbumImage(file multipart.File, fileHeader *multipart.FileHeader, private bool) (string, error) {
+	if !private {
+		return UploadImage(file, fileHeader, "albums")
+	}
+
+	checksum, err := utils.FileSHA256(file)
+	if err != nil {
+		return "", err
+	}
+
+	fileURL, err := utils.UploadPrivateFile(file, fileHeader, "albums")
+	if err != nil {
+		return "", err
+	}
+
+	return queueUploadedImage(fileURL, fileHeader, checksum), nil
+}
+
+func queueUploadedImage(fileURL string, fileHeader *multipart.FileHeader, checksum string) string {
+	contentType := fileHeader.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") || contentType == "image/svg+xml" {
+		return fileURL
+	}
+
+	// File sudah ter-upload, jadi gagal daftar antrian cukup di-log saja
+	if err := registerImageAsset(fileURL, contentType, fileHeader.Size, checksum); err != nil {
+		log.Printf("⚠️ Failed to queue image derivatives for %s: %v\n", fileURL, err)
+	}
+
+	return fileURL
+}
+
+// registerImageAsset mendaftarkan gambar ke antrian. checksum (SHA-256) boleh kosong, nanti diisi worker.
+func registerImageAsset(fileURL string, contentType string, size int64, checksum string) error {
+	key, private, err := utils.ResolveObjectURL(fileURL)
+	if err != nil {
+		return err
+	}
+
+	asset := models.ImageAsset{
+		URL:        fileURL,
+		StorageKey: key,
+		IsPrivate:  private,
+		MimeType:   contentType,
+		Bytes:      size,
+		Status:     ImageStatusPending,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if checksum != "" {
+		asset.Sha256 = &checksum
+	}
+
+	return config.DB.
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "url"}}, DoNothing: true}).
+		Omit("Variants").
+		Create(&asset).Error
+}
+
+// StartImageWorker memproses antrian image_assets di background.
+// Aman dijalankan di beberapa instance karena claim memakai SKIP LOCKED.
+func StartImageWorker() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("IMAGE_WORKER_INTERVAL", "5s"))
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	log.Printf("🖼️  Image worker started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for {
+				processed, err := ProcessPendingImages(imageWorkerBatch)
+				if err != nil {
+					log.Printf("❌ Image worker error: %v\n", err)
+					break
+				}
+				if processed < imageWorkerBatch {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// ProcessPendingImages meng-claim beberapa asset lalu membuat derivative-nya
+func ProcessPendingImages(limit int) (int, error) {
+	assets, err := claimImageAssets(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, asset := range assets {
+		if err := processImageAsset(asset); err != nil {
+			log.Printf("❌ Failed to process image %s: %v\n", asset.StorageKey, err)
+			markImageAssetFailed(asset, err)
+			continue
+		}
+		if err := removeDuplicateUpload(asset); err != nil {
+			log.Printf("⚠️ Failed to check duplicate upload %s: %v\n", asset.StorageKey, err)
+		}
+	}
+
+	return len(assets), nil
+}
+
+func claimImageAssets(limit int) ([]models.ImageAsset, error) {
+	var assets []models.ImageAsset
+
+	// Asset "processing" yang lock-nya kadaluarsa berarti worker sebelumnya mati di tengah jalan
+	err := config.DB.Raw(`
+		UPDATE image_assets
+		SET status = ?, locked_at = NOW(), attempts = attempts + 1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM image_assets
+			WHERE (status = ? OR (status = ? AND locked_at < ?))
+			AND attempts < ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		ImageStatusProcessing,
+		ImageStatusPending, ImageStatusProcessing, time.Now().Add(-imageLockTimeout),
+		imageMaxAttempts,
+		limit,
+	).Scan(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim image assets: %v", err)
+	}
+
+	return assets, nil
+}
+
+func markImageAssetFailed(asset models.ImageAsset, cause error) {
+	status := ImageStatusPending
+
+	// Original sudah dihapus, retry tidak ada gunanya
+	if asset.Attempts >= imageMaxAttempts || errors.Is(cause, utils.ErrObjectNotFound) {
+		status = ImageStatusFailed
+	}
+
+	if err := config.DB.Model(&models.ImageAsset{}).
+		Where("id = ?", asset.ID).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"last_error": cause.Error(),
+			"locked_at":  nil,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		log.Printf("❌ Failed to update image asset %d: %v\n", asset.ID, err)
+	}
+}
+
+func readStorageObject(store utils.Storage, key string) ([]byte, utils.ObjectInfo, error) {
+	body, info, err := store.Get(context.TODO(), key)
+	if err != nil {
+		return nil, utils.ObjectInfo{}, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, imageMaxSourceSize+1))
+	if err != nil {
+		return nil, utils.ObjectInfo{}, err
+	}
+	if len(data) > imageMaxSourceSize {
+		return nil, utils.ObjectInfo{}, fmt.Errorf("image is larger than %d bytes", imageMaxSourceSize)
+	}
+
+	return data, info, nil
+}
+
+func processImageAsset(asset models.ImageAsset) error {
+	store := utils.StoreFor(asset.IsPrivate)
+	if store == nil {
+		return utils.ErrPrivateStorageDisabled
+	}
+
+	data, info, err := readStorageObject(store, asset.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	// Gambar diputar sesuai EXIF Orientation, jadi semua derivative sudah tegak
+	img, format, exif, err := decodeOrientedImage(data)
+	if err != nil {
+		return err
+	}
+	rotated := exif != nil && exif.Orientation > 1
+
+	outputs, err := generateImageDerivatives(data, img, format, rotated)
+	if err != nil {
+		return err
+	}
+
+	// Blurhash, LQIP dan palette warna untuk ditampilkan selama gambar dimuat
+	columns, err := imagePlaceholderColumns(img)
+	if err != nil {
+		return err
+	}
+	// Upload lewat presigned URL belum punya checksum, jadi selalu dihitung dari file di storage
+	columns["sha256"] = utils.SHA256Hex(data)
+	columns["phash"] = int64(utils.DifferenceHash(img))
+
+	variants := make([]models.ImageVariant, 0, len(outputs))
+	for _, out := range outputs {
+		key := utils.VariantKey(asset.StorageKey, out.Name, variantExtension(out.Format))
+		if err := store.Put(context.TODO(), key, bytes.NewReader(out.Data), int64(len(out.Data)), "image/"+out.Format); err != nil {
+			return fmt.Errorf("failed to upload variant %s: %w", key, err)
+		}
+
+		variants = append(variants, models.ImageVariant{
+			AssetID:    asset.ID,
+			Name:       out.Name,
+			Format:     out.Format,
+			StorageKey: key,
+			URL:        utils.ObjectURL(key, asset.IsPrivate),
+			Width:      int32(out.Width),
+			Height:     int32(out.Height),
+			Bytes:      int64(len(out.Data)),
+			Crc32:      out.checksum(),
+			CreatedAt:  time.Now(),
+		})
+	}
+
+	mimeType := info.ContentType
+	if mimeType == "" {
+		mimeType = "image/" + format
+	}
+
+	bounds := img.Bounds()
+	now := time.Now()
+	// CRC file original untuk ZIP download album
+	checksum := int64(crc32.ChecksumIEEE(data))
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		// Hapus hasil percobaan sebelumnya (kalau ada) supaya retry tidak bentrok
+		// Salinan ber-watermark diurus worker watermark, tidak ikut dihapus
+		if err := tx.Where("asset_id = ? AND name NOT LIKE ?", asset.ID, VariantWatermarkPrefix+"%").Delete(&models.ImageVariant{}).Error; err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			if err := tx.Create(&variants).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := saveImageMetadata(tx, asset.ID, exif); err != nil {
+			return err
+		}
+
+		// Media album yang sudah tersimpan sebelum proses selesai ikut diisi ukurannya
+		if err := tx.Model(&models.AlbumMedia{}).
+			Where("url = ?", asset.URL).
+			Updates(map[string]interface{}{
+				"width":     bounds.Dx(),
+				"height":    bounds.Dy(),
+				"mime_type": mimeType,
+				"bytes":     len(data),
+				"crc32":     checksum,
+			}).Error; err != nil {
+			return err
+		}
+
+		columns["status"] = ImageStatusReady
+		columns["mime_type"] = mimeType
+		columns["width"] = bounds.Dx()
+		columns["height"] = bounds.Dy()
+		columns["bytes"] = len(data)
+		columns["crc32"] = checksum
+		columns["last_error"] = ""
+		columns["locked_at"] = nil
+		columns["processed_at"] = now
+		columns["updated_at"] = now
+
+		return tx.Model(&models.ImageAsset{}).
+			Where("id = ?", asset.ID).
+			Updates(columns).Error
+	})
+}
+
+// generateImageDerivatives membuat salinan tanpa metadata plus ukuran responsive.
+// JPEG tetap JPEG, PNG/GIF jadi PNG supaya transparansi tidak hilang.
+// rotated = img sudah diputar sesuai EXIF, salinan JPEG harus di-encode ulang karena tag Orientation ikut dibuang.
+func generateImageDerivatives(original []byte, img image.Image, format string, rotated bool) ([]imageOutput, error) {
+	bounds := img.Bounds()
+	var outputs []imageOutput
+
+	switch {
+	case format == "jpeg" && !rotated:
+		stripped, err := utils.StripJPEGMetadata(original)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, imageOutput{Name: VariantStripped, Format: "jpeg", Data: stripped, Width: bounds.Dx(), Height: bounds.Dy()})
+	case format == "webp" && !rotated:
+		stripped, err := utils.StripWebPMetadata(original)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, imageOutput{Name: VariantStripped, Format: "webp", Data: stripped, Width: bounds.Dx(), Height: bounds.Dy()})
+	case format == "jpeg" || format == "png" || format == "webp":
+		// Re-encode membuang semua chunk metadata (eXIf, tEXt, XMP).
+		// WebP yang diputar jadi JPEG (PNG kalau transparan), encoder WebP kita lossless dan terlalu besar untuk foto.
+		strippedFormat := format
+		if format == "webp" {
+			strippedFormat = "jpeg"
+			if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+				strippedFormat = "png"
+			}
+		}
+		stripped, err := encodeImage(img, strippedFormat)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, imageOutput{Name: VariantStripped, Format: strippedFormat, Data: stripped, Width: bounds.Dx(), Height: bounds.Dy()})
+	}
+
+	baseFormat := "jpeg"
+	if format == "png" || format == "gif" {
+		baseFormat = "png"
+	}
+
+	for _, size := range imageSizes {
+		if size.Width >= bounds.Dx() {
+			continue
+		}
+
+		resized := utils.ResizeToWidth(img, size.Width)
+		resizedBounds := resized.Bounds()
+
+		base, err := encodeImage(resized, baseFormat)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, imageOutput{Name: size.Name, Format: baseFormat, Data: base, Width: resizedBounds.Dx(), Height: resizedBounds.Dy()})
+
+		webp, err := utils.EncodeWebP(resized)
+		if err != nil {
+			return nil, err
+		}
+
+		// Encoder WebP kita lossless: untuk foto sering lebih besar dari JPEG, variant seperti itu
+		// tidak disimpan supaya browser yang memilih WebP tidak men-download file yang lebih berat
+		if len(webp) < len(base) {
+			outputs = append(outputs, imageOutput{Name: size.Name, Format: "webp", Data: webp, Width: resizedBounds.Dx(), Height: resizedBounds.Dy()})
+		}
+	}
+
+	return outputs, nil
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	switch format {
+	case "jpeg":
+		return utils.EncodeJPEG(img, imageJPEGQuality)
+	case "png":
+		return utils.EncodePNG(img)
+	case "webp":
+		return utils.EncodeWebP(img)
+	}
+	return nil, fmt.Errorf("unsupported image format: %s", format)
+}
+
+func variantExtension(format string) string {
+	if format == "jpeg" {
+		return "jpg"
+	}
+	return format
+}
+
+// GetResponsiveImages mengambil derivative untuk banyak URL sekaligus.
+// URL yang belum/tidak punya derivative tidak ada di map.
+func GetResponsiveImages(urls []string) (map[string]dto.ResponsiveImageResponse, error) {
+	result := map[string]dto.ResponsiveImageResponse{}
+	if len(urls) == 0 {
+		return result, nil
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Preload("Variants", "name NOT LIKE ?", VariantWatermarkPrefix+"%").
+		Where("url IN ? AND status = ?", urls, ImageStatusReady).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get image variants: %v", err)
+	}
+
+	for _, asset := range assets {
+		result[asset.URL] = mapImageAssetToDTO(asset)
+	}
+
+	return result, nil
+}
+
+// ResponsiveImage mengambil hasil GetResponsiveImages, fallback ke URL original saja
+func ResponsiveImage(images map[string]dto.ResponsiveImageResponse, url string) dto.ResponsiveImageResponse {
+	if img, ok := images[url]; ok {
+		return img
+	}
+	return dto.ResponsiveImageResponse{URL: url, Variants: []dto.ImageVariantResponse{}}
+}
+
+func mapImageAssetToDTO(asset models.ImageAsset) dto.ResponsiveImageResponse {
+	variants := asset.Variants
+	sort.Slice(variants, func(i, j int) bool {
+		if variants[i].Width != variants[j].Width {
+			return variants[i].Width < variants[j].Width
+		}
+		return variants[i].Format < variants[j].Format
+	})
+
+	response := dto.ResponsiveImageResponse{
+		URL:      asset.URL,
+		Width:    asset.Width,
+		Height:   asset.Height,
+		Variants: make([]dto.ImageVariantResponse, 0, len(variants)),
+
+		Placeholder: mapImagePlaceholderToDTO(asset),
+	}
+
+	for _, v := range variants {
+		response.Variants = append(response.Variants, dto.ImageVariantResponse{
+			Name:   v.Name,
+			Format: v.Format,
+			URL:    v.URL,
+			Width:  v.Width,
+			Height: v.Height,
+			Bytes:  v.Bytes,
+		})
+	}
+
+	fillSrcsets(&response)
+
+	return response
+}
+
+func fillSrcsets(response *dto.ResponsiveImageResponse) {
+	var srcset, webpSrcset []string
+	for _, v := range response.Variants {
+		entry := fmt.Sprintf("%s %dw", v.URL, v.Width)
+		if v.Format == "webp" {
+			webpSrcset = append(webpSrcset, entry)
+		} else {
+			srcset = append(srcset, entry)
+		}
+	}
+
+	response.Srcset = strings.Join(srcset, ", ")
+	response.WebpSrcset = strings.Join(webpSrcset, ", ")
+}
+
+// signResponsiveImage mengganti semua URL private:// dengan signed URL supaya bisa ditampilkan
+func signResponsiveImage(image dto.ResponsiveImageResponse) (dto.ResponsiveImageResponse, error) {
+	if !utils.IsPrivateURL(image.URL) {
+		return image, nil
+	}
+
+	ctx := context.TODO()
+	expires := utils.PrivateURLExpiration()
+
+	signed, err := utils.SignObjectURL(ctx, image.URL, expires)
+	if err != nil {
+		return image, err
+	}
+
+	result := image
+	result.URL = signed
+	result.Variants = make([]dto.ImageVariantResponse, len(image.Variants))
+	for i, v := range image.Variants {
+		if v.URL, err = utils.SignObjectURL(ctx, v.URL, expires); err != nil {
+			return image, err
+		}
+		result.Variants[i] = v
+	}
+
+	fillSrcsets(&result)
+	return result, nil
+}
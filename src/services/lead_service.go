@@ -0,0 +1,461 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	LeadStatusNew       = "new"
+	LeadStatusContacted = "contacted"
+	LeadStatusQuoted    = "quoted"
+	LeadStatusBooked    = "booked"
+	LeadStatusLost      = "lost"
+)
+
+var LeadStatuses = []string{LeadStatusNew, LeadStatusContacted, LeadStatusQuoted, LeadStatusBooked, LeadStatusLost}
+
+var (
+	ErrInquiryRateLimited   = errors.New("too many inquiries, please try again later")
+	ErrLeadNotFound         = errors.New("lead not found")
+	ErrInvalidLeadStatus    = errors.New("invalid lead status")
+	ErrInvalidEventDate     = errors.New("event date must not be in the past")
+	ErrInquiryCategory      = errors.New("category not found")
+	ErrInquiryPhotographer  = errors.New("photographer not found")
+	ErrLeadAssigneeNotFound = errors.New("assignee not found")
+)
+
+// Maksimal 5 inquiry per IP per jam
+var inquiryLimiter = utils.NewRateLimiter(5, time.Hour)
+
+type InquiryInput struct {
+	Name             string `json:"name" validate:"required,max=100"`
+	Email            string `json:"email" validate:"required_without=PhoneNumber,omitempty,email,max=255"`
+	PhoneNumber      string `json:"phone_number" validate:"required_without=Email,omitempty,max=50"`
+	EventDate        string `json:"event_date" validate:"omitempty,datetime=2006-01-02"`
+	CategoryUUID     string `json:"category_uuid" validate:"omitempty,uuid"`
+	PhotographerSlug string `json:"photographer_slug" validate:"omitempty,max=255"`
+	Message          string `json:"message" validate:"required,max=5000"`
+
+	// Honeypot: disembunyikan di form, hanya bot yang mengisinya
+	Website string `json:"website"`
+}
+
+type LeadFilter struct {
+	Status       string `form:"status"`
+	AssignedUUID string `form:"assigned_uuid" binding:"omitempty,uuid"`
+	Search       string `form:"search"`
+}
+
+type LeadStatusInput struct {
+	Status string `json:"status" validate:"required"`
+}
+
+// LeadAssignInput: UserUUID kosong = lepas assignment
+type LeadAssignInput struct {
+	UserUUID string `json:"user_uuid" validate:"omitempty,uuid"`
+}
+
+type LeadNoteInput struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+func isValidLeadStatus(status string) bool {
+	for _, s := range LeadStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SubmitInquiry menyimpan inquiry dari website publik sebagai lead baru lalu mengirim notifikasi.
+// Submission honeypot tetap dianggap berhasil supaya bot tidak tahu sudah ditolak.
+func SubmitInquiry(input InquiryInput, ipAddress string, userAgent string) error {
+	if input.Website != "" {
+		log.Printf("🕸️ Inquiry from %s dropped by honeypot\n", ipAddress)
+		return nil
+	}
+
+	if !inquiryLimiter.Allow(ipAddress) {
+		return ErrInquiryRateLimited
+	}
+
+	lead := models.Lead{
+		Name:        strings.TrimSpace(input.Name),
+		Email:       strings.ToLower(strings.TrimSpace(input.Email)),
+		PhoneNumber: strings.TrimSpace(input.PhoneNumber),
+		Message:     strings.TrimSpace(input.Message),
+		Status:      LeadStatusNew,
+		IPAddress:   ipAddress,
+		UserAgent:   userAgent,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if input.EventDate != "" {
+		eventDate, err := time.ParseInLocation("2006-01-02", input.EventDate, time.Local)
+		if err != nil {
+			return ErrInvalidEventDate
+		}
+		year, month, day := time.Now().Date()
+		if eventDate.Before(time.Date(year, month, day, 0, 0, 0, 0, time.Local)) {
+			return ErrInvalidEventDate
+		}
+		lead.EventDate = &eventDate
+	}
+
+	if input.CategoryUUID != "" {
+		var category models.Category
+		if err := config.DB.Scopes(publishedNow("")).Where("uuid = ?", input.CategoryUUID).First(&category).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInquiryCategory
+			}
+			return fmt.Errorf("failed to get category: %v", err)
+		}
+		lead.CategoryID = &category.ID
+		lead.Category = &category
+	}
+
+	if input.PhotographerSlug != "" {
+		var user models.User
+		if err := config.DB.Scopes(publishedNow("")).
+			Where("slug = ? AND role <> ?", input.PhotographerSlug, "admin").
+			First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInquiryPhotographer
+			}
+			return fmt.Errorf("failed to get photographer: %v", err)
+		}
+		lead.PreferredUserID = &user.ID
+		lead.PreferredUser = &user
+	}
+
+	if err := config.DB.Omit(clause.Associations).Create(&lead).Error; err != nil {
+		return fmt.Errorf("failed to save inquiry: %v", err)
+	}
+
+	go notifyNewLead(lead)
+
+	return nil
+}
+
+// notifyNewLead jalan di background, gagal kirim hanya dicatat di log
+func notifyNewLead(lead models.Lead) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response := mapLeadToDTO(lead)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "New inquiry from %s\n\n", response.Name)
+	fmt.Fprintf(&body, "Email: %s\n", response.Email)
+	fmt.Fprintf(&body, "Phone: %s\n", response.PhoneNumber)
+	if response.EventDate != "" {
+		fmt.Fprintf(&body, "Event date: %s\n", response.EventDate)
+	}
+	if response.Category != nil {
+		fmt.Fprintf(&body, "Category: %s\n", response.Category.Name)
+	}
+	if response.PreferredUser != nil {
+		fmt.Fprintf(&body, "Preferred photographer: %s\n", response.PreferredUser.Name)
+	}
+	fmt.Fprintf(&body, "\n%s\n", response.Message)
+
+	err := utils.Notify.Notify(ctx, utils.Notification{
+		Type:       "lead.created",
+		Subject:    "New inquiry from " + response.Name,
+		Body:       body.String(),
+		Data:       map[string]interface{}{"lead": response},
+		OccurredAt: response.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to notify new lead %s: %v\n", lead.UUID, err)
+	}
+}
+
+func mapLeadUserToDTO(user *models.User) *dto.LeadUserResponse {
+	if user == nil {
+		return nil
+	}
+	return &dto.LeadUserResponse{UUID: user.UUID, Name: user.Name, Slug: user.Slug}
+}
+
+func mapLeadToDTO(lead models.Lead) dto.LeadResponse {
+	response := dto.LeadResponse{
+		UUID:          lead.UUID,
+		Name:          lead.Name,
+		Email:         lead.Email,
+		PhoneNumber:   lead.PhoneNumber,
+		PreferredUser: mapLeadUserToDTO(lead.PreferredUser),
+		AssignedUser:  mapLeadUserToDTO(lead.AssignedUser),
+		Message:       lead.Message,
+		Status:        lead.Status,
+		CreatedAt:     lead.CreatedAt,
+		UpdatedAt:     lead.UpdatedAt,
+	}
+	if lead.EventDate != nil {
+		response.EventDate = lead.EventDate.Format("2006-01-02")
+	}
+	if lead.Category != nil {
+		response.Category = &dto.LeadCategoryResponse{
+			UUID: lead.Category.UUID,
+			Name: lead.Category.Name,
+			Slug: lead.Category.Slug,
+		}
+	}
+	return response
+}
+
+// preloadLead: user/kategori yang sudah di trash tetap ditampilkan
+func preloadLead(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	return db.
+		Preload("Category", unscoped).
+		Preload("PreferredUser", unscoped).
+		Preload("AssignedUser", unscoped)
+}
+
+// leadScope: tanpa lead:manage:any hanya lead yang di-assign ke actor yang terlihat
+func leadScope(actor Actor) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if actor.Can(utils.PermLeadManageAny) {
+			return db
+		}
+		return db.Where("assigned_user_id = (?)", config.DB.Table("users").Select("id").Where("uuid = ?", actor.UserUUID))
+	}
+}
+
+func GetLeads(page int, limit int, filter LeadFilter, actor Actor) ([]dto.LeadResponse, int64, error) {
+	var leads []models.Lead
+	var total int64
+
+	query := config.DB.Model(&models.Lead{}).Scopes(leadScope(actor))
+
+	if filter.Status != "" {
+		if !isValidLeadStatus(filter.Status) {
+			return nil, 0, ErrInvalidLeadStatus
+		}
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AssignedUUID != "" {
+		query = query.Where("assigned_user_id = (?)", config.DB.Table("users").Select("id").Where("uuid = ?", filter.AssignedUUID))
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("(name ILIKE ? OR email ILIKE ? OR phone_number ILIKE ?)", like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Scopes(preloadLead).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&leads).Error; err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.LeadResponse, len(leads))
+	for i, lead := range leads {
+		response[i] = mapLeadToDTO(lead)
+	}
+	return response, total, nil
+}
+
+func getLead(db *gorm.DB, uuid string, actor Actor) (models.Lead, error) {
+	var lead models.Lead
+	if err := db.Scopes(preloadLead, leadScope(actor)).Where("uuid = ?", uuid).First(&lead).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return lead, ErrLeadNotFound
+		}
+		return lead, err
+	}
+	return lead, nil
+}
+
+func GetLeadByUUID(uuid string, actor Actor) (dto.LeadResponse, error) {
+	lead, err := getLead(config.DB, uuid, actor)
+	if err != nil {
+		return dto.LeadResponse{}, err
+	}
+
+	var notes []models.LeadNote
+	if err := config.DB.Where("lead_id = ?", lead.ID).Order("created_at ASC, id ASC").Find(&notes).Error; err != nil {
+		return dto.LeadResponse{}, err
+	}
+
+	response := mapLeadToDTO(lead)
+	response.Notes, err = mapLeadNotesToDTO(notes)
+	if err != nil {
+		return dto.LeadResponse{}, err
+	}
+	return response, nil
+}
+
+func mapLeadNotesToDTO(notes []models.LeadNote) ([]dto.LeadNoteResponse, error) {
+	var authorUUIDs []string
+	for _, note := range notes {
+		if note.AuthorUUID != nil {
+			authorUUIDs = append(authorUUIDs, *note.AuthorUUID)
+		}
+	}
+
+	names := map[string]string{}
+	if len(authorUUIDs) > 0 {
+		var users []models.User
+		if err := config.DB.Unscoped().Select("uuid", "name").Where("uuid IN ?", authorUUIDs).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			names[user.UUID] = user.Name
+		}
+	}
+
+	response := make([]dto.LeadNoteResponse, len(notes))
+	for i, note := range notes {
+		response[i] = dto.LeadNoteResponse{
+			UUID:      note.UUID,
+			Body:      note.Body,
+			CreatedAt: note.CreatedAt,
+		}
+		if note.AuthorUUID != nil {
+			response[i].AuthorUUID = *note.AuthorUUID
+			response[i].AuthorName = names[*note.AuthorUUID]
+		}
+	}
+	return response, nil
+}
+
+// leadAuditSnapshot: assignment dicatat sebagai uuid user, bukan id internal
+func leadAuditSnapshot(lead models.Lead) map[string]interface{} {
+	snapshot := map[string]interface{}{
+		"status":        lead.Status,
+		"assigned_user": nil,
+	}
+	if lead.AssignedUser != nil {
+		snapshot["assigned_user"] = lead.AssignedUser.UUID
+	}
+	return snapshot
+}
+
+func UpdateLeadStatus(uuid string, input LeadStatusInput, actor Actor) (dto.LeadResponse, error) {
+	if !isValidLeadStatus(input.Status) {
+		return dto.LeadResponse{}, ErrInvalidLeadStatus
+	}
+
+	var lead models.Lead
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		lead, err = getLead(tx, uuid, actor)
+		if err != nil {
+			return err
+		}
+
+		before := leadAuditSnapshot(lead)
+		lead.Status = input.Status
+		lead.UpdatedAt = time.Now()
+
+		if err := tx.Model(&lead).Updates(map[string]interface{}{
+			"status":     lead.Status,
+			"updated_at": lead.UpdatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update lead: %v", err)
+		}
+
+		return recordAudit(tx, actor, AuditActionUpdate, AuditEntityLead, lead.UUID, before, leadAuditSnapshot(lead))
+	})
+	if err != nil {
+		return dto.LeadResponse{}, err
+	}
+
+	return mapLeadToDTO(lead), nil
+}
+
+// AssignLead hanya untuk lead:manage:any
+func AssignLead(uuid string, input LeadAssignInput, actor Actor) (dto.LeadResponse, error) {
+	if !actor.Can(utils.PermLeadManageAny) {
+		return dto.LeadResponse{}, ErrForbidden
+	}
+
+	var lead models.Lead
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		lead, err = getLead(tx, uuid, actor)
+		if err != nil {
+			return err
+		}
+
+		before := leadAuditSnapshot(lead)
+
+		lead.AssignedUserID = nil
+		lead.AssignedUser = nil
+		if input.UserUUID != "" {
+			var user models.User
+			if err := tx.Where("uuid = ?", input.UserUUID).First(&user).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrLeadAssigneeNotFound
+				}
+				return fmt.Errorf("failed to get user: %v", err)
+			}
+			lead.AssignedUserID = &user.ID
+			lead.AssignedUser = &user
+		}
+		lead.UpdatedAt = time.Now()
+
+		if err := tx.Model(&lead).Updates(map[string]interface{}{
+			"assigned_user_id": lead.AssignedUserID,
+			"updated_at":       lead.UpdatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to assign lead: %v", err)
+		}
+
+		return recordAudit(tx, actor, AuditActionUpdate, AuditEntityLead, lead.UUID, before, leadAuditSnapshot(lead))
+	})
+	if err != nil {
+		return dto.LeadResponse{}, err
+	}
+
+	return mapLeadToDTO(lead), nil
+}
+
+func AddLeadNote(uuid string, input LeadNoteInput, actor Actor) (dto.LeadNoteResponse, error) {
+	lead, err := getLead(config.DB, uuid, actor)
+	if err != nil {
+		return dto.LeadNoteResponse{}, err
+	}
+
+	note := models.LeadNote{
+		LeadID:    lead.ID,
+		Body:      strings.TrimSpace(input.Body),
+		CreatedAt: time.Now(),
+	}
+	if actor.UserUUID != "" {
+		note.AuthorUUID = &actor.UserUUID
+	}
+
+	if err := config.DB.Create(&note).Error; err != nil {
+		return dto.LeadNoteResponse{}, fmt.Errorf("failed to save note: %v", err)
+	}
+
+	notes, err := mapLeadNotesToDTO([]models.LeadNote{note})
+	if err != nil {
+		return dto.LeadNoteResponse{}, err
+	}
+	return notes[0], nil
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	DeletionStatusPending = "pending"
+	DeletionStatusFailed  = "failed"
+
+	deletionMaxAttempts = 10
+	deletionWorkerBatch = 50
+	retryBaseBackoff    = 30 * time.Second
+	retryMaxBackoff     = 6 * time.Hour
+	// Lama "lease" saat diproses, kalau worker mati row akan dicoba lagi setelah ini
+	deletionLease = 5 * time.Minute
+)
+
+// QueueObjectDeletions mencatat key yang harus dihapus dari storage di dalam tx yang sama
+// dengan perubahan DB-nya. File baru benar-benar dihapus worker setelah tx commit.
+func QueueObjectDeletions(tx *gorm.DB, reason string, keys ...string) error {
+	return queueObjectDeletions(tx, reason, false, keys...)
+}
+
+// queueObjectDeletions sama seperti QueueObjectDeletions, private = key ada di PrivateStore
+func queueObjectDeletions(tx *gorm.DB, reason string, private bool, keys ...string) error {
+	rows := make([]models.PendingObjectDeletion, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		rows = append(rows, models.PendingObjectDeletion{
+			StorageKey:    key,
+			IsPrivate:     private,
+			Reason:        reason,
+			Status:        DeletionStatusPending,
+			NextAttemptAt: time.Now(),
+			CreatedAt:     time.Now(),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to queue storage deletion: %v", err)
+	}
+	return nil
+}
+
+// queueStoredFileDeletion mengantrikan file (berdasarkan public URL atau private://) beserta derivative-nya
+func queueStoredFileDeletion(tx *gorm.DB, fileURL string, reason string) error {
+	fileURL = strings.Trim(fileURL, `"`)
+	if fileURL == "" {
+		return nil
+	}
+
+	key, private, err := utils.ResolveObjectURL(fileURL)
+	if err != nil {
+		// Bukan file di storage kita, tidak ada yang perlu dihapus
+		log.Printf("⚠️ Skip deleting %s: %v\n", fileURL, err)
+		return nil
+	}
+
+	keys := []string{key}
+
+	var asset models.ImageAsset
+	err = tx.Preload("Variants").Where("url = ?", fileURL).First(&asset).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get image asset: %v", err)
+	}
+	if err == nil {
+		for _, v := range asset.Variants {
+			keys = append(keys, v.StorageKey)
+		}
+
+		if err := tx.Delete(&asset).Error; err != nil {
+			return fmt.Errorf("failed to delete image asset: %v", err)
+		}
+	}
+
+	return queueObjectDeletions(tx, reason, private, keys...)
+}
+
+// StartObjectDeletionWorker memproses pending_object_deletions dengan retry dan backoff
+func StartObjectDeletionWorker() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("DELETION_WORKER_INTERVAL", "10s"))
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	log.Printf("🗑️  Storage deletion worker started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for {
+				processed, err := ProcessObjectDeletions(deletionWorkerBatch)
+				if err != nil {
+					log.Printf("❌ Storage deletion worker error: %v\n", err)
+					break
+				}
+				if processed < deletionWorkerBatch {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// ProcessObjectDeletions mengambil row yang sudah waktunya lalu menghapus objeknya
+func ProcessObjectDeletions(limit int) (int, error) {
+	var rows []models.PendingObjectDeletion
+
+	// next_attempt_at dimajukan sebagai lease supaya instance lain tidak mengambil row yang sama
+	if err := config.DB.Raw(`
+		UPDATE pending_object_deletions
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM pending_object_deletions
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		time.Now().Add(deletionLease), DeletionStatusPending, time.Now(), limit,
+	).Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to claim storage deletions: %v", err)
+	}
+
+	for _, row := range rows {
+		store := utils.StoreFor(row.IsPrivate)
+		if store == nil {
+			markObjectDeletionFailed(row, utils.ErrPrivateStorageDisabled)
+			continue
+		}
+
+		err := store.Delete(context.TODO(), row.StorageKey)
+		if err == nil || errors.Is(err, utils.ErrObjectNotFound) {
+			if err := config.DB.Delete(&models.PendingObjectDeletion{}, row.ID).Error; err != nil {
+				log.Printf("❌ Failed to remove deletion row %d: %v\n", row.ID, err)
+			}
+			continue
+		}
+
+		markObjectDeletionFailed(row, err)
+	}
+
+	return len(rows), nil
+}
+
+func markObjectDeletionFailed(row models.PendingObjectDeletion, cause error) {
+	updates := map[string]interface{}{
+		"last_error":      cause.Error(),
+		"next_attempt_at": time.Now().Add(retryBackoff(row.Attempts)),
+	}
+
+	if row.Attempts >= deletionMaxAttempts {
+		updates["status"] = DeletionStatusFailed
+		log.Printf("❌ Giving up deleting %s after %d attempts: %v\n", row.StorageKey, row.Attempts, cause)
+	} else {
+		log.Printf("⚠️ Failed to delete %s (attempt %d): %v\n", row.StorageKey, row.Attempts, cause)
+	}
+
+	if err := config.DB.Model(&models.PendingObjectDeletion{}).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+		log.Printf("❌ Failed to update deletion row %d: %v\n", row.ID, err)
+	}
+}
+
+// retryBackoff: 30s, 1m, 2m, 4m, ... maksimal 6 jam
+func retryBackoff(attempts int32) time.Duration {
+	backoff := retryBaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= retryMaxBackoff {
+			return retryMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrResetRateLimited  = errors.New("too many reset requests, please try again later")
+	ErrResetTokenInvalid = errors.New("invalid or expired reset token")
+)
+
+// Maksimal 3 permintaan reset per email per jam
+var resetLimiter = utils.NewRateLimiter(3, time.Hour)
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RequestPasswordReset membuat token reset dan mengirim link lewat email.
+// Tidak mengembalikan error kalau email tidak terdaftar supaya tidak bisa dipakai
+// untuk menebak akun yang ada.
+func RequestPasswordReset(email string, requestIP string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if !resetLimiter.Allow(email) {
+		return ErrResetRateLimited
+	}
+
+	var user models.User
+	if err := config.DB.Where("LOWER(email) = ?", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+
+	// User tanpa password memang tidak boleh login, jadi tidak boleh reset juga
+	if user.Password == "" {
+		return nil
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %v", err)
+	}
+
+	expiration := time.Duration(utils.GetEnvAsDurationInSeconds("RESET_TOKEN_EXPIRATION", "1h")) * time.Second
+	resetToken := models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashSecretToken(token),
+		ExpiresAt: time.Now().Add(expiration),
+		RequestIP: requestIP,
+		CreatedAt: time.Now(),
+	}
+
+	if err := config.DB.Create(&resetToken).Error; err != nil {
+		return fmt.Errorf("failed to save reset token: %v", err)
+	}
+
+	resetURL := utils.GetEnvOrDefault("RESET_PASSWORD_URL", "http://localhost:3000/reset-password")
+	body := fmt.Sprintf(
+		"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s?token=%s\n\nThis link expires in %s and can only be used once. If you did not request this, you can ignore this email.\n",
+		user.Name, resetURL, token, expiration,
+	)
+
+	// Dikirim di background supaya waktu respons email terdaftar dan tidak terdaftar sama
+	go func() {
+		if err := utils.Mail.Send(user.Email, "Reset your password", body); err != nil {
+			log.Printf("⚠️ Failed to send reset password email to user %s: %v\n", user.UUID, err)
+		}
+	}()
+
+	return nil
+}
+
+// ResetPassword memvalidasi token lalu mengganti password user.
+// Semua session (refresh token) milik user ikut dicabut.
+func ResetPassword(input ResetPasswordInput) error {
+	tx := config.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %v", tx.Error)
+	}
+
+	var resetToken models.PasswordResetToken
+	if err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", hashSecretToken(input.Token), time.Now()).
+		First(&resetToken).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrResetTokenInvalid
+		}
+		return fmt.Errorf("failed to get reset token: %v", err)
+	}
+
+	now := time.Now()
+
+	if err := tx.Model(&models.User{}).
+		Where("id = ?", resetToken.UserID).
+		Updates(map[string]interface{}{
+			"password":            utils.HashPassword(input.Password),
+			"password_changed_at": now,
+			"updated_at":          now,
+		}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update password: %v", err)
+	}
+
+	// Tandai token ini dan token lain milik user yang sama sebagai terpakai
+	if err := tx.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", resetToken.UserID).
+		Update("used_at", now).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to invalidate reset tokens: %v", err)
+	}
+
+	// Paksa login ulang di semua device
+	if err := revokeSessions(tx.Where("user_id = ?", resetToken.UserID), "password_reset"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return nil
+}
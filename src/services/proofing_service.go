@@ -0,0 +1,619 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrProofingNotFound      = errors.New("proofing not found or link has expired")
+	ErrProofingLinkNotFound  = errors.New("proofing link not found")
+	ErrProofingListNotFound  = errors.New("selection list not found")
+	ErrProofingListExists    = errors.New("selection list with this name already exists")
+	ErrProofingListLimit     = errors.New("max_selections exceeds the limit of this proofing link")
+	ErrProofingMediaNotFound = errors.New("photo is not part of this album")
+	ErrProofingLimitReached  = errors.New("selection limit reached")
+	ErrProofingSubmitted     = errors.New("selection has already been submitted")
+	ErrProofingEmpty         = errors.New("select at least one photo before submitting")
+	ErrProofingRateLimited   = errors.New("too many requests, please try again later")
+)
+
+// Perubahan pilihan dari client per link, cukup longgar untuk klik-klik biasa
+var proofingLimiter = utils.NewRateLimiter(600, time.Hour)
+
+type ProofingListInput struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	MaxSelections *int32 `json:"max_selections" validate:"omitempty,min=1"`
+}
+
+type ProofingLinkInput struct {
+	Label         string              `json:"label" validate:"max=100"`
+	MaxSelections *int32              `json:"max_selections" validate:"omitempty,min=1"`
+	ExpiresAt     *time.Time          `json:"expires_at"`
+	Lists         []ProofingListInput `json:"lists" validate:"dive"` // daftar awal, mis. "Album cetak"
+}
+
+type ProofingItemInput struct {
+	MediaUUID string `json:"media_uuid" validate:"required,uuid"`
+	Comment   string `json:"comment" validate:"max=2000"`
+}
+
+func proofingShareURL(token string) string {
+	shareURL := utils.GetEnvOrDefault("PROOFING_SHARE_URL", "http://localhost:3000/proofing")
+	return strings.TrimRight(shareURL, "/") + "/" + token
+}
+
+func mapProofingLinkToDTO(link models.ProofingLink) dto.ProofingLinkResponse {
+	return dto.ProofingLinkResponse{
+		UUID:          link.UUID,
+		Label:         link.Label,
+		MaxSelections: link.MaxSelections,
+		ExpiresAt:     link.ExpiresAt,
+		RevokedAt:     link.RevokedAt,
+		SubmittedAt:   link.SubmittedAt,
+		CreatedAt:     link.CreatedAt,
+	}
+}
+
+// listMaxSelections: batas daftar, kalau kosong pakai batas default link
+func listMaxSelections(link models.ProofingLink, list models.ProofingList) *int32 {
+	if list.MaxSelections != nil {
+		return list.MaxSelections
+	}
+	return link.MaxSelections
+}
+
+func mapProofingListsToDTO(link models.ProofingLink) []dto.ProofingListResponse {
+	response := make([]dto.ProofingListResponse, len(link.Lists))
+	for i, list := range link.Lists {
+		items := make([]dto.ProofingItemResponse, len(list.Items))
+		for j, item := range list.Items {
+			items[j] = dto.ProofingItemResponse{
+				MediaUUID: item.Media.UUID,
+				Filename:  utils.OriginalFilename(item.Media.StorageKey),
+				Comment:   item.Comment,
+				UpdatedAt: item.UpdatedAt,
+			}
+		}
+
+		response[i] = dto.ProofingListResponse{
+			UUID:          list.UUID,
+			Name:          list.Name,
+			MaxSelections: listMaxSelections(link, list),
+			Count:         len(items),
+			Items:         items,
+		}
+	}
+	return response
+}
+
+// preloadProofingLists memuat daftar beserta foto yang dipilih, urut sesuai posisi di album
+func preloadProofingLists(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Lists", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Preload("Lists.Items", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Select("proofing_items.*").
+				Joins("JOIN album_media ON album_media.id = proofing_items.media_id").
+				Order("album_media.position ASC, proofing_items.id ASC")
+		}).
+		Preload("Lists.Items.Media")
+}
+
+func validateListLimit(link models.ProofingLink, max *int32) error {
+	if max != nil && link.MaxSelections != nil && *max > *link.MaxSelections {
+		return ErrProofingListLimit
+	}
+	return nil
+}
+
+// CreateProofingLink membuat link proofing. Token hanya dikembalikan sekali, DB menyimpan hash-nya.
+func CreateProofingLink(albumUUID string, input ProofingLinkInput, actor Actor) (dto.ProofingLinkResponse, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return dto.ProofingLinkResponse{}, ErrGalleryLinkExpiry
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return dto.ProofingLinkResponse{}, fmt.Errorf("failed to generate proofing token: %v", err)
+	}
+
+	var response dto.ProofingLinkResponse
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		link := models.ProofingLink{
+			AlbumID:       album.ID,
+			Label:         strings.TrimSpace(input.Label),
+			TokenHash:     hashSecretToken(token),
+			MaxSelections: input.MaxSelections,
+			ExpiresAt:     input.ExpiresAt,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		if actor.UserUUID != "" {
+			link.CreatedBy = &actor.UserUUID
+		}
+
+		seen := map[string]bool{}
+		for _, l := range input.Lists {
+			name := strings.TrimSpace(l.Name)
+			if seen[strings.ToLower(name)] {
+				return ErrProofingListExists
+			}
+			seen[strings.ToLower(name)] = true
+
+			if err := validateListLimit(link, l.MaxSelections); err != nil {
+				return err
+			}
+			link.Lists = append(link.Lists, models.ProofingList{
+				Name:          name,
+				MaxSelections: l.MaxSelections,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			})
+		}
+
+		if err := tx.Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to create proofing link: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityProofingLink, link.UUID, nil, auditSnapshot(link)); err != nil {
+			return err
+		}
+
+		response = mapProofingLinkToDTO(link)
+		response.Lists = mapProofingListsToDTO(link)
+		response.Token = token
+		response.URL = proofingShareURL(token)
+		return nil
+	})
+
+	return response, err
+}
+
+func GetProofingLinks(albumUUID string, actor Actor) ([]dto.ProofingLinkResponse, error) {
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []models.ProofingLink
+	if err := config.DB.
+		Scopes(preloadProofingLists).
+		Where("album_id = ?", album.ID).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get proofing links: %v", err)
+	}
+
+	response := make([]dto.ProofingLinkResponse, len(links))
+	for i, link := range links {
+		response[i] = mapProofingLinkToDTO(link)
+		response[i].Lists = mapProofingListsToDTO(link)
+	}
+	return response, nil
+}
+
+func getAlbumProofingLink(db *gorm.DB, albumUUID string, linkUUID string, actor Actor) (models.ProofingLink, error) {
+	album, err := getAuthorizedAlbum(db, albumUUID, actor)
+	if err != nil {
+		return models.ProofingLink{}, err
+	}
+
+	var link models.ProofingLink
+	if err := db.
+		Scopes(preloadProofingLists).
+		Where("uuid = ? AND album_id = ?", linkUUID, album.ID).
+		First(&link).Error; err != nil {
+		return models.ProofingLink{}, ErrProofingLinkNotFound
+	}
+	return link, nil
+}
+
+func GetProofingLink(albumUUID string, linkUUID string, actor Actor) (dto.ProofingLinkResponse, error) {
+	link, err := getAlbumProofingLink(config.DB, albumUUID, linkUUID, actor)
+	if err != nil {
+		return dto.ProofingLinkResponse{}, err
+	}
+
+	response := mapProofingLinkToDTO(link)
+	response.Lists = mapProofingListsToDTO(link)
+	return response, nil
+}
+
+// ExportProofingSelection mengembalikan nama file foto yang dipilih (semua daftar kalau listUUID kosong)
+func ExportProofingSelection(albumUUID string, linkUUID string, listUUID string, actor Actor) ([]string, error) {
+	link, err := getAlbumProofingLink(config.DB, albumUUID, linkUUID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	found := listUUID == ""
+	seen := map[string]bool{}
+	var filenames []string
+	for _, list := range link.Lists {
+		if listUUID != "" && list.UUID != listUUID {
+			continue
+		}
+		found = true
+
+		for _, item := range list.Items {
+			filename := utils.OriginalFilename(item.Media.StorageKey)
+			if !seen[filename] {
+				seen[filename] = true
+				filenames = append(filenames, filename)
+			}
+		}
+	}
+
+	if !found {
+		return nil, ErrProofingListNotFound
+	}
+	return filenames, nil
+}
+
+// setProofingLinkState mencabut atau membuka kembali link (submitted_at direset supaya client bisa mengubah pilihan)
+func setProofingLinkState(albumUUID string, linkUUID string, actor Actor, updates func(link *models.ProofingLink) map[string]interface{}) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		link, err := getAlbumProofingLink(tx, albumUUID, linkUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		link.Lists = nil
+		before := auditSnapshot(link)
+
+		values := updates(&link)
+		if len(values) == 0 {
+			return nil
+		}
+		values["updated_at"] = time.Now()
+
+		if err := tx.Model(&models.ProofingLink{}).Where("id = ?", link.ID).Updates(values).Error; err != nil {
+			return fmt.Errorf("failed to update proofing link: %v", err)
+		}
+
+		return recordAudit(tx, actor, AuditActionUpdate, AuditEntityProofingLink, link.UUID, before, auditSnapshot(link))
+	})
+}
+
+func RevokeProofingLink(albumUUID string, linkUUID string, actor Actor) error {
+	return setProofingLinkState(albumUUID, linkUUID, actor, func(link *models.ProofingLink) map[string]interface{} {
+		if link.RevokedAt != nil {
+			return nil
+		}
+		now := time.Now()
+		link.RevokedAt = &now
+		return map[string]interface{}{"revoked_at": now}
+	})
+}
+
+func ReopenProofingLink(albumUUID string, linkUUID string, actor Actor) error {
+	return setProofingLinkState(albumUUID, linkUUID, actor, func(link *models.ProofingLink) map[string]interface{} {
+		if link.SubmittedAt == nil {
+			return nil
+		}
+		link.SubmittedAt = nil
+		return map[string]interface{}{"submitted_at": nil}
+	})
+}
+
+// findActiveProofingLink mencari link yang belum dicabut/kadaluarsa
+func findActiveProofingLink(db *gorm.DB, token string) (models.ProofingLink, error) {
+	var link models.ProofingLink
+	if token == "" {
+		return link, ErrProofingNotFound
+	}
+
+	err := db.
+		Joins("JOIN albums ON albums.id = proofing_links.album_id").
+		Where("proofing_links.token_hash = ?", hashSecretToken(token)).
+		Where("proofing_links.revoked_at IS NULL").
+		Where("proofing_links.expires_at IS NULL OR proofing_links.expires_at > ?", time.Now()).
+		Where("albums.deleted_at IS NULL").
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return link, ErrProofingNotFound
+	}
+	if err != nil {
+		return link, fmt.Errorf("failed to get proofing link: %v", err)
+	}
+	return link, nil
+}
+
+// OpenProofing mengembalikan foto album dan pilihan client
+func OpenProofing(token string, locale string) (dto.ProofingGalleryResponse, error) {
+	link, err := findActiveProofingLink(config.DB.Scopes(preloadProofingLists), token)
+	if err != nil {
+		return dto.ProofingGalleryResponse{}, err
+	}
+
+	var album models.Album
+	if err := config.DB.
+		Preload("User").
+		Preload("Category").
+		Preload("Media", orderAlbumMedia).
+		Where("id = ?", link.AlbumID).
+		First(&album).Error; err != nil {
+		return dto.ProofingGalleryResponse{}, ErrProofingNotFound
+	}
+
+	albums := []models.Album{album}
+	if err := localizeAlbums(albums, locale); err != nil {
+		return dto.ProofingGalleryResponse{}, err
+	}
+	album = albums[0]
+
+	media, _, err := signedGalleryMedia(album)
+	if err != nil {
+		return dto.ProofingGalleryResponse{}, err
+	}
+
+	return dto.ProofingGalleryResponse{
+		Title:         album.Title,
+		Description:   album.Description,
+		UserName:      album.User.Name,
+		MaxSelections: link.MaxSelections,
+		ExpiresAt:     link.ExpiresAt,
+		SubmittedAt:   link.SubmittedAt,
+		Media:         media,
+		Lists:         mapProofingListsToDTO(link),
+		URLExpiresAt:  time.Now().Add(utils.PrivateURLExpiration()),
+	}, nil
+}
+
+// withOpenProofing menjalankan perubahan client di tx dengan link ter-lock, jadi batas pilihan
+// tidak bisa dilewati dengan request paralel dan link yang sudah submit tidak bisa diubah
+func withOpenProofing(token string, fn func(tx *gorm.DB, link models.ProofingLink) error) error {
+	if !proofingLimiter.Allow(hashSecretToken(token)) {
+		return ErrProofingRateLimited
+	}
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		link, err := findActiveProofingLink(tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "proofing_links"}}), token)
+		if err != nil {
+			return err
+		}
+		if link.SubmittedAt != nil {
+			return ErrProofingSubmitted
+		}
+		return fn(tx, link)
+	})
+}
+
+func getProofingList(tx *gorm.DB, link models.ProofingLink, listUUID string) (models.ProofingList, error) {
+	var list models.ProofingList
+	if err := tx.Where("uuid = ? AND link_id = ?", listUUID, link.ID).First(&list).Error; err != nil {
+		return list, ErrProofingListNotFound
+	}
+	return list, nil
+}
+
+func CreateProofingList(token string, input ProofingListInput) (dto.ProofingListResponse, error) {
+	var response dto.ProofingListResponse
+
+	err := withOpenProofing(token, func(tx *gorm.DB, link models.ProofingLink) error {
+		if err := validateListLimit(link, input.MaxSelections); err != nil {
+			return err
+		}
+
+		name := strings.TrimSpace(input.Name)
+		var count int64
+		if err := tx.Model(&models.ProofingList{}).Where("link_id = ? AND LOWER(name) = LOWER(?)", link.ID, name).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check selection list: %v", err)
+		}
+		if count > 0 {
+			return ErrProofingListExists
+		}
+
+		list := models.ProofingList{
+			LinkID:        link.ID,
+			Name:          name,
+			MaxSelections: input.MaxSelections,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		if err := tx.Omit("Items").Create(&list).Error; err != nil {
+			return fmt.Errorf("failed to create selection list: %v", err)
+		}
+
+		response = dto.ProofingListResponse{
+			UUID:          list.UUID,
+			Name:          list.Name,
+			MaxSelections: listMaxSelections(link, list),
+			Items:         []dto.ProofingItemResponse{},
+		}
+		return nil
+	})
+
+	return response, err
+}
+
+func DeleteProofingList(token string, listUUID string) error {
+	return withOpenProofing(token, func(tx *gorm.DB, link models.ProofingLink) error {
+		list, err := getProofingList(tx, link, listUUID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.ProofingList{}, list.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete selection list: %v", err)
+		}
+		return nil
+	})
+}
+
+// SetProofingItem menambahkan foto ke daftar, atau mengganti komentarnya kalau sudah ada
+func SetProofingItem(token string, listUUID string, input ProofingItemInput) (dto.ProofingItemResponse, error) {
+	var response dto.ProofingItemResponse
+
+	err := withOpenProofing(token, func(tx *gorm.DB, link models.ProofingLink) error {
+		list, err := getProofingList(tx, link, listUUID)
+		if err != nil {
+			return err
+		}
+
+		var media models.AlbumMedia
+		if err := tx.Where("uuid = ? AND album_id = ?", input.MediaUUID, link.AlbumID).First(&media).Error; err != nil {
+			return ErrProofingMediaNotFound
+		}
+
+		comment := strings.TrimSpace(input.Comment)
+
+		var item models.ProofingItem
+		err = tx.Where("list_id = ? AND media_id = ?", list.ID, media.ID).First(&item).Error
+		switch {
+		case err == nil:
+			item.Comment = comment
+			item.UpdatedAt = time.Now()
+			if err := tx.Model(&models.ProofingItem{}).Where("id = ?", item.ID).
+				Updates(map[string]interface{}{"comment": item.Comment, "updated_at": item.UpdatedAt}).Error; err != nil {
+				return fmt.Errorf("failed to update selection: %v", err)
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if max := listMaxSelections(link, list); max != nil {
+				var count int64
+				if err := tx.Model(&models.ProofingItem{}).Where("list_id = ?", list.ID).Count(&count).Error; err != nil {
+					return fmt.Errorf("failed to count selections: %v", err)
+				}
+				if count >= int64(*max) {
+					return fmt.Errorf("%w (%d photos)", ErrProofingLimitReached, *max)
+				}
+			}
+
+			item = models.ProofingItem{
+				ListID:    list.ID,
+				MediaID:   media.ID,
+				Comment:   comment,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+			if err := tx.Omit("Media").Create(&item).Error; err != nil {
+				return fmt.Errorf("failed to save selection: %v", err)
+			}
+		default:
+			return fmt.Errorf("failed to get selection: %v", err)
+		}
+
+		response = dto.ProofingItemResponse{
+			MediaUUID: media.UUID,
+			Filename:  utils.OriginalFilename(media.StorageKey),
+			Comment:   item.Comment,
+			UpdatedAt: item.UpdatedAt,
+		}
+		return nil
+	})
+
+	return response, err
+}
+
+func RemoveProofingItem(token string, listUUID string, mediaUUID string) error {
+	return withOpenProofing(token, func(tx *gorm.DB, link models.ProofingLink) error {
+		list, err := getProofingList(tx, link, listUUID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.
+			Where("list_id = ? AND media_id IN (?)", list.ID,
+				tx.Model(&models.AlbumMedia{}).Select("id").Where("uuid = ? AND album_id = ?", mediaUUID, link.AlbumID)).
+			Delete(&models.ProofingItem{}).Error; err != nil {
+			return fmt.Errorf("failed to remove selection: %v", err)
+		}
+		return nil
+	})
+}
+
+// SubmitProofing mengunci pilihan client lalu memberi tahu tim
+func SubmitProofing(token string) error {
+	var submitted models.ProofingLink
+
+	err := withOpenProofing(token, func(tx *gorm.DB, link models.ProofingLink) error {
+		var count int64
+		if err := tx.Model(&models.ProofingItem{}).
+			Joins("JOIN proofing_lists ON proofing_lists.id = proofing_items.list_id").
+			Where("proofing_lists.link_id = ?", link.ID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count selections: %v", err)
+		}
+		if count == 0 {
+			return ErrProofingEmpty
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.ProofingLink{}).Where("id = ?", link.ID).
+			Updates(map[string]interface{}{"submitted_at": now, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to submit selection: %v", err)
+		}
+
+		submitted = link
+		submitted.SubmittedAt = &now
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	go notifyProofingSubmitted(submitted)
+	return nil
+}
+
+// notifyProofingSubmitted jalan di background, gagal kirim hanya dicatat di log
+func notifyProofingSubmitted(link models.ProofingLink) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := config.DB.Scopes(preloadProofingLists).First(&link, link.ID).Error; err != nil {
+		log.Printf("⚠️ Failed to load proofing link %s: %v\n", link.UUID, err)
+		return
+	}
+
+	var album models.Album
+	if err := config.DB.Unscoped().Select("uuid, title").First(&album, link.AlbumID).Error; err != nil {
+		log.Printf("⚠️ Failed to load album for proofing %s: %v\n", link.UUID, err)
+		return
+	}
+
+	lists := mapProofingListsToDTO(link)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "The client submitted their selection for \"%s\"", album.Title)
+	if link.Label != "" {
+		fmt.Fprintf(&body, " (%s)", link.Label)
+	}
+	body.WriteString("\n\n")
+	for _, list := range lists {
+		fmt.Fprintf(&body, "%s: %d photos\n", list.Name, list.Count)
+	}
+
+	err := utils.Notify.Notify(ctx, utils.Notification{
+		Type:    "proofing.submitted",
+		Subject: "Proofing selection submitted: " + album.Title,
+		Body:    body.String(),
+		Data: map[string]interface{}{
+			"album_uuid": album.UUID,
+			"link_uuid":  link.UUID,
+			"lists":      lists,
+		},
+		OccurredAt: *link.SubmittedAt,
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to notify proofing submission %s: %v\n", link.UUID, err)
+	}
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ContentStatusDraft     = "draft"
+	ContentStatusScheduled = "scheduled"
+	ContentStatusPublished = "published"
+	ContentStatusArchived  = "archived"
+
+	TransitionPublished   = "published"
+	TransitionUnpublished = "unpublished"
+
+	RevalidationStatusPending = "pending"
+	RevalidationStatusFailed  = "failed"
+
+	publishScheduleCheckpoint = "publish_schedule"
+	revalidationMaxAttempts   = 10
+	revalidationWorkerBatch   = 50
+	revalidationLease         = 5 * time.Minute
+)
+
+var ErrInvalidPublishWindow = errors.New("unpublish_at must be after publish_at")
+
+// ContentStatus menurunkan status dari is_published dan jadwal tayang
+func ContentStatus(isPublished bool, publishAt *time.Time, unpublishAt *time.Time) string {
+	now := time.Now()
+
+	switch {
+	case !isPublished:
+		return ContentStatusDraft
+	case unpublishAt != nil && !unpublishAt.After(now):
+		return ContentStatusArchived
+	case publishAt != nil && publishAt.After(now):
+		return ContentStatusScheduled
+	default:
+		return ContentStatusPublished
+	}
+}
+
+// publishedNow dipakai semua query publik: is_published dan sedang dalam jadwal tayang.
+// table diisi kalau kolomnya perlu prefix (mis. saat join).
+func publishedNow(table string) func(db *gorm.DB) *gorm.DB {
+	prefix := ""
+	if table != "" {
+		prefix = table + "."
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		now := time.Now()
+		return db.Where(
+			prefix+"is_published = ? AND ("+prefix+"publish_at IS NULL OR "+prefix+"publish_at <= ?) AND ("+prefix+"unpublish_at IS NULL OR "+prefix+"unpublish_at > ?)",
+			true, now, now,
+		)
+	}
+}
+
+// ParsePublishWindow membaca publish_at/unpublish_at dari form (RFC3339, kosong = tanpa jadwal)
+func ParsePublishWindow(publishAt string, unpublishAt string) (*time.Time, *time.Time, error) {
+	parse := func(field string, value string) (*time.Time, error) {
+		if value == "" || value == "null" || value == "undefined" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s, use RFC3339 format", field)
+		}
+		return &t, nil
+	}
+
+	from, err := parse("publish_at", publishAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	until, err := parse("unpublish_at", unpublishAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ValidatePublishWindow(from, until); err != nil {
+		return nil, nil, err
+	}
+	return from, until, nil
+}
+
+func ValidatePublishWindow(publishAt *time.Time, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ErrInvalidPublishWindow
+	}
+	return nil
+}
+
+type scheduledSource struct {
+	EntityType string
+	Table      string
+	SlugColumn string
+}
+
+// Tabel yang punya jadwal tayang
+var scheduledSources = []scheduledSource{
+	{EntityType: AuditEntityAlbum, Table: "albums", SlugColumn: "slug"},
+	{EntityType: AuditEntityCategory, Table: "categories", SlugColumn: "slug"},
+	{EntityType: AuditEntityUser, Table: "users", SlugColumn: "slug"},
+	{EntityType: AuditEntityFaq, Table: "faqs", SlugColumn: "''"},
+}
+
+// StartPublishScheduler mengecek transisi jadwal lalu mengirim event revalidation
+func StartPublishScheduler() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("PUBLISH_SCHEDULER_INTERVAL", "1m"))
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+
+	log.Printf("⏰ Publish scheduler started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := QueuePublishTransitions(time.Now()); err != nil {
+				log.Printf("❌ Publish scheduler error: %v\n", err)
+			}
+
+			for {
+				processed, err := ProcessRevalidationEvents(revalidationWorkerBatch)
+				if err != nil {
+					log.Printf("❌ Revalidation worker error: %v\n", err)
+					break
+				}
+				if processed < revalidationWorkerBatch {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// QueuePublishTransitions mencatat event untuk setiap publish_at/unpublish_at yang lewat
+// sejak checkpoint terakhir. Checkpoint di-lock supaya hanya satu instance yang memproses.
+func QueuePublishTransitions(now time.Time) (int, error) {
+	queued := 0
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var checkpoint models.SchedulerCheckpoint
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("name = ?", publishScheduleCheckpoint).
+			First(&checkpoint).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Jalan pertama: mulai dari sekarang, jadwal yang sudah lewat tidak dikirim ulang
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.SchedulerCheckpoint{Name: publishScheduleCheckpoint, CheckedAt: now}).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read scheduler checkpoint: %v", err)
+		}
+
+		since := checkpoint.CheckedAt
+		if !now.After(since) {
+			return nil
+		}
+
+		var events []models.RevalidationEvent
+		for _, source := range scheduledSources {
+			var rows []struct {
+				UUID        string
+				Slug        string
+				PublishAt   *time.Time
+				UnpublishAt *time.Time
+			}
+
+			if err := tx.Table(source.Table).
+				Select("uuid, "+source.SlugColumn+" AS slug, publish_at, unpublish_at").
+				Where("is_published = ? AND deleted_at IS NULL", true).
+				Where("(publish_at > ? AND publish_at <= ?) OR (unpublish_at > ? AND unpublish_at <= ?)", since, now, since, now).
+				Scan(&rows).Error; err != nil {
+				return fmt.Errorf("failed to read %s schedule: %v", source.Table, err)
+			}
+
+			inWindow := func(t *time.Time) bool {
+				return t != nil && t.After(since) && !t.After(now)
+			}
+
+			for _, row := range rows {
+				if inWindow(row.PublishAt) {
+					events = append(events, newRevalidationEvent(source.EntityType, row.UUID, row.Slug, TransitionPublished, *row.PublishAt))
+				}
+				if inWindow(row.UnpublishAt) {
+					events = append(events, newRevalidationEvent(source.EntityType, row.UUID, row.Slug, TransitionUnpublished, *row.UnpublishAt))
+				}
+			}
+		}
+
+		if len(events) > 0 {
+			if err := tx.Create(&events).Error; err != nil {
+				return fmt.Errorf("failed to queue revalidation events: %v", err)
+			}
+		}
+		queued = len(events)
+
+		return tx.Model(&checkpoint).Update("checked_at", now).Error
+	})
+
+	if queued > 0 {
+		log.Printf("⏰ Queued %d publish transitions\n", queued)
+	}
+	return queued, err
+}
+
+func newRevalidationEvent(entityType string, uuid string, slug string, transition string, occurredAt time.Time) models.RevalidationEvent {
+	return models.RevalidationEvent{
+		EntityType:    entityType,
+		EntityUUID:    uuid,
+		Slug:          slug,
+		Transition:    transition,
+		OccurredAt:    occurredAt,
+		Status:        RevalidationStatusPending,
+		NextAttemptAt: time.Now(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ProcessRevalidationEvents mengirim event yang sudah waktunya dengan retry dan backoff
+func ProcessRevalidationEvents(limit int) (int, error) {
+	var rows []models.RevalidationEvent
+
+	if err := config.DB.Raw(`
+		UPDATE revalidation_events
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM revalidation_events
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY occurred_at, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		time.Now().Add(revalidationLease), RevalidationStatusPending, time.Now(), limit,
+	).Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to claim revalidation events: %v", err)
+	}
+
+	for _, row := range rows {
+		err := utils.Revalidate.Revalidate(context.TODO(), utils.RevalidationEvent{
+			EntityType: row.EntityType,
+			UUID:       row.EntityUUID,
+			Slug:       row.Slug,
+			Transition: row.Transition,
+			OccurredAt: row.OccurredAt,
+		})
+		if err == nil {
+			if err := config.DB.Delete(&models.RevalidationEvent{}, row.ID).Error; err != nil {
+				log.Printf("❌ Failed to remove revalidation event %d: %v\n", row.ID, err)
+			}
+			continue
+		}
+
+		updates := map[string]interface{}{
+			"last_error":      err.Error(),
+			"next_attempt_at": time.Now().Add(retryBackoff(row.Attempts)),
+		}
+		if row.Attempts >= revalidationMaxAttempts {
+			updates["status"] = RevalidationStatusFailed
+			log.Printf("❌ Giving up revalidating %s %s after %d attempts: %v\n", row.EntityType, row.EntityUUID, row.Attempts, err)
+		} else {
+			log.Printf("⚠️ Failed to revalidate %s %s (attempt %d): %v\n", row.EntityType, row.EntityUUID, row.Attempts, err)
+		}
+
+		if err := config.DB.Model(&models.RevalidationEvent{}).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+			log.Printf("❌ Failed to update revalidation event %d: %v\n", row.ID, err)
+		}
+	}
+
+	return len(rows), nil
+}
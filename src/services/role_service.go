@@ -0,0 +1,242 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+)
+
+type RoleInput struct {
+	Name        string   `json:"name" validate:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type cachedPermissions struct {
+	permissions []string
+	loadedAt    time.Time
+}
+
+// Cache permission per role supaya middleware tidak query DB tiap request
+var (
+	rolePermissionCache   = map[string]cachedPermissions{}
+	rolePermissionCacheMu sync.RWMutex
+	rolePermissionTTL     = time.Minute
+)
+
+func GetRolePermissions(role string) ([]string, error) {
+	rolePermissionCacheMu.RLock()
+	cached, ok := rolePermissionCache[role]
+	rolePermissionCacheMu.RUnlock()
+
+	if ok && time.Since(cached.loadedAt) < rolePermissionTTL {
+		return cached.permissions, nil
+	}
+
+	var permissions []string
+	if err := config.DB.
+		Table("permissions").
+		Select("permissions.code").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", role).
+		Pluck("permissions.code", &permissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %v", err)
+	}
+
+	rolePermissionCacheMu.Lock()
+	rolePermissionCache[role] = cachedPermissions{permissions: permissions, loadedAt: time.Now()}
+	rolePermissionCacheMu.Unlock()
+
+	return permissions, nil
+}
+
+func invalidateRolePermissionCache() {
+	rolePermissionCacheMu.Lock()
+	rolePermissionCache = map[string]cachedPermissions{}
+	rolePermissionCacheMu.Unlock()
+}
+
+func RoleExists(name string) (bool, error) {
+	var count int64
+	if err := config.DB.Model(&models.Role{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check role: %v", err)
+	}
+	return count > 0, nil
+}
+
+func mapRoleToDTO(role models.Role) dto.RoleResponse {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		permissions = append(permissions, p.Code)
+	}
+
+	return dto.RoleResponse{
+		UUID:        role.UUID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func GetRoles() ([]dto.RoleResponse, error) {
+	var roles []models.Role
+	if err := config.DB.Preload("Permissions").Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get roles: %v", err)
+	}
+
+	response := make([]dto.RoleResponse, len(roles))
+	for i, role := range roles {
+		response[i] = mapRoleToDTO(role)
+	}
+
+	return response, nil
+}
+
+func GetPermissions() ([]dto.PermissionResponse, error) {
+	var permissions []models.Permission
+	if err := config.DB.Order("code ASC").Find(&permissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %v", err)
+	}
+
+	response := make([]dto.PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		response[i] = dto.PermissionResponse{
+			Code:        p.Code,
+			Description: p.Description,
+		}
+	}
+
+	return response, nil
+}
+
+func findPermissionsByCode(codes []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(codes) == 0 {
+		return permissions, nil
+	}
+
+	if err := config.DB.Where("code IN ?", codes).Find(&permissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %v", err)
+	}
+	if len(permissions) != len(codes) {
+		return nil, fmt.Errorf("unknown permission in list")
+	}
+
+	return permissions, nil
+}
+
+func CreateRole(input RoleInput) (dto.RoleResponse, error) {
+	exists, err := RoleExists(input.Name)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+	if exists {
+		return dto.RoleResponse{}, fmt.Errorf("role already exists")
+	}
+
+	permissions, err := findPermissionsByCode(input.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	role := models.Role{
+		Name:        input.Name,
+		Description: input.Description,
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := config.DB.Create(&role).Error; err != nil {
+		return dto.RoleResponse{}, fmt.Errorf("failed to save role: %v", err)
+	}
+
+	invalidateRolePermissionCache()
+	return mapRoleToDTO(role), nil
+}
+
+func UpdateRole(uuid string, input RoleInput) (dto.RoleResponse, error) {
+	tx := config.DB.Begin()
+	if tx.Error != nil {
+		return dto.RoleResponse{}, fmt.Errorf("failed to begin transaction: %v", tx.Error)
+	}
+
+	var role models.Role
+	if err := tx.Where("uuid = ?", uuid).First(&role).Error; err != nil {
+		tx.Rollback()
+		return dto.RoleResponse{}, fmt.Errorf("role not found")
+	}
+
+	var count int64
+	if err := tx.Model(&models.Role{}).Where("name = ? AND id != ?", input.Name, role.ID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return dto.RoleResponse{}, fmt.Errorf("failed to check role name: %v", err)
+	}
+	if count > 0 {
+		tx.Rollback()
+		return dto.RoleResponse{}, fmt.Errorf("role already exists")
+	}
+
+	permissions, err := findPermissionsByCode(input.Permissions)
+	if err != nil {
+		tx.Rollback()
+		return dto.RoleResponse{}, err
+	}
+
+	role.Name = input.Name
+	role.Description = input.Description
+	role.UpdatedAt = time.Now()
+
+	// users.role ikut berubah lewat ON UPDATE CASCADE
+	if err := tx.Omit("Permissions").Save(&role).Error; err != nil {
+		tx.Rollback()
+		return dto.RoleResponse{}, fmt.Errorf("failed to update role: %v", err)
+	}
+
+	if err := tx.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+		tx.Rollback()
+		return dto.RoleResponse{}, fmt.Errorf("failed to update role permissions: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.RoleResponse{}, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	invalidateRolePermissionCache()
+	role.Permissions = permissions
+	return mapRoleToDTO(role), nil
+}
+
+func DeleteRole(uuid string) error {
+	var role models.Role
+	if err := config.DB.Where("uuid = ?", uuid).First(&role).Error; err != nil {
+		return fmt.Errorf("role not found")
+	}
+
+	if role.Name == "admin" {
+		return fmt.Errorf("admin role cannot be deleted")
+	}
+
+	var count int64
+	// User di trash masih mereferensikan role sampai di-purge
+	if err := config.DB.Unscoped().Model(&models.User{}).Where("role = ?", role.Name).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check role usage: %v", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("role is still assigned to %d user(s)", count)
+	}
+
+	if err := config.DB.Delete(&role).Error; err != nil {
+		return fmt.Errorf("failed to delete role: %v", err)
+	}
+
+	invalidateRolePermissionCache()
+	return nil
+}
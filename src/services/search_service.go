@@ -0,0 +1,274 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"html"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	SearchTypeAlbum    = "album"
+	SearchTypeCategory = "category"
+	SearchTypeUser     = "user"
+	SearchTypeFaq      = "faq"
+
+	// Query pencarian digabung dari dua konfigurasi, sama seperti kolom search_vector
+	searchTSQuery = "(websearch_to_tsquery('simple', @q) || websearch_to_tsquery('english', @q))"
+
+	// ts_headline tidak meng-escape HTML, jadi kata yang cocok ditandai karakter private use
+	// lalu hasilnya di-escape di Go sebelum penanda diganti <mark> (lihat searchHighlightHTML)
+	searchMarkStart = "\uE000"
+	searchMarkStop  = "\uE001"
+
+	searchHighlightOptions = `StartSel="` + searchMarkStart + `", StopSel="` + searchMarkStop + `", HighlightAll=true`
+	searchSnippetOptions   = `StartSel="` + searchMarkStart + `", StopSel="` + searchMarkStop + `", MaxWords=30, MinWords=10, MaxFragments=2`
+)
+
+var ErrInvalidSearchType = errors.New("invalid search type")
+
+var SearchTypes = []string{SearchTypeAlbum, SearchTypeCategory, SearchTypeUser, SearchTypeFaq}
+
+type SearchInput struct {
+	Query string
+	Types []string
+	Lang  string // locale request untuk judul/snippet, FAQ memakai kolom en kalau "en"
+}
+
+func searchHeadline(column string, options string) string {
+	return "ts_headline('english', coalesce(" + column + ", ''), " + searchTSQuery + ", '" + options + "')"
+}
+
+// searchHighlightHTML meng-escape teks hasil ts_headline, hanya penanda kata yang cocok yang jadi <mark>
+func searchHighlightHTML(headline string) string {
+	escaped := html.EscapeString(headline)
+	return strings.NewReplacer(searchMarkStart, "<mark>", searchMarkStop, "</mark>").Replace(escaped)
+}
+
+// searchTranslationLocales: locale di fallback chain yang diambil dari content_translations,
+// berhenti di locale pertama yang disimpan di kolom tabel (sama seperti translatedValue)
+func searchTranslationLocales(entityType string, locale string) []string {
+	var locales []string
+	for _, l := range utils.LocaleFallbacks(locale) {
+		if slices.Contains(columnLocales(entityType), l) {
+			break
+		}
+		locales = append(locales, l)
+	}
+	return locales
+}
+
+// searchTranslation subquery content_translations untuk baris tabel yang sedang dicari
+func searchTranslation(table string, entityType string) string {
+	return "FROM content_translations ct WHERE ct.entity_type = '" + entityType + "' AND ct.entity_id = " + table + ".id"
+}
+
+// searchLocalizedColumn: terjemahan field sesuai urutan locale, fallback ke kolom tabel
+func searchLocalizedColumn(table string, entityType string, field string, column string, locales []string) string {
+	if field == "" || len(locales) == 0 {
+		return column
+	}
+	return "coalesce((SELECT ct.value " + searchTranslation(table, entityType) +
+		" AND ct.field = '" + field + "' AND ct.locale IN @locales AND ct.value <> ''" +
+		" ORDER BY strpos(@locale_order, ',' || ct.locale || ',') LIMIT 1), " + column + ")"
+}
+
+// searchSource membangun select hasil pencarian untuk satu tabel.
+// Yang dicocokkan: search_vector tabel atau salah satu terjemahannya; judul/snippet memakai bahasa request.
+func searchSource(table string, searchType string, entityType string, slug string, title searchField, snippet searchField, image string, input SearchInput) *gorm.DB {
+	locales := searchTranslationLocales(entityType, input.Lang)
+	titleColumn := searchLocalizedColumn(table, entityType, title.Field, title.Column, locales)
+	snippetColumn := searchLocalizedColumn(table, entityType, snippet.Field, snippet.Column, locales)
+	translationRank := "coalesce((SELECT max(ts_rank_cd(ct.search_vector, " + searchTSQuery + ")) " + searchTranslation(table, entityType) + "), 0)"
+
+	args := []interface{}{sql.Named("q", input.Query)}
+	if len(locales) > 0 {
+		args = append(args, sql.Named("locales", locales), sql.Named("locale_order", ","+strings.Join(locales, ",")+","))
+	}
+
+	return config.DB.Table(table).
+		Select(
+			"'"+searchType+"' AS type, uuid, coalesce("+slug+", '') AS slug, "+
+				"coalesce("+titleColumn+", '') AS title, "+
+				searchHeadline(titleColumn, searchHighlightOptions)+" AS highlight, "+
+				searchHeadline(snippetColumn, searchSnippetOptions)+" AS snippet, "+
+				"coalesce("+image+", '') AS image, "+
+				"GREATEST(ts_rank_cd(search_vector, "+searchTSQuery+"), "+translationRank+") AS rank",
+			args...,
+		).
+		Where("deleted_at IS NULL").
+		Where("(search_vector @@ "+searchTSQuery+" OR EXISTS (SELECT 1 "+searchTranslation(table, entityType)+" AND ct.search_vector @@ "+searchTSQuery+"))", sql.Named("q", input.Query)).
+		Scopes(publishedNow(""))
+}
+
+// searchField kolom tabel beserta nama field-nya di content_translations ("" = tidak diterjemahkan)
+type searchField struct {
+	Column string
+	Field  string
+}
+
+func searchSources(input SearchInput) ([]interface{}, error) {
+	question, answer := "question_id", "answer_id"
+	if input.Lang == "en" {
+		question, answer = "question_en", "answer_en"
+	}
+
+	types := input.Types
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+
+	var sources []interface{}
+	for _, searchType := range types {
+		switch searchType {
+		case SearchTypeAlbum:
+			sources = append(sources, searchSource("albums", SearchTypeAlbum, AuditEntityAlbum, "slug",
+				searchField{"title", "title"}, searchField{"description", "description"}, "thumbnail", input).
+				Where("visibility = ?", AlbumVisibilityPublic))
+		case SearchTypeCategory:
+			sources = append(sources, searchSource("categories", SearchTypeCategory, AuditEntityCategory, "slug",
+				searchField{"name", "name"}, searchField{"description", "description"}, "photo_url", input))
+		case SearchTypeUser:
+			sources = append(sources, searchSource("users", SearchTypeUser, AuditEntityUser, "slug",
+				searchField{"name", ""}, searchField{"description", "description"}, "photo", input).
+				Where("role != ?", "admin"))
+		case SearchTypeFaq:
+			// FAQ tidak punya slug dan gambar
+			sources = append(sources, searchSource("faqs", SearchTypeFaq, AuditEntityFaq, "NULL",
+				searchField{question, "question"}, searchField{answer, "answer"}, "NULL", input))
+		default:
+			return nil, ErrInvalidSearchType
+		}
+	}
+	return sources, nil
+}
+
+// Search mencari konten publik (album, kategori, fotografer, FAQ) diurutkan berdasarkan relevansi
+func Search(input SearchInput, page int, limit int) ([]dto.SearchResultResponse, int64, error) {
+	results := []dto.SearchResultResponse{}
+	var total int64
+
+	if strings.TrimSpace(input.Query) == "" {
+		return results, 0, nil
+	}
+
+	sources, err := searchSources(input)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	union := config.DB.Raw(strings.TrimSuffix(strings.Repeat("(?) UNION ALL ", len(sources)), " UNION ALL "), sources...)
+	query := config.DB.Table("(?) AS results", union)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Order("rank DESC, title").
+		Limit(limit).
+		Offset(offset).
+		Scan(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Highlight = searchHighlightHTML(results[i].Highlight)
+		results[i].Snippet = searchHighlightHTML(results[i].Snippet)
+	}
+	applySearchPlaceholders(results)
+	applySearchAlbumImages(results)
+	return results, total, nil
+}
+
+// applySearchPlaceholders mengisi placeholder gambar hasil pencarian (sebelum thumbnail album diganti salinannya)
+func applySearchPlaceholders(results []dto.SearchResultResponse) {
+	var urls []string
+	for _, r := range results {
+		if r.Image != "" {
+			urls = append(urls, r.Image)
+		}
+	}
+
+	placeholders, err := GetImagePlaceholders(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		return
+	}
+	for i := range results {
+		results[i].ImagePlaceholder = ImagePlaceholder(placeholders, results[i].Image)
+	}
+}
+
+// applySearchAlbumImages mengganti thumbnail album di hasil pencarian sama seperti halaman album publik:
+// salinan ber-watermark, atau salinan tanpa metadata kalau EXIF_REDACT_GPS aktif. Yang belum siap dikosongkan.
+func applySearchAlbumImages(results []dto.SearchResultResponse) {
+	var uuids []string
+	for _, r := range results {
+		if r.Type == SearchTypeAlbum && r.Image != "" {
+			uuids = append(uuids, r.UUID)
+		}
+	}
+	if len(uuids) == 0 {
+		return
+	}
+
+	thumbnails := map[string]string{}
+	var albums []models.Album
+	if err := config.DB.Preload("Category").Where("uuid IN ?", uuids).Find(&albums).Error; err != nil {
+		log.Printf("⚠️ Failed to get albums for search images: %v\n", err)
+	} else {
+		responses := make([]dto.AlbumResponse, len(albums))
+		for i, album := range albums {
+			responses[i] = dto.AlbumResponse{UUID: album.UUID, Thumbnail: album.Thumbnail}
+		}
+		watermarked := applyAlbumWatermarks(albums, responses)
+		applyStrippedImages(responses, watermarked)
+
+		for _, r := range responses {
+			thumbnails[r.UUID] = r.Thumbnail
+		}
+	}
+
+	for i, r := range results {
+		if r.Type != SearchTypeAlbum {
+			continue
+		}
+		results[i].Image = thumbnails[r.UUID]
+		if results[i].Image == "" {
+			results[i].ImagePlaceholder = nil
+		}
+	}
+}
+
+// adminSearch dipakai list admin: full-text ditambah ILIKE supaya kata yang belum selesai diketik tetap cocok
+func adminSearch(search string, likeColumns ...string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		conditions := []string{"search_vector @@ " + searchTSQuery}
+		for _, column := range likeColumns {
+			conditions = append(conditions, column+" ILIKE @like")
+		}
+
+		return db.Where(strings.Join(conditions, " OR "), sql.Named("q", search), sql.Named("like", "%"+search+"%"))
+	}
+}
+
+// adminSearchOrder mengurutkan hasil pencarian admin berdasarkan relevansi
+func adminSearchOrder(search string) clause.OrderBy {
+	return clause.OrderBy{
+		Expression: clause.NamedExpr{
+			SQL:  "ts_rank_cd(search_vector, " + searchTSQuery + ") DESC",
+			Vars: []interface{}{sql.Named("q", search)},
+		},
+	}
+}
@@ -0,0 +1,517 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+
+	sitemapAlbumsPerFile = 200 // mapPublicAlbumsToDTO ikut memuat watermark, jangan terlalu besar
+	feedItemLimit        = 20
+
+	sitemapNamespace      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapXhtmlNamespace = "http://www.w3.org/1999/xhtml"
+	sitemapImageNamespace = "http://www.google.com/schemas/sitemap-image/1.1"
+	atomNamespace         = "http://www.w3.org/2005/Atom"
+)
+
+var (
+	ErrSitemapNotFound   = errors.New("sitemap not found")
+	ErrFeedNotFound      = errors.New("feed not found")
+	ErrInvalidFeedFormat = errors.New("format must be rss or atom")
+)
+
+// AlbumFeedFilter: kosong = semua album publik
+type AlbumFeedFilter struct {
+	CategorySlug     string
+	PhotographerSlug string
+}
+
+// SiteURL base URL frontend publik, dipakai untuk semua link di sitemap dan feed
+func SiteURL() string {
+	return strings.TrimRight(utils.GetEnvOrDefault("SITE_URL", "http://localhost:3000"), "/")
+}
+
+// seoBaseURL base URL publik endpoint sitemap/feed di API ini
+func seoBaseURL() string {
+	return strings.TrimRight(utils.GetEnvOrDefault("SEO_BASE_URL", "http://localhost:"+utils.GetEnvOrDefault("PORT", "8080")+"/v1/api"), "/")
+}
+
+// pagePath path halaman frontend dari env, {slug} diganti slug konten
+func pagePath(key string, fallback string, slug string) string {
+	return strings.ReplaceAll(utils.GetEnvOrDefault(key, fallback), "{slug}", url.PathEscape(slug))
+}
+
+func categoryPagePath(slug string) string {
+	return pagePath("SEO_CATEGORY_PATH", "/categories/{slug}", slug)
+}
+
+func albumPagePath(slug string) string {
+	return pagePath("SEO_ALBUM_PATH", "/albums/{slug}", slug)
+}
+
+func photographerPagePath(slug string) string {
+	return pagePath("SEO_PHOTOGRAPHER_PATH", "/photographers/{slug}", slug)
+}
+
+// LocalizedPageURL: default locale tanpa prefix, locale lain diawali /<locale> (mis. /en/albums/wedding)
+func LocalizedPageURL(path string, locale string) string {
+	if locale == utils.DefaultLocale() {
+		return SiteURL() + path
+	}
+	if path == "/" {
+		path = ""
+	}
+	return SiteURL() + "/" + locale + path
+}
+
+func sitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// sitemapEntries satu <url> per locale, masing-masing dengan hreflang ke semua versi bahasa
+func sitemapEntries(path string, lastMod time.Time, images []string) []dto.SitemapURL {
+	locales := utils.SupportedLocales()
+
+	alternates := make([]dto.SitemapAlternate, 0, len(locales)+1)
+	for _, locale := range locales {
+		alternates = append(alternates, dto.SitemapAlternate{Rel: "alternate", Hreflang: locale, Href: LocalizedPageURL(path, locale)})
+	}
+	alternates = append(alternates, dto.SitemapAlternate{Rel: "alternate", Hreflang: "x-default", Href: LocalizedPageURL(path, utils.DefaultLocale())})
+
+	sitemapImages := make([]dto.SitemapImage, 0, len(images))
+	for _, image := range images {
+		if image != "" {
+			sitemapImages = append(sitemapImages, dto.SitemapImage{Loc: image})
+		}
+	}
+
+	entries := make([]dto.SitemapURL, len(locales))
+	for i, locale := range locales {
+		entries[i] = dto.SitemapURL{
+			Loc:        LocalizedPageURL(path, locale),
+			LastMod:    sitemapTime(lastMod),
+			Alternates: alternates,
+			Images:     sitemapImages,
+		}
+	}
+	return entries
+}
+
+func newSitemap(withImages bool) dto.SitemapResponse {
+	sitemap := dto.SitemapResponse{
+		Xmlns:      sitemapNamespace,
+		XmlnsXhtml: sitemapXhtmlNamespace,
+		URLs:       []dto.SitemapURL{},
+	}
+	if withImages {
+		sitemap.XmlnsImage = sitemapImageNamespace
+	}
+	return sitemap
+}
+
+type sitemapRow struct {
+	ID        int32
+	Slug      string
+	UpdatedAt time.Time
+}
+
+func publicCategoryRows() ([]sitemapRow, error) {
+	var rows []sitemapRow
+	err := config.DB.Model(&models.Category{}).
+		Select("id", "slug", "updated_at").
+		Scopes(publishedNow("")).
+		Where("slug <> ''").
+		Order("id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// publicPhotographerRows sama dengan kriteria GetTeamMembers
+func publicPhotographerRows() ([]sitemapRow, error) {
+	var rows []sitemapRow
+	err := config.DB.Model(&models.User{}).
+		Select("id", "slug", "updated_at").
+		Scopes(publishedNow("")).
+		Where("role != ? AND slug <> ''", "admin").
+		Order("id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func publicAlbumRows() ([]sitemapRow, error) {
+	var rows []sitemapRow
+	err := config.DB.Model(&models.Album{}).
+		Select("id", "slug", "updated_at").
+		Scopes(publicAlbums("")).
+		Where("slug <> ''").
+		Order("id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func latestUpdate(rows []sitemapRow) time.Time {
+	var latest time.Time
+	for _, row := range rows {
+		if row.UpdatedAt.After(latest) {
+			latest = row.UpdatedAt
+		}
+	}
+	return latest
+}
+
+func latestFaqUpdate() (time.Time, bool, error) {
+	var result struct {
+		Count     int64
+		UpdatedAt *time.Time
+	}
+	if err := config.DB.Model(&models.Faq{}).
+		Select("COUNT(*) AS count, MAX(updated_at) AS updated_at").
+		Scopes(publishedNow("")).
+		Scan(&result).Error; err != nil {
+		return time.Time{}, false, err
+	}
+	if result.Count == 0 || result.UpdatedAt == nil {
+		return time.Time{}, false, nil
+	}
+	return *result.UpdatedAt, true, nil
+}
+
+// GetSitemapIndex daftar sitemap anak. Sitemap album dipecah per sitemapAlbumsPerFile album.
+func GetSitemapIndex() (dto.SitemapIndexResponse, error) {
+	index := dto.SitemapIndexResponse{Xmlns: sitemapNamespace, Sitemaps: []dto.SitemapIndexEntry{}}
+	add := func(name string, lastMod time.Time) {
+		index.Sitemaps = append(index.Sitemaps, dto.SitemapIndexEntry{
+			Loc:     seoBaseURL() + "/sitemaps/" + name + ".xml",
+			LastMod: sitemapTime(lastMod),
+		})
+	}
+
+	categories, err := publicCategoryRows()
+	if err != nil {
+		return index, fmt.Errorf("failed to get categories: %v", err)
+	}
+	if len(categories) > 0 {
+		add("categories", latestUpdate(categories))
+	}
+
+	albums, err := publicAlbumRows()
+	if err != nil {
+		return index, fmt.Errorf("failed to get albums: %v", err)
+	}
+	for start := 0; start < len(albums); start += sitemapAlbumsPerFile {
+		end := min(start+sitemapAlbumsPerFile, len(albums))
+		add("albums-"+strconv.Itoa(start/sitemapAlbumsPerFile+1), latestUpdate(albums[start:end]))
+	}
+
+	photographers, err := publicPhotographerRows()
+	if err != nil {
+		return index, fmt.Errorf("failed to get photographers: %v", err)
+	}
+	if len(photographers) > 0 {
+		add("photographers", latestUpdate(photographers))
+	}
+
+	faqUpdatedAt, hasFaq, err := latestFaqUpdate()
+	if err != nil {
+		return index, fmt.Errorf("failed to get faqs: %v", err)
+	}
+	if hasFaq {
+		add("faq", faqUpdatedAt)
+	}
+
+	return index, nil
+}
+
+// GetSitemap sitemap anak berdasarkan nama dari index (tanpa .xml), mis. categories, albums-2
+func GetSitemap(name string) (dto.SitemapResponse, error) {
+	switch name {
+	case "categories":
+		return rowsSitemap(publicCategoryRows, categoryPagePath)
+	case "photographers":
+		return rowsSitemap(publicPhotographerRows, photographerPagePath)
+	case "faq":
+		return faqSitemap()
+	}
+
+	if page, ok := strings.CutPrefix(name, "albums-"); ok {
+		if number, err := strconv.Atoi(page); err == nil && number > 0 {
+			return albumSitemap(number)
+		}
+	}
+	return dto.SitemapResponse{}, fmt.Errorf("%w: %s", ErrSitemapNotFound, name)
+}
+
+func rowsSitemap(load func() ([]sitemapRow, error), path func(slug string) string) (dto.SitemapResponse, error) {
+	sitemap := newSitemap(false)
+
+	rows, err := load()
+	if err != nil {
+		return sitemap, fmt.Errorf("failed to get sitemap entries: %v", err)
+	}
+	for _, row := range rows {
+		sitemap.URLs = append(sitemap.URLs, sitemapEntries(path(row.Slug), row.UpdatedAt, nil)...)
+	}
+	return sitemap, nil
+}
+
+func faqSitemap() (dto.SitemapResponse, error) {
+	sitemap := newSitemap(false)
+
+	updatedAt, hasFaq, err := latestFaqUpdate()
+	if err != nil {
+		return sitemap, fmt.Errorf("failed to get faqs: %v", err)
+	}
+	if hasFaq {
+		sitemap.URLs = sitemapEntries(utils.GetEnvOrDefault("SEO_FAQ_PATH", "/faq"), updatedAt, nil)
+	}
+	return sitemap, nil
+}
+
+// albumSitemap memakai URL gambar yang sama dengan halaman publik (versi watermark kalau aktif)
+func albumSitemap(page int) (dto.SitemapResponse, error) {
+	sitemap := newSitemap(true)
+
+	var albums []models.Album
+	if err := config.DB.
+		Preload("User").
+		Preload("Category").
+		Preload("Media", orderAlbumMedia).
+		Scopes(publicAlbums("")).
+		Where("slug <> ''").
+		Order("id ASC").
+		Offset((page - 1) * sitemapAlbumsPerFile).
+		Limit(sitemapAlbumsPerFile).
+		Find(&albums).Error; err != nil {
+		return sitemap, fmt.Errorf("failed to get albums: %v", err)
+	}
+	if len(albums) == 0 {
+		return sitemap, fmt.Errorf("%w: albums-%d", ErrSitemapNotFound, page)
+	}
+
+	for _, album := range mapPublicAlbumsToDTO(albums) {
+		sitemap.URLs = append(sitemap.URLs, sitemapEntries(albumPagePath(album.Slug), album.UpdatedAt, album.Images)...)
+	}
+	return sitemap, nil
+}
+
+// RobotsTxt mengarahkan crawler ke sitemap index. SEO_INDEXING_ENABLED=false untuk staging.
+func RobotsTxt() string {
+	var robots strings.Builder
+	robots.WriteString("User-agent: *\n")
+	if utils.GetEnvOrDefault("SEO_INDEXING_ENABLED", "true") != "true" {
+		robots.WriteString("Disallow: /\n")
+		return robots.String()
+	}
+	robots.WriteString("Allow: /\n\n")
+	robots.WriteString("Sitemap: " + seoBaseURL() + "/sitemap.xml\n")
+	return robots.String()
+}
+
+type albumFeed struct {
+	Title       string
+	Description string
+	Link        string
+	SelfLink    string
+	Locale      string
+	Albums      []dto.AlbumResponse
+	PublishedAt map[string]time.Time // per UUID album
+}
+
+// loadAlbumFeed album publik terbaru (berdasarkan waktu tayang), opsional per kategori atau fotografer
+func loadAlbumFeed(filter AlbumFeedFilter, format string, locale string) (albumFeed, error) {
+	var website models.Website
+	if err := config.DB.First(&website).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return albumFeed{}, fmt.Errorf("failed to get website: %v", err)
+	}
+
+	feed := albumFeed{
+		Title:       website.MetaTitle,
+		Description: website.MetaDesc,
+		Link:        LocalizedPageURL("/", locale),
+		SelfLink:    seoBaseURL() + "/feeds/" + format,
+		Locale:      locale,
+		PublishedAt: map[string]time.Time{},
+	}
+	if feed.Title == "" {
+		feed.Title = "Luminor"
+	}
+
+	query := config.DB.
+		Preload("User").
+		Preload("Category").
+		Preload("Media", orderAlbumMedia).
+		Scopes(publicAlbums(""))
+
+	switch {
+	case filter.CategorySlug != "":
+		var category models.Category
+		if err := config.DB.Where("slug = ?", filter.CategorySlug).Scopes(publishedNow("")).First(&category).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return feed, fmt.Errorf("%w: category %s", ErrFeedNotFound, filter.CategorySlug)
+			}
+			return feed, fmt.Errorf("failed to get category: %v", err)
+		}
+		categories := []models.Category{category}
+		if err := localizeCategories(categories, locale); err != nil {
+			return feed, err
+		}
+
+		query = query.Where("category_id = ?", category.ID)
+		feed.Title += " - " + categories[0].Name
+		feed.Link = LocalizedPageURL(categoryPagePath(category.Slug), locale)
+		feed.SelfLink = seoBaseURL() + "/feeds/categories/" + url.PathEscape(category.Slug) + "/" + format
+	case filter.PhotographerSlug != "":
+		var user models.User
+		if err := config.DB.Where("slug = ? AND role != ?", filter.PhotographerSlug, "admin").Scopes(publishedNow("")).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return feed, fmt.Errorf("%w: photographer %s", ErrFeedNotFound, filter.PhotographerSlug)
+			}
+			return feed, fmt.Errorf("failed to get photographer: %v", err)
+		}
+
+		query = query.Where("user_id = ?", user.ID)
+		feed.Title += " - " + user.Name
+		feed.Link = LocalizedPageURL(photographerPagePath(user.Slug), locale)
+		feed.SelfLink = seoBaseURL() + "/feeds/photographers/" + url.PathEscape(user.Slug) + "/" + format
+	}
+	if locale != utils.DefaultLocale() {
+		feed.SelfLink += "?lang=" + url.QueryEscape(locale)
+	}
+
+	var albums []models.Album
+	if err := query.
+		Order("COALESCE(publish_at, created_at) DESC").
+		Limit(feedItemLimit).
+		Find(&albums).Error; err != nil {
+		return feed, fmt.Errorf("failed to get albums: %v", err)
+	}
+
+	for _, album := range albums {
+		publishedAt := album.CreatedAt
+		if album.PublishAt != nil {
+			publishedAt = *album.PublishAt
+		}
+		feed.PublishedAt[album.UUID] = publishedAt
+	}
+
+	if err := localizeAlbums(albums, locale); err != nil {
+		return feed, err
+	}
+	feed.Albums = mapPublicAlbumsToDTO(albums)
+	return feed, nil
+}
+
+// GetAlbumFeed feed album baru dalam format RSS 2.0 atau Atom 1.0
+func GetAlbumFeed(filter AlbumFeedFilter, format string, locale string) (interface{}, error) {
+	if format != FeedFormatRSS && format != FeedFormatAtom {
+		return nil, ErrInvalidFeedFormat
+	}
+
+	feed, err := loadAlbumFeed(filter, format, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FeedFormatAtom {
+		return mapAlbumFeedToAtom(feed), nil
+	}
+	return mapAlbumFeedToRSS(feed), nil
+}
+
+func mapAlbumFeedToRSS(feed albumFeed) dto.RSSResponse {
+	channel := dto.RSSChannel{
+		Title:       feed.Title,
+		Link:        feed.Link,
+		Description: feed.Description,
+		Language:    feed.Locale,
+		SelfLink:    dto.RSSLink{Href: feed.SelfLink, Rel: "self", Type: "application/rss+xml"},
+		Items:       []dto.RSSItem{},
+	}
+	if channel.Description == "" {
+		channel.Description = feed.Title
+	}
+
+	for _, album := range feed.Albums {
+		publishedAt := feed.PublishedAt[album.UUID]
+		if channel.LastBuildDate == "" {
+			channel.LastBuildDate = publishedAt.UTC().Format(time.RFC1123Z)
+		}
+
+		link := LocalizedPageURL(albumPagePath(album.Slug), feed.Locale)
+		channel.Items = append(channel.Items, dto.RSSItem{
+			Title:       album.Title,
+			Link:        link,
+			GUID:        dto.RSSGUID{IsPermaLink: false, Value: "urn:uuid:" + album.UUID},
+			Description: album.Description,
+			Category:    album.CategoryName,
+			PubDate:     publishedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return dto.RSSResponse{Version: "2.0", XmlnsAtom: atomNamespace, Channel: channel}
+}
+
+func mapAlbumFeedToAtom(feed albumFeed) dto.AtomResponse {
+	atom := dto.AtomResponse{
+		Xmlns:    atomNamespace,
+		Lang:     feed.Locale,
+		ID:       feed.SelfLink,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Author:   &dto.AtomAuthor{Name: feed.Title, URI: SiteURL()}, // wajib kalau ada entry tanpa author
+		Links: []dto.AtomLink{
+			{Href: feed.SelfLink, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: []dto.AtomEntry{},
+	}
+
+	var updated time.Time
+	for _, album := range feed.Albums {
+		if album.UpdatedAt.After(updated) {
+			updated = album.UpdatedAt
+		}
+
+		entry := dto.AtomEntry{
+			ID:        "urn:uuid:" + album.UUID,
+			Title:     album.Title,
+			Link:      dto.AtomLink{Href: LocalizedPageURL(albumPagePath(album.Slug), feed.Locale), Rel: "alternate", Type: "text/html"},
+			Published: sitemapTime(feed.PublishedAt[album.UUID]),
+			Updated:   sitemapTime(album.UpdatedAt),
+			Summary:   album.Description,
+		}
+		if album.UserName != "" {
+			entry.Author = &dto.AtomAuthor{Name: album.UserName}
+			if album.UserSlug != "" {
+				entry.Author.URI = LocalizedPageURL(photographerPagePath(album.UserSlug), feed.Locale)
+			}
+		}
+		if album.CategorySlug != "" {
+			entry.Category = &dto.AtomCategory{Term: album.CategorySlug, Label: album.CategoryName}
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	atom.Updated = sitemapTime(updated)
+	return atom
+}
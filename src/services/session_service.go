@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSessionInvalid     = errors.New("invalid or expired session")
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+type SessionMeta struct {
+	UserAgent string
+	IPAddress string
+}
+
+// createSession menyimpan session baru dan mengembalikan refresh token beserta jti-nya.
+// parent nil berarti login baru (family baru).
+func createSession(tx *gorm.DB, user *models.User, parent *models.Session, meta SessionMeta) (string, string, error) {
+	jti := uuid.NewString()
+
+	refreshToken, err := utils.GenerateRefreshToken(user.UUID, user.Role, jti)
+	if err != nil {
+		return "", "", err
+	}
+
+	session := models.Session{
+		UserID:    user.ID,
+		FamilyID:  uuid.NewString(),
+		Jti:       jti,
+		UserAgent: meta.UserAgent,
+		IPAddress: meta.IPAddress,
+		ExpiresAt: time.Now().Add(utils.GetRefreshDuration()),
+		CreatedAt: time.Now(),
+	}
+	if parent != nil {
+		session.FamilyID = parent.FamilyID
+		session.ParentJti = &parent.Jti
+	}
+
+	if err := tx.Create(&session).Error; err != nil {
+		return "", "", fmt.Errorf("failed to save session: %v", err)
+	}
+
+	return refreshToken, jti, nil
+}
+
+// RotateSession menukar refresh token lama dengan pasangan token baru.
+// Kalau token yang sudah pernah di-rotate dipakai lagi, seluruh family dicabut.
+func RotateSession(refreshToken string, meta SessionMeta) (*models.User, string, string, error) {
+	_, claims, err := utils.ValidateRefreshToken(refreshToken)
+	if err != nil || claims == nil || claims.ID == "" {
+		return nil, "", "", ErrSessionInvalid
+	}
+
+	tx := config.DB.Begin()
+	if tx.Error != nil {
+		return nil, "", "", fmt.Errorf("failed to begin transaction: %v", tx.Error)
+	}
+
+	var session models.Session
+	if err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("jti = ?", claims.ID).
+		First(&session).Error; err != nil {
+		tx.Rollback()
+		return nil, "", "", ErrSessionInvalid
+	}
+
+	if session.RevokedAt != nil {
+		if session.ReplacedBy == nil {
+			tx.Rollback()
+			return nil, "", "", ErrSessionInvalid
+		}
+
+		// Token sudah pernah ditukar → kemungkinan dicuri, cabut seluruh family
+		if err := revokeSessions(tx.Where("family_id = ?", session.FamilyID), "reuse_detected"); err != nil {
+			tx.Rollback()
+			return nil, "", "", err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, "", "", fmt.Errorf("failed to commit transaction: %v", err)
+		}
+
+		log.Printf("⚠️ Refresh token reuse detected for session family %s\n", session.FamilyID)
+		return nil, "", "", ErrRefreshTokenReused
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		tx.Rollback()
+		return nil, "", "", ErrSessionInvalid
+	}
+
+	var user models.User
+	if err := tx.Where("id = ?", session.UserID).First(&user).Error; err != nil {
+		tx.Rollback()
+		return nil, "", "", ErrSessionInvalid
+	}
+
+	accessToken, err := utils.GenerateAccessToken(user.UUID, user.Role)
+	if err != nil {
+		tx.Rollback()
+		return nil, "", "", err
+	}
+
+	newRefreshToken, newJti, err := createSession(tx, &user, &session, meta)
+	if err != nil {
+		tx.Rollback()
+		return nil, "", "", err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&session).Updates(map[string]interface{}{
+		"revoked_at":     now,
+		"revoked_reason": "rotated",
+		"replaced_by":    newJti,
+		"last_used_at":   now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return nil, "", "", fmt.Errorf("failed to rotate session: %v", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, "", "", fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return &user, accessToken, newRefreshToken, nil
+}
+
+// RevokeSessionByToken dipakai saat logout
+func RevokeSessionByToken(refreshToken string) error {
+	_, claims, err := utils.ValidateRefreshToken(refreshToken)
+	if err != nil || claims == nil || claims.ID == "" {
+		return ErrSessionInvalid
+	}
+
+	return revokeSessions(config.DB.Where("jti = ?", claims.ID), "logout")
+}
+
+func revokeSessions(query *gorm.DB, reason string) error {
+	if err := query.Model(&models.Session{}).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+	return nil
+}
+
+// GetActiveSessions mengembalikan session aktif milik user.
+// currentToken opsional untuk menandai session yang sedang dipakai.
+func GetActiveSessions(userUUID string, currentToken string) ([]dto.SessionResponse, error) {
+	user, err := GetUserByUUID(userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.Session
+	if err := config.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", user.ID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %v", err)
+	}
+
+	currentJti := ""
+	if currentToken != "" {
+		if _, claims, err := utils.ValidateRefreshToken(currentToken); err == nil && claims != nil {
+			currentJti = claims.ID
+		}
+	}
+
+	response := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = dto.SessionResponse{
+			UUID:       session.UUID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			ExpiresAt:  session.ExpiresAt,
+			LastUsedAt: session.LastUsedAt,
+			CreatedAt:  session.CreatedAt,
+			IsCurrent:  session.Jti == currentJti,
+		}
+	}
+
+	return response, nil
+}
+
+func RevokeUserSession(userUUID string, sessionUUID string) error {
+	user, err := GetUserByUUID(userUUID)
+	if err != nil {
+		return err
+	}
+
+	var session models.Session
+	if err := config.DB.Where("uuid = ? AND user_id = ?", sessionUUID, user.ID).First(&session).Error; err != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	// Cabut satu family supaya turunan rotasi dari session ini ikut mati
+	return revokeSessions(config.DB.Where("family_id = ?", session.FamilyID), "revoked_by_admin")
+}
+
+func RevokeAllUserSessions(userUUID string) error {
+	user, err := GetUserByUUID(userUUID)
+	if err != nil {
+		return err
+	}
+
+	return revokeSessions(config.DB.Where("user_id = ?", user.ID), "revoked_by_admin")
+}
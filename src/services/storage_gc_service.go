@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/utils"
+)
+
+// Prefix yang diperiksa GC, di storage public maupun private. Prefix lain di bucket tidak pernah disentuh.
+var StorageGCPrefixes = []string{"albums/", "users/", "categories/", "websites/"}
+
+// Id advisory lock supaya scheduled GC hanya jalan di satu instance
+const storageGCLockID int64 = 7310452101
+
+type storageReference struct {
+	Table   string
+	Column  string
+	IsKey   bool   // true kalau kolom berisi object key, false kalau public URL / private://
+	Private string // untuk kolom key: ekspresi SQL bool, true = objek ada di PrivateStore ("" = selalu public)
+	Where   string // filter tambahan (opsional)
+}
+
+// Semua kolom di DB yang menyimpan file di storage.
+// Tambahkan di sini kalau ada tabel/kolom baru yang menyimpan URL file.
+var storageReferences = []storageReference{
+	{Table: "users", Column: "photo"},
+	{Table: "categories", Column: "photo_url"},
+	{Table: "albums", Column: "thumbnail"},
+	{Table: "album_media", Column: "storage_key", IsKey: true, Private: "url LIKE '" + utils.PrivateURLScheme + "%'"},
+	{Table: "websites", Column: "og_image"},
+	{Table: "websites", Column: "video_web"},
+	{Table: "websites", Column: "video_mobile"},
+	{Table: "image_assets", Column: "storage_key", IsKey: true, Private: "is_private"},
+	{Table: "image_variants", Column: "storage_key", IsKey: true,
+		Private: "COALESCE((SELECT ia.is_private FROM image_assets ia WHERE ia.id = image_variants.asset_id), false)"},
+	{Table: "pending_uploads", Column: "storage_key", IsKey: true, Private: "is_private", Where: "status = 'pending'"},
+}
+
+// StorageObject objek di storage public atau private. Key yang sama bisa ada di dua-duanya
+// (mis. salinan lama setelah visibility album dipindah), jadi keduanya dicek terpisah.
+type StorageObject struct {
+	utils.ObjectInfo
+	Private bool
+}
+
+// Name key untuk log, objek private diawali private://
+func (o StorageObject) Name() string {
+	if o.Private {
+		return utils.PrivateURLScheme + o.Key
+	}
+	return o.Key
+}
+
+type storageObjectRef struct {
+	Key     string
+	Private bool
+}
+
+type StorageGCReport struct {
+	Scanned    int
+	Referenced int
+	InGrace    int
+	Orphans    []StorageObject
+	Deleted    int
+	Failed     int
+}
+
+// CollectReferencedKeys mengumpulkan semua object key (per storage) yang masih dipakai DB
+func CollectReferencedKeys() (map[storageObjectRef]bool, error) {
+	keys := map[storageObjectRef]bool{}
+
+	for _, ref := range storageReferences {
+		query := config.DB.Table(ref.Table).Where(ref.Column + " IS NOT NULL AND " + ref.Column + " <> ''")
+		if ref.Where != "" {
+			query = query.Where(ref.Where)
+		}
+
+		if ref.IsKey {
+			private := ref.Private
+			if private == "" {
+				private = "false"
+			}
+
+			var rows []storageObjectRef
+			if err := query.Select(ref.Column + " AS key, " + private + " AS private").Scan(&rows).Error; err != nil {
+				return nil, fmt.Errorf("failed to read %s.%s: %v", ref.Table, ref.Column, err)
+			}
+			for _, row := range rows {
+				keys[row] = true
+			}
+			continue
+		}
+
+		var values []string
+		if err := query.Pluck(ref.Column, &values).Error; err != nil {
+			return nil, fmt.Errorf("failed to read %s.%s: %v", ref.Table, ref.Column, err)
+		}
+
+		for _, value := range values {
+			// URL dari storage lain (mis. link eksternal) tidak relevan
+			key, private, err := utils.ResolveObjectURL(value)
+			if err != nil {
+				continue
+			}
+			keys[storageObjectRef{Key: key, Private: private}] = true
+		}
+	}
+
+	return keys, nil
+}
+
+// listStorageGCObjects list semua prefix GC di storage public dan private (kalau dikonfigurasi)
+func listStorageGCObjects(ctx context.Context) ([]StorageObject, error) {
+	var objects []StorageObject
+	for _, private := range []bool{false, true} {
+		store := utils.StoreFor(private)
+		if store == nil {
+			continue
+		}
+
+		for _, prefix := range StorageGCPrefixes {
+			listed, err := store.List(ctx, prefix)
+			if err != nil {
+				return nil, err
+			}
+			for _, obj := range listed {
+				objects = append(objects, StorageObject{ObjectInfo: obj, Private: private})
+			}
+		}
+	}
+	return objects, nil
+}
+
+// RunStorageGC mencari objek yang tidak direferensikan DB, di storage public dan private.
+// Objek yang lebih muda dari grace tidak disentuh karena bisa jadi upload yang
+// DB write-nya belum selesai. dryRun = true hanya melaporkan tanpa menghapus.
+func RunStorageGC(dryRun bool, grace time.Duration, logf func(string, ...any)) (StorageGCReport, error) {
+	var report StorageGCReport
+	ctx := context.Background()
+
+	// List dulu baru baca DB: objek yang sudah direferensikan sebelum list
+	// pasti ikut terbaca di query DB sesudahnya
+	objects, err := listStorageGCObjects(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Scanned = len(objects)
+
+	referenced, err := CollectReferencedKeys()
+	if err != nil {
+		return report, err
+	}
+
+	cutoff := time.Now().Add(-grace)
+	for _, obj := range objects {
+		if referenced[storageObjectRef{Key: obj.Key, Private: obj.Private}] {
+			report.Referenced++
+			continue
+		}
+		if obj.LastModified.After(cutoff) {
+			report.InGrace++
+			continue
+		}
+		report.Orphans = append(report.Orphans, obj)
+	}
+
+	sort.Slice(report.Orphans, func(i, j int) bool {
+		return report.Orphans[i].Name() < report.Orphans[j].Name()
+	})
+
+	for _, obj := range report.Orphans {
+		if dryRun {
+			logf("🔎 orphan %s (%d bytes, %s)\n", obj.Name(), obj.Size, obj.LastModified.Format(time.RFC3339))
+			continue
+		}
+
+		if err := utils.StoreFor(obj.Private).Delete(ctx, obj.Key); err != nil {
+			report.Failed++
+			logf("❌ failed to delete %s: %v\n", obj.Name(), err)
+			continue
+		}
+		report.Deleted++
+		logf("🗑️  deleted %s (%d bytes)\n", obj.Name(), obj.Size)
+	}
+
+	logf("✅ Storage GC: scanned %d, referenced %d, in grace %d, orphans %d, deleted %d, failed %d\n",
+		report.Scanned, report.Referenced, report.InGrace, len(report.Orphans), report.Deleted, report.Failed)
+
+	return report, nil
+}
+
+// GetStorageGCGrace membaca STORAGE_GC_GRACE (default 72 jam)
+func GetStorageGCGrace() time.Duration {
+	grace, err := time.ParseDuration(utils.GetEnvOrDefault("STORAGE_GC_GRACE", "72h"))
+	if err != nil || grace < 0 {
+		return 72 * time.Hour
+	}
+	return grace
+}
+
+// StartStorageGC menjalankan GC terjadwal. Default hanya laporan (STORAGE_GC_DELETE=false).
+func StartStorageGC() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("STORAGE_GC_INTERVAL", "24h"))
+	if err != nil || interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	dryRun := utils.GetEnvOrDefault("STORAGE_GC_DELETE", "false") != "true"
+	grace := GetStorageGCGrace()
+
+	log.Printf("🧹 Storage GC scheduled every %s (dry run: %t)\n", interval, dryRun)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := runScheduledStorageGC(dryRun, grace); err != nil {
+				log.Printf("❌ Storage GC error: %v\n", err)
+			}
+		}
+	}()
+}
+
+func runScheduledStorageGC(dryRun bool, grace time.Duration) error {
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", storageGCLockID).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked {
+		// Instance lain sedang menjalankan GC
+		return nil
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", storageGCLockID)
+
+	_, err = RunStorageGC(dryRun, grace, log.Printf)
+	return err
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidTranslation = errors.New("invalid translation")
+
+// Translations locale → field → value
+type Translations map[string]map[string]string
+
+// Field yang bisa diterjemahkan per entity
+var translatableFields = map[string][]string{
+	AuditEntityAlbum:    {"title", "description"},
+	AuditEntityCategory: {"name", "description"},
+	AuditEntityUser:     {"description"},
+	AuditEntityFaq:      {"question", "answer"},
+}
+
+// columnLocales: locale yang disimpan di kolom tabelnya sendiri, bukan di content_translations
+func columnLocales(entityType string) []string {
+	if entityType == AuditEntityFaq {
+		return []string{"id", "en"}
+	}
+	return []string{utils.DefaultLocale()}
+}
+
+func ValidateTranslations(entityType string, translations Translations) error {
+	locales := utils.SupportedLocales()
+	fields := translatableFields[entityType]
+
+	for locale, values := range translations {
+		if !slices.Contains(locales, locale) {
+			return fmt.Errorf("%w: unsupported locale %s", ErrInvalidTranslation, locale)
+		}
+		if slices.Contains(columnLocales(entityType), locale) {
+			return fmt.Errorf("%w: locale %s is stored in the main fields", ErrInvalidTranslation, locale)
+		}
+		for field := range values {
+			if !slices.Contains(fields, field) {
+				return fmt.Errorf("%w: field %s is not translatable", ErrInvalidTranslation, field)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseTranslations membaca field form "translations" berisi JSON {"en": {"title": "..."}}
+func ParseTranslations(entityType string, raw string) (Translations, error) {
+	if raw == "" || raw == "null" || raw == "undefined" {
+		return nil, nil
+	}
+
+	translations := Translations{}
+	if err := json.Unmarshal([]byte(raw), &translations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTranslation, err)
+	}
+	if err := ValidateTranslations(entityType, translations); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// saveTranslations mengganti semua terjemahan entity. nil = tidak dikirim, tidak diubah.
+func saveTranslations(tx *gorm.DB, entityType string, entityID int32, translations Translations) error {
+	if translations == nil {
+		return nil
+	}
+	if err := ValidateTranslations(entityType, translations); err != nil {
+		return err
+	}
+
+	if err := deleteTranslations(tx, entityType, []int32{entityID}); err != nil {
+		return err
+	}
+
+	var rows []models.ContentTranslation
+	for locale, values := range translations {
+		for field, value := range values {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			rows = append(rows, models.ContentTranslation{
+				EntityType: entityType,
+				EntityID:   entityID,
+				Locale:     locale,
+				Field:      field,
+				Value:      value,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to save translations: %v", err)
+	}
+	return nil
+}
+
+func deleteTranslations(tx *gorm.DB, entityType string, entityIDs []int32) error {
+	if len(entityIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("entity_type = ? AND entity_id IN ?", entityType, entityIDs).
+		Delete(&models.ContentTranslation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete translations: %v", err)
+	}
+	return nil
+}
+
+// loadTranslations mengambil terjemahan beberapa entity sekaligus
+func loadTranslations(db *gorm.DB, entityType string, entityIDs []int32) (map[int32]Translations, error) {
+	result := map[int32]Translations{}
+	if len(entityIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []models.ContentTranslation
+	if err := db.Where("entity_type = ? AND entity_id IN ?", entityType, entityIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load translations: %v", err)
+	}
+
+	for _, row := range rows {
+		if result[row.EntityID] == nil {
+			result[row.EntityID] = Translations{}
+		}
+		if result[row.EntityID][row.Locale] == nil {
+			result[row.EntityID][row.Locale] = map[string]string{}
+		}
+		result[row.EntityID][row.Locale][row.Field] = row.Value
+	}
+	return result, nil
+}
+
+// GetTranslations dipakai endpoint admin untuk membaca semua locale sekaligus
+func GetTranslations(entityType string, entityID int32) (Translations, error) {
+	translations, err := loadTranslations(config.DB, entityType, []int32{entityID})
+	if err != nil {
+		return nil, err
+	}
+	if translations[entityID] == nil {
+		return Translations{}, nil
+	}
+	return translations[entityID], nil
+}
+
+// withTranslations menambahkan terjemahan ke snapshot audit supaya perubahannya ikut tercatat
+func withTranslations(tx *gorm.DB, snapshot map[string]interface{}, entityType string, entityID int32) map[string]interface{} {
+	translations, err := loadTranslations(tx, entityType, []int32{entityID})
+	if err != nil || translations[entityID] == nil {
+		snapshot["translations"] = Translations{}
+	} else {
+		snapshot["translations"] = translations[entityID]
+	}
+	return snapshot
+}
+
+// translatedValue memilih nilai sesuai fallback chain. columns berisi nilai kolom per locale.
+func translatedValue(translations Translations, locale string, field string, columns map[string]string) string {
+	for _, l := range utils.LocaleFallbacks(locale) {
+		if value, ok := columns[l]; ok {
+			if value != "" {
+				return value
+			}
+			continue
+		}
+		if value := translations[l][field]; value != "" {
+			return value
+		}
+	}
+	return columns[utils.DefaultLocale()]
+}
+
+func defaultColumn(value string) map[string]string {
+	return map[string]string{utils.DefaultLocale(): value}
+}
+
+// localizeAlbums mengganti judul dan deskripsi album (beserta kategorinya) sesuai locale, tidak disimpan
+func localizeAlbums(albums []models.Album, locale string) error {
+	if len(albums) == 0 || locale == utils.DefaultLocale() {
+		return nil
+	}
+
+	ids := make([]int32, len(albums))
+	categories := make([]models.Category, len(albums))
+	for i, album := range albums {
+		ids[i] = album.ID
+		categories[i] = album.Category
+	}
+
+	translations, err := loadTranslations(config.DB, AuditEntityAlbum, ids)
+	if err != nil {
+		return err
+	}
+	if err := localizeCategories(categories, locale); err != nil {
+		return err
+	}
+
+	for i := range albums {
+		t := translations[albums[i].ID]
+		albums[i].Title = translatedValue(t, locale, "title", defaultColumn(albums[i].Title))
+		albums[i].Description = translatedValue(t, locale, "description", defaultColumn(albums[i].Description))
+		albums[i].Category = categories[i]
+	}
+	return nil
+}
+
+func localizeCategories(categories []models.Category, locale string) error {
+	if len(categories) == 0 || locale == utils.DefaultLocale() {
+		return nil
+	}
+
+	ids := make([]int32, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+
+	translations, err := loadTranslations(config.DB, AuditEntityCategory, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range categories {
+		t := translations[categories[i].ID]
+		categories[i].Name = translatedValue(t, locale, "name", defaultColumn(categories[i].Name))
+		categories[i].Description = translatedValue(t, locale, "description", defaultColumn(categories[i].Description))
+	}
+	return nil
+}
+
+func localizeUsers(users []models.User, locale string) error {
+	if len(users) == 0 || locale == utils.DefaultLocale() {
+		return nil
+	}
+
+	ids := make([]int32, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	translations, err := loadTranslations(config.DB, AuditEntityUser, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range users {
+		users[i].Description = translatedValue(translations[users[i].ID], locale, "description", defaultColumn(users[i].Description))
+	}
+	return nil
+}
+
+// localizedFaq mengembalikan pertanyaan dan jawaban sesuai locale
+func localizedFaq(faq models.Faq, translations Translations, locale string) (string, string) {
+	question := translatedValue(translations, locale, "question", map[string]string{"id": faq.QuestionID, "en": faq.QuestionEn})
+	answer := translatedValue(translations, locale, "answer", map[string]string{"id": faq.AnswerID, "en": faq.AnswerEn})
+	return question, answer
+}
@@ -0,0 +1,497 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const trashPurgeBatch = 100
+
+var (
+	ErrNotInTrash      = errors.New("item not found in trash")
+	ErrRestoreConflict = errors.New("item cannot be restored")
+)
+
+func deletedAtPtr(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	t := deletedAt.Time
+	return &t
+}
+
+// unscopedPreload dipakai supaya relasi yang ikut di trash tetap ter-load
+func unscopedPreload(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// trashedAlbumsQuery: album di trash, photographer hanya melihat miliknya sendiri
+func trashedAlbumsQuery(actor Actor) *gorm.DB {
+	query := config.DB.Unscoped().Model(&models.Album{}).Where("deleted_at IS NOT NULL")
+	if !actor.Can(utils.PermAlbumWriteAny) {
+		query = query.Where("user_id = (?)", config.DB.Table("users").Select("id").Where("uuid = ?", actor.UserUUID))
+	}
+	return query
+}
+
+func GetTrashedAlbums(page int, limit int, actor Actor) ([]dto.AlbumResponse, int64, error) {
+	var albums []models.Album
+	var total int64
+
+	if err := trashedAlbumsQuery(actor).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := trashedAlbumsQuery(actor).
+		Preload("User", unscopedPreload).
+		Preload("Category", unscopedPreload).
+		Preload("Media", orderAlbumMedia).
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&albums).Error; err != nil {
+		return nil, 0, err
+	}
+
+	response := mapAlbumsToDTO(albums)
+	for i, album := range albums {
+		response[i].DeletedAt = deletedAtPtr(album.DeletedAt)
+	}
+
+	return response, total, nil
+}
+
+func GetTrashedCategories(page int, limit int) ([]dto.CategoryResponse, int64, error) {
+	var categories []models.Category
+	var total int64
+
+	query := config.DB.Unscoped().Model(&models.Category{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&categories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.CategoryResponse, len(categories))
+	for i, category := range categories {
+		response[i] = dto.CategoryResponse{
+			UUID:        category.UUID,
+			Name:        category.Name,
+			Slug:        category.Slug,
+			PhotoUrl:    category.PhotoURL,
+			IsPublished: category.IsPublished,
+			CreatedAt:   category.CreatedAt,
+			YoutubeURL:  category.YoutubeURL,
+			UpdatedAt:   category.UpdatedAt,
+			DeletedAt:   deletedAtPtr(category.DeletedAt),
+		}
+	}
+	applyCategoryPlaceholders(response)
+
+	return response, total, nil
+}
+
+func GetTrashedUsers(page int, limit int) ([]dto.UserResponse, int64, error) {
+	var users []models.User
+	var total int64
+
+	query := config.DB.Unscoped().Model(&models.User{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.UserResponse, len(users))
+	for i, user := range users {
+		response[i] = dto.UserResponse{
+			UUID:        user.UUID,
+			Name:        user.Name,
+			Email:       user.Email,
+			Photo:       user.Photo,
+			Role:        user.Role,
+			Slug:        user.Slug,
+			IsPublished: user.IsPublished,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+			DeletedAt:   deletedAtPtr(user.DeletedAt),
+		}
+	}
+	applyUserPlaceholders(response)
+
+	return response, total, nil
+}
+
+func GetTrashedFaqs(page int, limit int) ([]dto.FaqResponse, int64, error) {
+	var faqs []models.Faq
+	var total int64
+
+	query := config.DB.Unscoped().Model(&models.Faq{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&faqs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.FaqResponse, len(faqs))
+	for i, faq := range faqs {
+		response[i] = dto.FaqResponse{
+			UUID:        faq.UUID,
+			QuestionID:  faq.QuestionID,
+			QuestionEn:  faq.QuestionEn,
+			AnswerID:    faq.AnswerID,
+			AnswerEn:    faq.AnswerEn,
+			IsPublished: faq.IsPublished,
+			CreatedAt:   faq.CreatedAt,
+			UpdatedAt:   faq.UpdatedAt,
+			DeletedAt:   deletedAtPtr(faq.DeletedAt),
+		}
+	}
+
+	return response, total, nil
+}
+
+// activeAlbumSlugs: album di trash yang slug-nya sudah dipakai album lain tidak ikut di-restore
+func activeAlbumSlugs(tx *gorm.DB) *gorm.DB {
+	return tx.Table("albums").Select("slug").Where("deleted_at IS NULL AND slug IS NOT NULL")
+}
+
+// RestoreAlbum mengembalikan album dari trash. Category dan pemiliknya harus sudah aktif.
+func RestoreAlbum(uuid string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		var album models.Album
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+			First(&album).Error; err != nil {
+			return ErrNotInTrash
+		}
+
+		if err := tx.Unscoped().Where("id = ?", album.UserID).First(&album.User).Error; err != nil {
+			return fmt.Errorf("failed to get album owner: %v", err)
+		}
+		if err := AuthorizeAlbum(album, actor); err != nil {
+			return err
+		}
+		if album.User.DeletedAt.Valid {
+			return fmt.Errorf("%w: owner is in trash, restore the user first", ErrRestoreConflict)
+		}
+
+		if err := tx.Unscoped().Where("id = ?", album.CategoryID).First(&album.Category).Error; err != nil {
+			return fmt.Errorf("failed to get album category: %v", err)
+		}
+		if album.Category.DeletedAt.Valid {
+			return fmt.Errorf("%w: category is in trash, restore the category first", ErrRestoreConflict)
+		}
+
+		var count int64
+		if err := tx.Model(&models.Album{}).Where("slug = ?", album.Slug).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check slug uniqueness: %v", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: slug already used by another album", ErrRestoreConflict)
+		}
+
+		if err := tx.Unscoped().Model(&album).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditActionRestore, AuditEntityAlbum, album.UUID, nil, nil)
+	})
+}
+
+// RestoreCategory mengembalikan category beserta album yang ikut terhapus bersamanya.
+// Album yang pemiliknya masih di trash tetap di trash.
+func RestoreCategory(uuid string, actor Actor) (int64, error) {
+	var restored int64
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+			First(&category).Error; err != nil {
+			return ErrNotInTrash
+		}
+
+		result := tx.Unscoped().Model(&models.Album{}).
+			Where("category_id = ? AND deleted_at = ?", category.ID, category.DeletedAt.Time).
+			Where("user_id NOT IN (?)", tx.Table("users").Select("id").Where("deleted_at IS NOT NULL")).
+			Where("slug NOT IN (?)", activeAlbumSlugs(tx)).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore albums: %v", result.Error)
+		}
+		restored = result.RowsAffected
+
+		if err := tx.Unscoped().Model(&category).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditActionRestore, AuditEntityCategory, category.UUID, nil,
+			map[string]interface{}{"albums_restored": restored})
+	})
+
+	return restored, err
+}
+
+// RestoreUser mengembalikan user beserta album yang ikut terhapus bersamanya.
+// Album yang category-nya masih di trash tetap di trash.
+func RestoreUser(uuid string, actor Actor) (int64, error) {
+	var restored int64
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+			First(&user).Error; err != nil {
+			return ErrNotInTrash
+		}
+
+		result := tx.Unscoped().Model(&models.Album{}).
+			Where("user_id = ? AND deleted_at = ?", user.ID, user.DeletedAt.Time).
+			Where("category_id NOT IN (?)", tx.Table("categories").Select("id").Where("deleted_at IS NOT NULL")).
+			Where("slug NOT IN (?)", activeAlbumSlugs(tx)).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore albums: %v", result.Error)
+		}
+		restored = result.RowsAffected
+
+		if err := tx.Unscoped().Model(&user).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditActionRestore, AuditEntityUser, user.UUID, nil,
+			map[string]interface{}{"albums_restored": restored})
+	})
+
+	return restored, err
+}
+
+func RestoreFaq(uuid string, actor Actor) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Faq{}).
+			Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotInTrash
+		}
+
+		return recordAudit(tx, actor, AuditActionRestore, AuditEntityFaq, uuid, nil, nil)
+	})
+}
+
+// GetTrashRetention membaca TRASH_RETENTION_DAYS (default 30 hari)
+func GetTrashRetention() time.Duration {
+	days, err := strconv.Atoi(utils.GetEnvOrDefault("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// StartTrashPurge menghapus permanen item yang sudah melewati masa retensi
+func StartTrashPurge() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+	retention := GetTrashRetention()
+
+	log.Printf("🗑️  Trash purge started (interval %s, retention %s)\n", interval, retention)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := PurgeTrash(time.Now().Add(-retention)); err != nil {
+				log.Printf("❌ Trash purge error: %v\n", err)
+			}
+		}
+	}()
+}
+
+// PurgeTrash menghapus permanen item yang masuk trash sebelum cutoff.
+// Anak diproses sebelum induknya: cascade FK menghapus row tanpa mengantrikan file-nya.
+func PurgeTrash(cutoff time.Time) (int, error) {
+	total := 0
+
+	steps := []struct {
+		model interface{}
+		purge func(tx *gorm.DB, id int32, cutoff time.Time) (bool, error)
+	}{
+		{&models.User{}, purgeTrashedUser},
+		{&models.Category{}, purgeTrashedCategory},
+		{&models.Album{}, purgeTrashedAlbum},
+	}
+
+	for _, step := range steps {
+		var ids []int32
+		if err := config.DB.Unscoped().Model(step.model).
+			Where("deleted_at < ?", cutoff).
+			Order("deleted_at ASC").
+			Limit(trashPurgeBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return total, fmt.Errorf("failed to get trashed items: %v", err)
+		}
+
+		// Satu transaksi per item supaya satu kegagalan tidak menahan yang lain
+		for _, id := range ids {
+			var purged bool
+			err := config.DB.Transaction(func(tx *gorm.DB) error {
+				var err error
+				purged, err = step.purge(tx, id, cutoff)
+				return err
+			})
+			if err != nil {
+				log.Printf("❌ Failed to purge %T %d: %v\n", step.model, id, err)
+				continue
+			}
+			if purged {
+				total++
+			}
+		}
+	}
+
+	// FAQ tidak punya file di storage
+	var purgedFaqs int64
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND entity_id IN (?)", AuditEntityFaq,
+			tx.Unscoped().Model(&models.Faq{}).Select("id").Where("deleted_at < ?", cutoff),
+		).Delete(&models.ContentTranslation{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Faq{})
+		purgedFaqs = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return total, fmt.Errorf("failed to purge faqs: %v", err)
+	}
+	total += int(purgedFaqs)
+
+	if total > 0 {
+		log.Printf("🗑️  Purged %d trashed items\n", total)
+	}
+	return total, nil
+}
+
+// lockTrashed mengunci row dan memastikan masih di trash (bisa saja baru di-restore)
+func lockTrashed(tx *gorm.DB, dest interface{}, id int32, cutoff time.Time) (bool, error) {
+	err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at < ?", id, cutoff).
+		First(dest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// purgeAlbums menghapus permanen album (termasuk yang aktif) yang cocok dengan query beserta file-nya
+func purgeAlbums(tx *gorm.DB, query string, args ...interface{}) error {
+	var albums []models.Album
+	if err := tx.Unscoped().Preload("Media").Where(query, args...).Find(&albums).Error; err != nil {
+		return err
+	}
+
+	ids := make([]int32, len(albums))
+	for i, album := range albums {
+		if err := queueAlbumFilesDeletion(tx, album, "trash_purged"); err != nil {
+			return err
+		}
+		ids[i] = album.ID
+	}
+	if err := deleteTranslations(tx, AuditEntityAlbum, ids); err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where(query, args...).Delete(&models.Album{}).Error
+}
+
+func purgeTrashedUser(tx *gorm.DB, id int32, cutoff time.Time) (bool, error) {
+	var user models.User
+	if ok, err := lockTrashed(tx, &user, id, cutoff); !ok {
+		return false, err
+	}
+
+	if err := purgeAlbums(tx, "user_id = ?", user.ID); err != nil {
+		return false, err
+	}
+	if err := queueStoredFileDeletion(tx, user.Photo, "trash_purged"); err != nil {
+		return false, err
+	}
+	if err := deleteTranslations(tx, AuditEntityUser, []int32{user.ID}); err != nil {
+		return false, err
+	}
+	return true, tx.Unscoped().Delete(&user).Error
+}
+
+func purgeTrashedCategory(tx *gorm.DB, id int32, cutoff time.Time) (bool, error) {
+	var category models.Category
+	if ok, err := lockTrashed(tx, &category, id, cutoff); !ok {
+		return false, err
+	}
+
+	if err := purgeAlbums(tx, "category_id = ?", category.ID); err != nil {
+		return false, err
+	}
+	if err := queueStoredFileDeletion(tx, category.PhotoURL, "trash_purged"); err != nil {
+		return false, err
+	}
+	if err := deleteTranslations(tx, AuditEntityCategory, []int32{category.ID}); err != nil {
+		return false, err
+	}
+	return true, tx.Unscoped().Delete(&category).Error
+}
+
+func purgeTrashedAlbum(tx *gorm.DB, id int32, cutoff time.Time) (bool, error) {
+	var album models.Album
+	if ok, err := lockTrashed(tx, &album, id, cutoff); !ok {
+		return false, err
+	}
+
+	return true, purgeAlbums(tx, "id = ?", album.ID)
+}
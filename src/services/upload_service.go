@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	UploadStatusPending   = "pending"
+	UploadStatusConfirmed = "confirmed"
+	UploadStatusExpired   = "expired"
+	UploadStatusDuplicate = "duplicate" // isinya sudah ada di album, media-nya dihapus lagi oleh image worker
+
+	// Waktu tambahan setelah URL kadaluarsa untuk memanggil confirm
+	uploadConfirmGrace = time.Hour
+	uploadCleanupBatch = 100
+)
+
+var ErrPresignNotSupported = errors.New("storage driver does not support presigned uploads")
+
+type UploadFileInput struct {
+	Filename    string `json:"filename" validate:"required,max=200"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+}
+
+type PresignUploadInput struct {
+	Files []UploadFileInput `json:"files" validate:"required,min=1,max=500,dive"`
+}
+
+type ConfirmUploadInput struct {
+	Uploads []string `json:"uploads" validate:"required,min=1,dive,required"`
+	// false = file yang isinya sudah ada di album dihapus lagi setelah image worker menghitung sha256
+	AllowDuplicates bool `json:"allow_duplicates"`
+}
+
+func getPresignExpiration() time.Duration {
+	duration, err := time.ParseDuration(utils.GetEnvOrDefault("PRESIGNED_UPLOAD_EXPIRATION", "1h"))
+	if err != nil || duration <= 0 {
+		return time.Hour
+	}
+	return duration
+}
+
+// validateUploadFile memastikan file adalah gambar dan ekstensinya cocok dengan content type
+func validateUploadFile(file UploadFileInput) (string, error) {
+	filename := path.Base(strings.ReplaceAll(file.Filename, `\`, "/"))
+	if filename == "." || filename == "/" {
+		return "", fmt.Errorf("invalid filename")
+	}
+
+	if !strings.HasPrefix(file.ContentType, "image/") || file.ContentType == "image/svg+xml" {
+		return "", fmt.Errorf("%s: only images can be uploaded", filename)
+	}
+
+	if mime.TypeByExtension(strings.ToLower(path.Ext(filename))) != file.ContentType {
+		return "", fmt.Errorf("%s: file extension does not match content type", filename)
+	}
+
+	if file.Size > utils.GetUploadMaxSize() {
+		return "", fmt.Errorf("%s: file is larger than %d bytes", filename, utils.GetUploadMaxSize())
+	}
+
+	return filename, nil
+}
+
+// CreatePresignedUploads membuat presigned PUT URL untuk tiap file di bawah prefix albums/
+func CreatePresignedUploads(albumUUID string, input PresignUploadInput, actor Actor) ([]dto.PresignedUploadResponse, error) {
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Album private di-upload langsung ke private storage
+	private := IsPrivateAlbum(album)
+	presigner, ok := utils.StoreFor(private).(utils.PresignStorage)
+	if !ok {
+		return nil, ErrPresignNotSupported
+	}
+
+	var userID *int32
+	if user, err := GetUserByUUID(actor.UserUUID); err == nil {
+		userID = &user.ID
+	}
+
+	// Validasi semua dulu supaya tidak ada URL yang terlanjur dibuat
+	filenames := make([]string, len(input.Files))
+	for i, file := range input.Files {
+		filename, err := validateUploadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		filenames[i] = filename
+	}
+
+	expiration := getPresignExpiration()
+	urlExpiresAt := time.Now().Add(expiration)
+
+	uploads := make([]models.PendingUpload, len(input.Files))
+	urls := make([]string, len(input.Files))
+	for i, file := range input.Files {
+		key := utils.BuildObjectKey("albums", filenames[i])
+
+		url, err := presigner.PresignPut(context.TODO(), key, file.ContentType, expiration)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = url
+
+		uploads[i] = models.PendingUpload{
+			AlbumID:     album.ID,
+			UserID:      userID,
+			StorageKey:  key,
+			IsPrivate:   private,
+			Filename:    filenames[i],
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			Status:      UploadStatusPending,
+			ExpiresAt:   urlExpiresAt.Add(uploadConfirmGrace),
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	if err := config.DB.Create(&uploads).Error; err != nil {
+		return nil, fmt.Errorf("failed to save pending uploads: %v", err)
+	}
+
+	response := make([]dto.PresignedUploadResponse, len(uploads))
+	for i, upload := range uploads {
+		response[i] = dto.PresignedUploadResponse{
+			UploadID:  upload.UUID,
+			Filename:  upload.Filename,
+			Key:       upload.StorageKey,
+			URL:       urls[i],
+			Method:    http.MethodPut,
+			Headers:   map[string]string{"Content-Type": upload.ContentType},
+			ExpiresAt: urlExpiresAt,
+		}
+	}
+
+	return response, nil
+}
+
+// verifyUploadedObject cek objek benar-benar ada, ukurannya sesuai, dan isinya gambar
+func verifyUploadedObject(upload models.PendingUpload) error {
+	if upload.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("upload expired")
+	}
+
+	store := utils.StoreFor(upload.IsPrivate)
+	if store == nil {
+		return utils.ErrPrivateStorageDisabled
+	}
+
+	info, err := store.Stat(context.TODO(), upload.StorageKey)
+	if errors.Is(err, utils.ErrObjectNotFound) {
+		return fmt.Errorf("file has not been uploaded")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check file: %v", err)
+	}
+
+	if info.Size != upload.Size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d", upload.Size, info.Size)
+	}
+	if info.ContentType != "" && info.ContentType != upload.ContentType {
+		return fmt.Errorf("content type mismatch: expected %s, got %s", upload.ContentType, info.ContentType)
+	}
+
+	// Content-Type dikirim client, jadi cek juga magic bytes-nya
+	body, _, err := store.Get(context.TODO(), upload.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %v", err)
+	}
+	defer body.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("failed to read file: %v", err)
+	}
+	if detected := http.DetectContentType(head[:n]); !strings.HasPrefix(detected, "image/") {
+		return fmt.Errorf("file content is not an image (%s)", detected)
+	}
+
+	return nil
+}
+
+// ConfirmUploads menempelkan upload yang sudah selesai ke album.
+// Upload yang gagal dicek tetap pending sampai kadaluarsa, jadi client bisa upload ulang.
+func ConfirmUploads(albumUUID string, input ConfirmUploadInput, actor Actor) (dto.ConfirmUploadResponse, error) {
+	response := dto.ConfirmUploadResponse{
+		Confirmed: []dto.AlbumMediaResponse{},
+		Failed:    []dto.UploadErrorResponse{},
+	}
+
+	album, err := getAuthorizedAlbum(config.DB, albumUUID, actor)
+	if err != nil {
+		return response, err
+	}
+
+	var uploads []models.PendingUpload
+	if err := config.DB.
+		Where("uuid IN ? AND album_id = ? AND status = ?", input.Uploads, album.ID, UploadStatusPending).
+		Order("id ASC").
+		Find(&uploads).Error; err != nil {
+		return response, fmt.Errorf("failed to get pending uploads: %v", err)
+	}
+
+	found := map[string]bool{}
+	var verified []models.PendingUpload
+	for _, upload := range uploads {
+		found[upload.UUID] = true
+		if err := verifyUploadedObject(upload); err != nil {
+			response.Failed = append(response.Failed, dto.UploadErrorResponse{UploadID: upload.UUID, Error: err.Error()})
+			continue
+		}
+		verified = append(verified, upload)
+	}
+
+	for _, uploadUUID := range input.Uploads {
+		if !found[uploadUUID] {
+			response.Failed = append(response.Failed, dto.UploadErrorResponse{UploadID: uploadUUID, Error: "upload not found"})
+		}
+	}
+
+	if len(verified) == 0 {
+		return response, nil
+	}
+
+	urls := make([]string, len(verified))
+	ids := make([]int32, len(verified))
+	for i, upload := range verified {
+		urls[i] = utils.ObjectURL(upload.StorageKey, upload.IsPrivate)
+		ids[i] = upload.ID
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		// Status dicek lagi di WHERE supaya confirm ganda tidak menambah media dua kali
+		result := tx.Model(&models.PendingUpload{}).
+			Where("id IN ? AND status = ?", ids, UploadStatusPending).
+			Updates(map[string]interface{}{"status": UploadStatusConfirmed, "confirmed_at": time.Now(), "allow_duplicates": input.AllowDuplicates})
+		if result.Error != nil {
+			return fmt.Errorf("failed to confirm uploads: %v", result.Error)
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return fmt.Errorf("uploads were confirmed by another request")
+		}
+
+		current, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		added, err := addAlbumMedia(tx, current, urls)
+		if err != nil {
+			return err
+		}
+
+		updated := current
+		updated.Media = append(append([]models.AlbumMedia{}, current.Media...), added...)
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, current.UUID, albumAuditSnapshot(current), albumAuditSnapshot(updated)); err != nil {
+			return err
+		}
+
+		response.Confirmed = MapAlbumMediaListToDTO(added)
+		return nil
+	})
+	if err != nil {
+		return response, err
+	}
+
+	for i, upload := range verified {
+		if err := registerImageAsset(urls[i], upload.ContentType, upload.Size, ""); err != nil {
+			log.Printf("⚠️ Failed to queue image derivatives for %s: %v\n", urls[i], err)
+		}
+	}
+
+	return response, nil
+}
+
+// StartUploadCleanup menghapus file dari upload yang tidak pernah di-confirm
+func StartUploadCleanup() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("UPLOAD_CLEANUP_INTERVAL", "10m"))
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Minute
+	}
+
+	log.Printf("🧹 Upload cleanup started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := CleanupExpiredUploads(); err != nil {
+				log.Printf("❌ Upload cleanup error: %v\n", err)
+			}
+		}
+	}()
+}
+
+func CleanupExpiredUploads() (int, error) {
+	total := 0
+
+	for {
+		var uploads []models.PendingUpload
+
+		// Claim dulu (status → expired) supaya instance lain tidak memproses yang sama
+		if err := config.DB.Raw(`
+			UPDATE pending_uploads
+			SET status = ?
+			WHERE id IN (
+				SELECT id FROM pending_uploads
+				WHERE status = ? AND expires_at < ?
+				ORDER BY id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`,
+			UploadStatusExpired, UploadStatusPending, time.Now(), uploadCleanupBatch,
+		).Scan(&uploads).Error; err != nil {
+			return total, fmt.Errorf("failed to claim expired uploads: %v", err)
+		}
+
+		for _, upload := range uploads {
+			store := utils.StoreFor(upload.IsPrivate)
+			if store == nil {
+				log.Printf("⚠️ Skip expired upload %s: %v\n", upload.StorageKey, utils.ErrPrivateStorageDisabled)
+				continue
+			}
+			if err := store.Delete(context.TODO(), upload.StorageKey); err != nil {
+				log.Printf("⚠️ Failed to delete expired upload %s: %v\n", upload.StorageKey, err)
+			}
+		}
+
+		total += len(uploads)
+		if len(uploads) < uploadCleanupBatch {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("🧹 Removed %d expired uploads\n", total)
+	}
+	return total, nil
+}
@@ -1,12 +1,20 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/charis16/luminor-golang-be/src/config"
 	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/models"
 	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserEmailExists  = errors.New("email already exists")
+	ErrUserEmailInTrash = errors.New("email belongs to a deleted user, restore it from trash instead")
 )
 
 type UserInput struct {
@@ -24,11 +32,14 @@ type UserInput struct {
 	PhoneNumber  string
 	CanLogin     bool
 	IsPublished  bool // tetap string kalau dari form
+	PublishAt    *time.Time
+	UnpublishAt  *time.Time
+	Translations Translations // nil = tidak diubah
 }
 
-func GetUserPortfolioBySlug(slug string) (dto.UserPortfolioResponse, error) {
+func GetUserPortfolioBySlug(slug string, locale string) (dto.UserPortfolioResponse, error) {
 	var user models.User
-	if err := config.DB.Where("slug = ?", slug).First(&user).Error; err != nil {
+	if err := config.DB.Where("slug = ?", slug).Scopes(publishedNow("")).First(&user).Error; err != nil {
 		return dto.UserPortfolioResponse{}, fmt.Errorf("failed to get user by slug: %v", err)
 	}
 
@@ -39,16 +50,27 @@ func GetUserPortfolioBySlug(slug string) (dto.UserPortfolioResponse, error) {
 	subQuery := config.DB.
 		Table("albums").
 		Select("category_id").
-		Where("user_id = ? AND is_published = ?", user.ID, true)
+		Where("user_id = ? AND deleted_at IS NULL", user.ID).
+		Scopes(publicAlbums(""))
 
 	var categories []models.Category
 	if err := config.DB.
 		Table("categories").
-		Where("id IN (?) AND is_published = ?", subQuery, true).
+		Where("id IN (?)", subQuery).
+		Scopes(publishedNow("")).
 		Find(&categories).Error; err != nil {
 		return dto.UserPortfolioResponse{}, err
 	}
 
+	users := []models.User{user}
+	if err := localizeUsers(users, locale); err != nil {
+		return dto.UserPortfolioResponse{}, err
+	}
+	user = users[0]
+	if err := localizeCategories(categories, locale); err != nil {
+		return dto.UserPortfolioResponse{}, err
+	}
+
 	categoryRes := make([]dto.CategoryResponse, 0, len(categories))
 	for _, c := range categories {
 		categoryRes = append(categoryRes, dto.CategoryResponse{
@@ -82,37 +104,62 @@ func GetUserPortfolioBySlug(slug string) (dto.UserPortfolioResponse, error) {
 		},
 		Categories: categoryRes, // Replace with the correct field name from dto.UserPortfolioResponse
 	}
+	response.User.PhotoPlaceholder = GetImagePlaceholder(user.Photo)
 
 	return response, nil
 
 }
 
-func GetAllUsers(page int, limit int, search string) ([]dto.UserResponse, int64, error) {
-	var users []models.User
-	var total int64
+var userListColumns = []string{"id", "uuid", "name", "email", "photo", "description", "phone_number", "url_instagram", "url_tiktok", "url_facebook", "is_published", "publish_at", "unpublish_at", "created_at", "updated_at", "role"}
 
-	query := config.DB.Model(&models.User{})
+// userListQuery filter yang sama untuk list admin (offset maupun cursor)
+func userListQuery(search string) *gorm.DB {
+	query := config.DB.Model(&models.User{}).Select(userListColumns)
 
 	fmt.Printf("Search term: %s\n", search)
-	// Apply search filter if search term is provided
+	// Full-text search, ILIKE untuk kata yang belum lengkap
 	if search != "" {
-		searchTerm := "%" + search + "%"
-		query = query.Where("name LIKE ? OR email LIKE ?", searchTerm, searchTerm)
+		query = query.Scopes(adminSearch(search, "name", "email"))
 	}
+	return query
+}
+
+func GetAllUsers(page int, limit int, search string) ([]dto.UserResponse, int64, error) {
+	var users []models.User
+	var total int64
+
+	query := userListQuery(search)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if search != "" {
+		query = query.Order(adminSearchOrder(search))
+	}
+
 	offset := (page - 1) * limit
 	if err := query.
-		Select("uuid", "name", "email", "photo", "description", "phone_number", "url_instagram", "url_tiktok", "url_facebook", "created_at", "updated_at", "role").
 		Limit(limit).
 		Offset(offset).
 		Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
+	return mapUserListToDTO(users), total, nil
+}
+
+func GetUsersByCursor(params CursorParams, search string) ([]dto.UserResponse, dto.CursorPageResponse, error) {
+	users, page, err := paginateByCursor(userListQuery(search), params, cursorSorts("name"), func(user models.User, column string) (interface{}, int32) {
+		return modelCursorKey(user.ID, user.CreatedAt, user.UpdatedAt, user.Name, column)
+	})
+	if err != nil {
+		return nil, page, err
+	}
+	return mapUserListToDTO(users), page, nil
+}
+
+func mapUserListToDTO(users []models.User) []dto.UserResponse {
 	// Mapping ke response DTO
 	response := make([]dto.UserResponse, len(users))
 	for i, user := range users {
@@ -129,12 +176,35 @@ func GetAllUsers(page int, limit int, search string) ([]dto.UserResponse, int64,
 			URLFacebook:  user.URLFacebook,
 			URLYoutube:   user.URLYoutube,
 			IsPublished:  user.IsPublished,
+			PublishAt:    user.PublishAt,
+			UnpublishAt:  user.UnpublishAt,
+			Status:       ContentStatus(user.IsPublished, user.PublishAt, user.UnpublishAt),
 			CreatedAt:    user.CreatedAt,
 			UpdatedAt:    user.UpdatedAt,
 		}
 	}
+	applyUserPlaceholders(response)
 
-	return response, total, nil
+	return response
+}
+
+// checkUserEmailAvailable: email tetap unique di DB walau user-nya di trash, jadi ikut dicek
+func checkUserEmailAvailable(tx *gorm.DB, email string, excludeID int32) error {
+	var existing models.User
+	err := tx.Unscoped().
+		Select("id", "deleted_at").
+		Where("email = ? AND id != ?", email, excludeID).
+		First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check email uniqueness: %v", err)
+	}
+	if existing.DeletedAt.Valid {
+		return ErrUserEmailInTrash
+	}
+	return ErrUserEmailExists
 }
 
 func GetUserByUUID(uuid string) (models.User, error) {
@@ -145,7 +215,13 @@ func GetUserByUUID(uuid string) (models.User, error) {
 	return user, nil
 }
 
-func CreateUser(input UserInput) (models.User, error) {
+func CreateUser(input UserInput, actor Actor) (models.User, error) {
+	if exists, err := RoleExists(input.Role); err != nil {
+		return models.User{}, err
+	} else if !exists {
+		return models.User{}, fmt.Errorf("role does not exist")
+	}
+
 	tx := config.DB.Begin()
 	if tx.Error != nil {
 		return models.User{}, fmt.Errorf("failed to begin transaction: %v", tx.Error)
@@ -158,8 +234,9 @@ func CreateUser(input UserInput) (models.User, error) {
 		slug = utils.GenerateSlug(slug)
 	}
 
+	// User di trash ikut dicek karena slug tetap unique di DB
 	var count int64
-	if err := tx.Model(&models.User{}).
+	if err := tx.Unscoped().Model(&models.User{}).
 		Where("slug = ? ", slug).
 		Count(&count).Error; err != nil {
 		tx.Rollback()
@@ -170,6 +247,11 @@ func CreateUser(input UserInput) (models.User, error) {
 		return models.User{}, fmt.Errorf("slug already exists")
 	}
 
+	if err := checkUserEmailAvailable(tx, input.Email, 0); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
 	user := models.User{
 		Slug:         slug,
 		Name:         input.Name,
@@ -182,6 +264,8 @@ func CreateUser(input UserInput) (models.User, error) {
 		URLYoutube:   input.URLYoutube,
 		PhoneNumber:  input.PhoneNumber,
 		IsPublished:  input.IsPublished,
+		PublishAt:    input.PublishAt,
+		UnpublishAt:  input.UnpublishAt,
 	}
 
 	if input.Password != "" {
@@ -196,6 +280,16 @@ func CreateUser(input UserInput) (models.User, error) {
 		return models.User{}, fmt.Errorf("failed to save user: %v", err)
 	}
 
+	if err := saveTranslations(tx, AuditEntityUser, user.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityUser, user.UUID, nil, withTranslations(tx, auditSnapshot(user), AuditEntityUser, user.ID)); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return models.User{}, fmt.Errorf("failed to commit transaction: %v", err)
 	}
@@ -203,9 +297,15 @@ func CreateUser(input UserInput) (models.User, error) {
 	return user, nil
 }
 
-func UpdateUser(uuid string, input UserInput) (models.User, error) {
+func UpdateUser(uuid string, input UserInput, actor Actor) (models.User, error) {
 	var user models.User
 
+	if exists, err := RoleExists(input.Role); err != nil {
+		return models.User{}, err
+	} else if !exists {
+		return models.User{}, fmt.Errorf("role does not exist")
+	}
+
 	tx := config.DB.Begin()
 	if tx.Error != nil {
 		return models.User{}, fmt.Errorf("failed to begin transaction: %v", tx.Error)
@@ -226,7 +326,7 @@ func UpdateUser(uuid string, input UserInput) (models.User, error) {
 
 	// Cek apakah slug sudah ada di user lain (selain user ini sendiri)
 	var count int64
-	if err := tx.Model(&models.User{}).
+	if err := tx.Unscoped().Model(&models.User{}).
 		Where("slug = ? AND id != ?", slug, user.ID).
 		Count(&count).Error; err != nil {
 		tx.Rollback()
@@ -237,12 +337,27 @@ func UpdateUser(uuid string, input UserInput) (models.User, error) {
 		return models.User{}, fmt.Errorf("slug already exists")
 	}
 
+	if err := checkUserEmailAvailable(tx, input.Email, user.ID); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
+	before := withTranslations(tx, auditSnapshot(user), AuditEntityUser, user.ID)
+
 	// Update field
 	user.Slug = slug
 	user.Name = input.Name
 	user.Email = input.Email
 	user.Role = input.Role
 	user.Description = input.Description
+
+	// Foto lama yang diganti ikut dihapus, tapi hanya kalau update berhasil
+	if user.Photo != "" && user.Photo != input.PhotoURL {
+		if err := queueStoredFileDeletion(tx, user.Photo, "user_photo_replaced"); err != nil {
+			tx.Rollback()
+			return models.User{}, err
+		}
+	}
 	user.Photo = input.PhotoURL
 	if input.Password != "" && input.CanLogin {
 		user.Password = utils.HashPassword(input.Password)
@@ -257,6 +372,8 @@ func UpdateUser(uuid string, input UserInput) (models.User, error) {
 	user.URLYoutube = input.URLYoutube
 	user.PhoneNumber = input.PhoneNumber
 	user.IsPublished = input.IsPublished
+	user.PublishAt = input.PublishAt
+	user.UnpublishAt = input.UnpublishAt
 
 	// Simpan perubahan
 	if err := tx.Save(&user).Error; err != nil {
@@ -264,6 +381,16 @@ func UpdateUser(uuid string, input UserInput) (models.User, error) {
 		return models.User{}, fmt.Errorf("failed to update user: %v", err)
 	}
 
+	if err := saveTranslations(tx, AuditEntityUser, user.ID, input.Translations); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityUser, user.UUID, before, withTranslations(tx, auditSnapshot(user), AuditEntityUser, user.ID)); err != nil {
+		tx.Rollback()
+		return models.User{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return models.User{}, fmt.Errorf("failed to commit transaction: %v", err)
 	}
@@ -271,7 +398,9 @@ func UpdateUser(uuid string, input UserInput) (models.User, error) {
 	return user, nil
 }
 
-func DeleteUser(uuid string) error {
+// DeleteUser memindahkan user dan album-albumnya ke trash.
+// File baru dihapus saat trash di-purge (lihat PurgeTrash).
+func DeleteUser(uuid string, actor Actor) error {
 	tx := config.DB.Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %v", tx.Error)
@@ -283,50 +412,30 @@ func DeleteUser(uuid string) error {
 		return fmt.Errorf("failed to get user: %v", err)
 	}
 
-	// === Step 1: Delete user photo from MinIO bucket "users"
-	if user.Photo != "" {
-		if err := utils.DeleteFromR2("users", user.Photo); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to delete user photo: %v", err)
-		}
+	// Timestamp yang sama dipakai supaya restore hanya mengembalikan album yang ikut terhapus di sini
+	now := time.Now()
+
+	if err := tx.Model(&models.Album{}).Where("user_id = ?", user.ID).Update("deleted_at", now).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete albums: %v", err)
 	}
 
-	// === Step 2: Get all albums owned by user
-	var albums []models.Album
-	if err := tx.Where("user_id = ?", user.ID).Find(&albums).Error; err != nil {
+	if err := tx.Model(&user).Update("deleted_at", now).Error; err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to get albums: %v", err)
-	}
-
-	for _, album := range albums {
-		var images []string
-		if len(album.Images) > 0 {
-			images = album.Images
-
-			for _, image := range images {
-				if image != "" {
-					if err := utils.DeleteFromR2("albums", image); err != nil {
-						tx.Rollback()
-						return fmt.Errorf("failed to delete album image: %v", err)
-					}
-				}
-			}
-		}
+		return fmt.Errorf("failed to delete user: %v", err)
 	}
 
-	// === Step 3: Delete albums from DB
-	if err := tx.Where("user_id = ?", user.ID).Delete(&models.Album{}).Error; err != nil {
+	// User di trash tidak boleh tetap login
+	if err := revokeSessions(tx.Where("user_id = ?", user.ID), "user_deleted"); err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to delete albums: %v", err)
+		return err
 	}
 
-	// === Step 4: Delete user
-	if err := tx.Delete(&user).Error; err != nil {
+	if err := recordAudit(tx, actor, AuditActionDelete, AuditEntityUser, user.UUID, auditSnapshot(user), nil); err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to delete user: %v", err)
+		return err
 	}
 
-	// === Step 5: Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
@@ -334,7 +443,7 @@ func DeleteUser(uuid string) error {
 	return nil
 }
 
-func DeleteImageUser(uuid string) error {
+func DeleteImageUser(uuid string, actor Actor) error {
 	tx := config.DB.Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %v", tx.Error)
@@ -346,11 +455,9 @@ func DeleteImageUser(uuid string) error {
 		return fmt.Errorf("failed to get user: %v", err)
 	}
 
-	if user.Photo != "" {
-		if err := utils.DeleteFromR2("users", user.Photo); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to delete user photo: %v", err)
-		}
+	if err := queueStoredFileDeletion(tx, user.Photo, "user_image_deleted"); err != nil {
+		tx.Rollback()
+		return err
 	}
 
 	if err := tx.Model(&user).Updates(map[string]interface{}{
@@ -360,6 +467,12 @@ func DeleteImageUser(uuid string) error {
 		return fmt.Errorf("failed to update user photo: %v", err)
 	}
 
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityUser, user.UUID,
+		map[string]interface{}{"photo": user.Photo}, map[string]interface{}{"photo": nil}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
@@ -370,7 +483,7 @@ func DeleteImageUser(uuid string) error {
 func GetUserOptions() ([]dto.UserResponse, error) {
 	var users []models.User
 	if err := config.DB.
-		Where("is_published = ?", true).
+		Scopes(publishedNow("")).
 		Where("role != ?", "admin").
 		Order("created_at DESC").
 		Find(&users).Error; err != nil {
@@ -397,20 +510,25 @@ func GetUserOptions() ([]dto.UserResponse, error) {
 			Description:  user.Description,
 		}
 	}
+	applyUserPlaceholders(response)
 
 	return response, nil
 }
 
-func GetTeamMembers() ([]dto.UserResponse, error) {
+func GetTeamMembers(locale string) ([]dto.UserResponse, error) {
 	var users []models.User
 	if err := config.DB.
-		Where("is_published = ?", true).
+		Scopes(publishedNow("")).
 		Where("role != ?", "admin").
 		Order("created_at DESC").
 		Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to get team members: %v", err)
 	}
 
+	if err := localizeUsers(users, locale); err != nil {
+		return nil, err
+	}
+
 	response := make([]dto.UserResponse, len(users))
 	for i, user := range users {
 		response[i] = dto.UserResponse{
@@ -431,6 +549,7 @@ func GetTeamMembers() ([]dto.UserResponse, error) {
 			UpdatedAt:    user.UpdatedAt,
 		}
 	}
+	applyUserPlaceholders(response)
 
 	return response, nil
 }
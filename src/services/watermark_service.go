@@ -0,0 +1,707 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charis16/luminor-golang-be/src/config"
+	"github.com/charis16/luminor-golang-be/src/dto"
+	"github.com/charis16/luminor-golang-be/src/models"
+	"github.com/charis16/luminor-golang-be/src/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	WatermarkModeInherit  = "inherit"
+	WatermarkModeEnabled  = "enabled"
+	WatermarkModeDisabled = "disabled"
+
+	WatermarkStatusNone       = "none"
+	WatermarkStatusPending    = "pending"
+	WatermarkStatusProcessing = "processing"
+	WatermarkStatusReady      = "ready"
+	WatermarkStatusFailed     = "failed"
+
+	// Salinan ber-watermark disimpan sebagai image_variants dengan prefix ini,
+	// "wm-full" ukuran asli, sisanya ukuran responsive yang sama dengan derivative biasa
+	VariantWatermarkPrefix = "wm-"
+	VariantWatermarkFull   = VariantWatermarkPrefix + "full"
+
+	watermarkWorkerBatch = 5
+)
+
+var ErrWatermarkImageRequired = errors.New("upload a watermark image before enabling it")
+
+type WebsiteWatermarkInput struct {
+	Enabled  *bool    `json:"enabled"`
+	Position string   `json:"position" validate:"omitempty,oneof=top-left top-right bottom-left bottom-right center"`
+	Opacity  *float64 `json:"opacity" validate:"omitempty,gt=0,lte=1"`
+	Scale    *float64 `json:"scale" validate:"omitempty,gt=0,lte=1"`
+	ImageURL string   `json:"-"` // diisi controller setelah upload
+}
+
+// WatermarkOverrideInput override per album/kategori, field kosong = ikut level di atasnya
+type WatermarkOverrideInput struct {
+	Mode     string   `json:"mode" validate:"required,oneof=inherit enabled disabled"`
+	Position *string  `json:"position" validate:"omitempty,oneof=top-left top-right bottom-left bottom-right center"`
+	Opacity  *float64 `json:"opacity" validate:"omitempty,gt=0,lte=1"`
+	Scale    *float64 `json:"scale" validate:"omitempty,gt=0,lte=1"`
+}
+
+// WatermarkSettings hasil akhir setelah override website -> kategori -> album
+type WatermarkSettings struct {
+	ImageURL string
+	Position string
+	Opacity  float64
+	Scale    float64
+}
+
+// Fingerprint berubah kalau hasil render akan berbeda, dipakai di nama file variant
+func (s WatermarkSettings) Fingerprint() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%.3f|%.3f", s.ImageURL, s.Position, s.Opacity, s.Scale)))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func mapWebsiteWatermarkToDTO(website models.Website) dto.WatermarkSettingsResponse {
+	return dto.WatermarkSettingsResponse{
+		ImageURL: website.WatermarkURL,
+		Enabled:  website.WatermarkEnabled,
+		Position: website.WatermarkPosition,
+		Opacity:  website.WatermarkOpacity,
+		Scale:    website.WatermarkScale,
+	}
+}
+
+func MapWatermarkOverrideToDTO(mode string, position *string, opacity *float64, scale *float64) dto.WatermarkOverrideResponse {
+	return dto.WatermarkOverrideResponse{
+		Mode:     mode,
+		Position: position,
+		Opacity:  opacity,
+		Scale:    scale,
+	}
+}
+
+// watermarkWebsite pengaturan watermark global. Belum ada data website = watermark mati.
+func watermarkWebsite(db *gorm.DB) (models.Website, error) {
+	var website models.Website
+	err := db.Order("id").First(&website).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Website{}, nil
+	}
+	return website, err
+}
+
+// resolveWatermark menggabungkan pengaturan website, kategori dan album.
+// Album private tidak pernah di-watermark karena tidak tampil di halaman publik.
+func resolveWatermark(website models.Website, category models.Category, album models.Album) (WatermarkSettings, bool) {
+	if website.WatermarkURL == "" || IsPrivateAlbum(album) {
+		return WatermarkSettings{}, false
+	}
+
+	enabled := website.WatermarkEnabled
+	settings := WatermarkSettings{
+		ImageURL: website.WatermarkURL,
+		Position: website.WatermarkPosition,
+		Opacity:  website.WatermarkOpacity,
+		Scale:    website.WatermarkScale,
+	}
+
+	apply := func(mode string, position *string, opacity *float64, scale *float64) {
+		switch mode {
+		case WatermarkModeEnabled:
+			enabled = true
+		case WatermarkModeDisabled:
+			enabled = false
+		}
+		if position != nil {
+			settings.Position = *position
+		}
+		if opacity != nil {
+			settings.Opacity = *opacity
+		}
+		if scale != nil {
+			settings.Scale = *scale
+		}
+	}
+	apply(category.WatermarkMode, category.WatermarkPosition, category.WatermarkOpacity, category.WatermarkScale)
+	apply(album.WatermarkMode, album.WatermarkPosition, album.WatermarkOpacity, album.WatermarkScale)
+
+	return settings, enabled
+}
+
+// queueWatermarkForURLs menandai gambar untuk dicek/di-render ulang worker watermark
+func queueWatermarkForURLs(tx *gorm.DB, urls []string) error {
+	if len(urls) == 0 {
+		return nil
+	}
+	return markWatermarkPending(tx.Where("url IN ?", urls))
+}
+
+// queueWatermarkForAlbums menandai semua gambar (media + thumbnail) album hasil subquery albumIDs
+func queueWatermarkForAlbums(tx *gorm.DB, albumIDs *gorm.DB) error {
+	media := tx.Session(&gorm.Session{NewDB: true}).Model(&models.AlbumMedia{}).Select("url").Where("album_id IN (?)", albumIDs)
+	thumbnails := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Album{}).Select("thumbnail").Where("id IN (?)", albumIDs)
+	return markWatermarkPending(tx.Where("url IN (?) OR url IN (?)", media, thumbnails))
+}
+
+func markWatermarkPending(query *gorm.DB) error {
+	if err := query.Model(&models.ImageAsset{}).
+		Updates(map[string]interface{}{
+			"watermark_status":    WatermarkStatusPending,
+			"watermark_attempts":  0,
+			"watermark_locked_at": nil,
+			"watermark_error":     "",
+		}).Error; err != nil {
+		return fmt.Errorf("failed to queue watermark render: %v", err)
+	}
+	return nil
+}
+
+func albumIDQuery(tx *gorm.DB, where string, args ...interface{}) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&models.Album{}).Select("id").Where(where, args...)
+}
+
+// UpdateWebsiteWatermark mengubah pengaturan watermark global lalu me-render ulang semua album
+func UpdateWebsiteWatermark(uuid string, input WebsiteWatermarkInput, actor Actor) (dto.WatermarkSettingsResponse, error) {
+	var response dto.WatermarkSettingsResponse
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var website models.Website
+		if err := tx.Where("uuid = ?", uuid).First(&website).Error; err != nil {
+			return fmt.Errorf("website not found")
+		}
+
+		before := auditSnapshot(website)
+		oldURL := website.WatermarkURL
+
+		if input.ImageURL != "" {
+			website.WatermarkURL = input.ImageURL
+		}
+		if input.Enabled != nil {
+			website.WatermarkEnabled = *input.Enabled
+		}
+		if input.Position != "" {
+			website.WatermarkPosition = input.Position
+		}
+		if input.Opacity != nil {
+			website.WatermarkOpacity = *input.Opacity
+		}
+		if input.Scale != nil {
+			website.WatermarkScale = *input.Scale
+		}
+		if website.WatermarkEnabled && website.WatermarkURL == "" {
+			return ErrWatermarkImageRequired
+		}
+		website.UpdatedAt = time.Now()
+
+		if err := tx.Save(&website).Error; err != nil {
+			return fmt.Errorf("failed to update watermark: %v", err)
+		}
+
+		if oldURL != "" && oldURL != website.WatermarkURL {
+			if err := queueStoredFileDeletion(tx, oldURL, "watermark_replaced"); err != nil {
+				return err
+			}
+		}
+
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityWebsite, website.UUID, before, auditSnapshot(website)); err != nil {
+			return err
+		}
+
+		response = mapWebsiteWatermarkToDTO(website)
+		return queueWatermarkForAlbums(tx, albumIDQuery(tx, "visibility = ?", AlbumVisibilityPublic))
+	})
+
+	return response, err
+}
+
+// RerenderWatermarks mengantrikan ulang semua album publik (misal setelah render gagal)
+func RerenderWatermarks() error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		return queueWatermarkForAlbums(tx, albumIDQuery(tx, "visibility = ?", AlbumVisibilityPublic))
+	})
+}
+
+func SetAlbumWatermark(albumUUID string, input WatermarkOverrideInput, actor Actor) (dto.WatermarkOverrideResponse, error) {
+	var response dto.WatermarkOverrideResponse
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		album, err := getAuthorizedAlbum(tx, albumUUID, actor)
+		if err != nil {
+			return err
+		}
+
+		before := albumAuditSnapshot(album)
+		album.WatermarkMode = input.Mode
+		album.WatermarkPosition = input.Position
+		album.WatermarkOpacity = input.Opacity
+		album.WatermarkScale = input.Scale
+		album.UpdatedAt = time.Now()
+
+		if err := tx.Model(&models.Album{}).Where("id = ?", album.ID).Updates(map[string]interface{}{
+			"watermark_mode":     album.WatermarkMode,
+			"watermark_position": album.WatermarkPosition,
+			"watermark_opacity":  album.WatermarkOpacity,
+			"watermark_scale":    album.WatermarkScale,
+			"updated_at":         album.UpdatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update album watermark: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityAlbum, album.UUID, before, albumAuditSnapshot(album)); err != nil {
+			return err
+		}
+
+		response = MapWatermarkOverrideToDTO(album.WatermarkMode, album.WatermarkPosition, album.WatermarkOpacity, album.WatermarkScale)
+		return queueWatermarkForAlbums(tx, albumIDQuery(tx, "id = ?", album.ID))
+	})
+
+	return response, err
+}
+
+func SetCategoryWatermark(categoryUUID string, input WatermarkOverrideInput, actor Actor) (dto.WatermarkOverrideResponse, error) {
+	var response dto.WatermarkOverrideResponse
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.Where("uuid = ?", categoryUUID).First(&category).Error; err != nil {
+			return fmt.Errorf("category not found")
+		}
+
+		before := auditSnapshot(category)
+		category.WatermarkMode = input.Mode
+		category.WatermarkPosition = input.Position
+		category.WatermarkOpacity = input.Opacity
+		category.WatermarkScale = input.Scale
+		category.UpdatedAt = time.Now()
+
+		if err := tx.Model(&models.Category{}).Where("id = ?", category.ID).Updates(map[string]interface{}{
+			"watermark_mode":     category.WatermarkMode,
+			"watermark_position": category.WatermarkPosition,
+			"watermark_opacity":  category.WatermarkOpacity,
+			"watermark_scale":    category.WatermarkScale,
+			"updated_at":         category.UpdatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update category watermark: %v", err)
+		}
+
+		if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityCategory, category.UUID, before, auditSnapshot(category)); err != nil {
+			return err
+		}
+
+		response = MapWatermarkOverrideToDTO(category.WatermarkMode, category.WatermarkPosition, category.WatermarkOpacity, category.WatermarkScale)
+		return queueWatermarkForAlbums(tx, albumIDQuery(tx, "category_id = ?", category.ID))
+	})
+
+	return response, err
+}
+
+// StartWatermarkWorker me-render salinan ber-watermark di background.
+// Aman dijalankan di beberapa instance karena claim memakai SKIP LOCKED.
+func StartWatermarkWorker() {
+	interval, err := time.ParseDuration(utils.GetEnvOrDefault("WATERMARK_WORKER_INTERVAL", "10s"))
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	log.Printf("💧 Watermark worker started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for {
+				processed, err := ProcessPendingWatermarks(watermarkWorkerBatch)
+				if err != nil {
+					log.Printf("❌ Watermark worker error: %v\n", err)
+					break
+				}
+				if processed < watermarkWorkerBatch {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// ProcessPendingWatermarks meng-claim beberapa asset lalu me-render (atau menghapus) salinan ber-watermark
+func ProcessPendingWatermarks(limit int) (int, error) {
+	var assets []models.ImageAsset
+
+	err := config.DB.Raw(`
+		UPDATE image_assets
+		SET watermark_status = ?, watermark_locked_at = NOW(), watermark_attempts = watermark_attempts + 1
+		WHERE id IN (
+			SELECT id FROM image_assets
+			WHERE (watermark_status = ? OR (watermark_status = ? AND watermark_locked_at < ?))
+			AND watermark_attempts < ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		WatermarkStatusProcessing,
+		WatermarkStatusPending, WatermarkStatusProcessing, time.Now().Add(-imageLockTimeout),
+		imageMaxAttempts,
+		limit,
+	).Scan(&assets).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim watermark assets: %v", err)
+	}
+
+	website, err := watermarkWebsite(config.DB)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get watermark settings: %v", err)
+	}
+
+	for _, asset := range assets {
+		if err := processWatermarkAsset(asset, website); err != nil {
+			log.Printf("❌ Failed to watermark image %s: %v\n", asset.StorageKey, err)
+			markWatermarkFailed(asset, err)
+		}
+	}
+
+	return len(assets), nil
+}
+
+func markWatermarkFailed(asset models.ImageAsset, cause error) {
+	status := WatermarkStatusPending
+	if asset.WatermarkAttempts >= imageMaxAttempts || errors.Is(cause, utils.ErrObjectNotFound) {
+		status = WatermarkStatusFailed
+	}
+
+	if err := config.DB.Model(&models.ImageAsset{}).
+		Where("id = ?", asset.ID).
+		Updates(map[string]interface{}{
+			"watermark_status":    status,
+			"watermark_error":     cause.Error(),
+			"watermark_locked_at": nil,
+		}).Error; err != nil {
+		log.Printf("❌ Failed to update image asset %d: %v\n", asset.ID, err)
+	}
+}
+
+// watermarkAlbumForURL album (beserta kategori) yang memakai gambar ini sebagai media atau thumbnail
+func watermarkAlbumForURL(url string) (models.Album, bool, error) {
+	var album models.Album
+	err := config.DB.
+		Preload("Category").
+		Where("id IN (?) OR thumbnail = ?", config.DB.Model(&models.AlbumMedia{}).Select("album_id").Where("url = ?", url), url).
+		Order("id").
+		First(&album).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return album, false, nil
+	}
+	return album, err == nil, err
+}
+
+func processWatermarkAsset(asset models.ImageAsset, website models.Website) error {
+	album, found, err := watermarkAlbumForURL(asset.URL)
+	if err != nil {
+		return fmt.Errorf("failed to get album: %v", err)
+	}
+
+	settings, enabled := resolveWatermark(website, album.Category, album)
+	if !found || !enabled || asset.IsPrivate {
+		return saveWatermarkVariants(asset, nil, nil)
+	}
+
+	fingerprint := settings.Fingerprint()
+	if asset.Watermark != nil && *asset.Watermark == fingerprint {
+		return saveWatermarkVariants(asset, &fingerprint, nil)
+	}
+
+	mark, err := loadWatermarkImage(settings.ImageURL)
+	if err != nil {
+		return fmt.Errorf("failed to load watermark: %w", err)
+	}
+
+	store := utils.StoreFor(asset.IsPrivate)
+	data, _, err := readStorageObject(store, asset.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	img, format, _, err := decodeOrientedImage(data)
+	if err != nil {
+		return err
+	}
+
+	outputs, err := generateWatermarkedImages(img, format, mark, settings)
+	if err != nil {
+		return err
+	}
+
+	variants := make([]models.ImageVariant, 0, len(outputs))
+	for _, out := range outputs {
+		// Fingerprint ada di key supaya CDN tidak menyajikan render lama
+		key := utils.VariantKey(asset.StorageKey, out.Name+"-"+fingerprint[:8], variantExtension(out.Format))
+		if err := store.Put(context.TODO(), key, bytes.NewReader(out.Data), int64(len(out.Data)), "image/"+out.Format); err != nil {
+			return fmt.Errorf("failed to upload variant %s: %w", key, err)
+		}
+
+		variants = append(variants, models.ImageVariant{
+			AssetID:    asset.ID,
+			Name:       out.Name,
+			Format:     out.Format,
+			StorageKey: key,
+			URL:        utils.ObjectURL(key, asset.IsPrivate),
+			Width:      int32(out.Width),
+			Height:     int32(out.Height),
+			Bytes:      int64(len(out.Data)),
+			Crc32:      out.checksum(),
+			CreatedAt:  time.Now(),
+		})
+	}
+
+	return saveWatermarkVariants(asset, &fingerprint, variants)
+}
+
+// saveWatermarkVariants mengganti variant "wm-*" asset. variants nil + fingerprint ada = hasil lama masih berlaku,
+// fingerprint nil = watermark tidak dipakai lagi dan variant lama dihapus.
+func saveWatermarkVariants(asset models.ImageAsset, fingerprint *string, variants []models.ImageVariant) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		status := WatermarkStatusReady
+		if fingerprint == nil {
+			status = WatermarkStatusNone
+		}
+
+		if fingerprint == nil || variants != nil {
+			var old []models.ImageVariant
+			if err := tx.Where("asset_id = ? AND name LIKE ?", asset.ID, VariantWatermarkPrefix+"%").Find(&old).Error; err != nil {
+				return err
+			}
+
+			keys := make([]string, 0, len(old))
+			for _, v := range old {
+				keys = append(keys, v.StorageKey)
+			}
+			if len(old) > 0 {
+				if err := tx.Delete(&old).Error; err != nil {
+					return err
+				}
+			}
+			if err := queueObjectDeletions(tx, "watermark_replaced", asset.IsPrivate, keys...); err != nil {
+				return err
+			}
+
+			if len(variants) > 0 {
+				if err := tx.Create(&variants).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(&models.ImageAsset{}).
+			Where("id = ?", asset.ID).
+			Updates(map[string]interface{}{
+				"watermark":           fingerprint,
+				"watermark_status":    status,
+				"watermark_attempts":  0,
+				"watermark_error":     "",
+				"watermark_locked_at": nil,
+			}).Error
+	})
+}
+
+// generateWatermarkedImages salinan ukuran asli + ukuran responsive, semuanya sudah ber-watermark.
+// Resize dulu baru ditempel watermark supaya logo tetap tajam di ukuran kecil.
+func generateWatermarkedImages(img image.Image, format string, mark image.Image, settings WatermarkSettings) ([]imageOutput, error) {
+	baseFormat := "jpeg"
+	if format == "png" || format == "gif" {
+		baseFormat = "png"
+	}
+
+	bounds := img.Bounds()
+	full := utils.ApplyWatermark(img, mark, settings.Position, settings.Opacity, settings.Scale)
+	data, err := encodeImage(full, baseFormat)
+	if err != nil {
+		return nil, err
+	}
+	outputs := []imageOutput{{Name: VariantWatermarkFull, Format: baseFormat, Data: data, Width: bounds.Dx(), Height: bounds.Dy()}}
+
+	for _, size := range imageSizes {
+		if size.Width >= bounds.Dx() {
+			continue
+		}
+
+		resized := utils.ApplyWatermark(utils.ResizeToWidth(img, size.Width), mark, settings.Position, settings.Opacity, settings.Scale)
+		resizedBounds := resized.Bounds()
+		name := VariantWatermarkPrefix + size.Name
+
+		base, err := encodeImage(resized, baseFormat)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, imageOutput{Name: name, Format: baseFormat, Data: base, Width: resizedBounds.Dx(), Height: resizedBounds.Dy()})
+
+		webp, err := utils.EncodeWebP(resized)
+		if err != nil {
+			return nil, err
+		}
+		// Sama seperti generateImageDerivatives: WebP yang tidak lebih kecil tidak disimpan
+		if len(webp) < len(base) {
+			outputs = append(outputs, imageOutput{Name: name, Format: "webp", Data: webp, Width: resizedBounds.Dx(), Height: resizedBounds.Dy()})
+		}
+	}
+
+	return outputs, nil
+}
+
+// Gambar watermark di-cache per URL, URL baru setiap kali watermark diganti
+var watermarkCache struct {
+	sync.Mutex
+	url   string
+	image image.Image
+}
+
+func loadWatermarkImage(url string) (image.Image, error) {
+	watermarkCache.Lock()
+	defer watermarkCache.Unlock()
+
+	if watermarkCache.url == url && watermarkCache.image != nil {
+		return watermarkCache.image, nil
+	}
+
+	key, private, err := utils.ResolveObjectURL(url)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := readStorageObject(utils.StoreFor(private), key)
+	if err != nil {
+		return nil, err
+	}
+	mark, _, err := utils.DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	watermarkCache.url = url
+	watermarkCache.image = mark
+	return mark, nil
+}
+
+// GetWatermarkedImages salinan ber-watermark untuk banyak URL sekaligus. Render lama tetap dipakai
+// selama render ulang berjalan; URL yang belum pernah di-render tidak ada di map.
+func GetWatermarkedImages(urls []string) (map[string]dto.ResponsiveImageResponse, error) {
+	result := map[string]dto.ResponsiveImageResponse{}
+	if len(urls) == 0 {
+		return result, nil
+	}
+
+	var assets []models.ImageAsset
+	if err := config.DB.
+		Preload("Variants", "name LIKE ?", VariantWatermarkPrefix+"%").
+		Where("url IN ? AND watermark IS NOT NULL", urls).
+		Find(&assets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watermarked images: %v", err)
+	}
+
+	for _, asset := range assets {
+		var full *models.ImageVariant
+		sizes := make([]models.ImageVariant, 0, len(asset.Variants))
+		for i, v := range asset.Variants {
+			if v.Name == VariantWatermarkFull {
+				full = &asset.Variants[i]
+				continue
+			}
+			v.Name = strings.TrimPrefix(v.Name, VariantWatermarkPrefix)
+			sizes = append(sizes, v)
+		}
+		if full == nil {
+			continue
+		}
+
+		asset.Variants = sizes
+		img := mapImageAssetToDTO(asset)
+		img.URL = full.URL
+		result[asset.URL] = img
+	}
+
+	return result, nil
+}
+
+// applyAlbumWatermarks mengganti URL gambar di response publik dengan salinan ber-watermark.
+// Gambar yang belum selesai di-render disembunyikan supaya original tanpa watermark tidak pernah tampil.
+// Return album mana saja yang ber-watermark.
+func applyAlbumWatermarks(albums []models.Album, responses []dto.AlbumResponse) []bool {
+	watermarked := make([]bool, len(albums))
+	website, err := watermarkWebsite(config.DB)
+	if err != nil {
+		log.Printf("⚠️ Failed to get watermark settings: %v\n", err)
+		return watermarked
+	}
+
+	var urls []string
+	for i, album := range albums {
+		if _, enabled := resolveWatermark(website, album.Category, album); enabled {
+			watermarked[i] = true
+			urls = append(urls, AlbumMediaURLs(album)...)
+			urls = append(urls, responses[i].Thumbnail)
+		}
+	}
+	if len(urls) == 0 {
+		return watermarked
+	}
+
+	images, err := GetWatermarkedImages(urls)
+	if err != nil {
+		log.Printf("⚠️ %v\n", err)
+		images = map[string]dto.ResponsiveImageResponse{}
+	}
+
+	for i := range responses {
+		if !watermarked[i] {
+			continue
+		}
+		replacePublicImages(&responses[i], images)
+	}
+	return watermarked
+}
+
+// replacePublicImages mengganti gambar response dengan versi di map, yang tidak ada di map dibuang
+func replacePublicImages(response *dto.AlbumResponse, images map[string]dto.ResponsiveImageResponse) {
+	imageURLs := make([]string, 0, len(response.Images))
+	imageSet := make([]dto.ResponsiveImageResponse, 0, len(response.Images))
+	for _, url := range response.Images {
+		if img, ok := images[url]; ok {
+			imageURLs = append(imageURLs, img.URL)
+			imageSet = append(imageSet, img)
+		}
+	}
+	response.Images = imageURLs
+	response.ImageSet = imageSet
+
+	media := make([]dto.AlbumMediaResponse, 0, len(response.Media))
+	for _, m := range response.Media {
+		if img, ok := images[m.URL]; ok {
+			m.URL = img.URL
+			media = append(media, m)
+		}
+	}
+	response.Media = media
+
+	if img, ok := images[response.Thumbnail]; ok {
+		response.Thumbnail = img.URL
+		response.ThumbnailSet = &img
+	} else if response.Thumbnail != "" {
+		response.Thumbnail = ""
+		response.ThumbnailSet = nil
+	}
+}
+
+// mapPublicAlbumsToDTO sama seperti mapAlbumsToDTO untuk halaman publik (butuh Category di-preload)
+func mapPublicAlbumsToDTO(albums []models.Album) []dto.AlbumResponse {
+	response := mapAlbumsToDTO(albums)
+	watermarked := applyAlbumWatermarks(albums, response)
+	applyStrippedImages(response, watermarked)
+	redactPublicMetadata(response)
+	return response
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/charis16/luminor-golang-be/src/config"
 	"github.com/charis16/luminor-golang-be/src/dto"
 	"github.com/charis16/luminor-golang-be/src/models"
-	"github.com/charis16/luminor-golang-be/src/utils"
 )
 
 type WebsiteInput struct {
@@ -46,11 +45,13 @@ func GetWebsite() (dto.WebsiteResponse, int64, error) {
 		MetaDesc:           website.MetaDesc,
 		MetaKeyword:        website.MetaKeyword,
 		OgImage:            website.OgImage,
+		OgImagePlaceholder: GetImagePlaceholder(website.OgImage),
 		Address:            website.Address,
 		PhoneNumber:        website.PhoneNumber,
 		Email:              website.Email,
 		UrlInstagram:       website.URLInstagram,
 		UrlTikTok:          website.URLFacebook,
+		Watermark:          mapWebsiteWatermarkToDTO(website),
 		CreatedAt:          website.CreatedAt,
 		UpdatedAt:          website.UpdatedAt,
 	}
@@ -58,7 +59,7 @@ func GetWebsite() (dto.WebsiteResponse, int64, error) {
 	return response, 1, nil
 }
 
-func CreateWebsiteInformation(input WebsiteInput) (*models.Website, error) {
+func CreateWebsiteInformation(input WebsiteInput, actor Actor) (*models.Website, error) {
 	tx := config.DB.Begin() // Mulai transaksi
 	website := models.Website{
 		CreatedAt: time.Now(),
@@ -117,6 +118,11 @@ func CreateWebsiteInformation(input WebsiteInput) (*models.Website, error) {
 		return nil, err
 	}
 
+	if err := recordAudit(tx, actor, AuditActionCreate, AuditEntityWebsite, website.UUID, nil, auditSnapshot(website)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, err // commit gagal
 	}
@@ -124,7 +130,7 @@ func CreateWebsiteInformation(input WebsiteInput) (*models.Website, error) {
 	return &website, nil
 }
 
-func EditWebsiteInformation(uuid string, input WebsiteInput) (models.Website, error) {
+func EditWebsiteInformation(uuid string, input WebsiteInput, actor Actor) (models.Website, error) {
 	tx := config.DB.Begin()
 	var website models.Website
 
@@ -133,6 +139,8 @@ func EditWebsiteInformation(uuid string, input WebsiteInput) (models.Website, er
 		return models.Website{}, err
 	}
 
+	before := auditSnapshot(website)
+
 	website.UpdatedAt = time.Now()
 	if input.Address != "" {
 		website.Address = input.Address
@@ -186,6 +194,11 @@ func EditWebsiteInformation(uuid string, input WebsiteInput) (models.Website, er
 		return models.Website{}, err
 	}
 
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityWebsite, website.UUID, before, auditSnapshot(website)); err != nil {
+		tx.Rollback()
+		return models.Website{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return models.Website{}, err
 	}
@@ -201,37 +214,57 @@ func GetWebsiteByUUID(uuid string) (models.Website, error) {
 	return website, nil
 }
 
-func DeleteWebsiteInformation(data models.Website, status string) error {
+func DeleteWebsiteInformation(data models.Website, status string, actor Actor) error {
 	tx := config.DB.Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %v", tx.Error)
 	}
 
+	before := auditSnapshot(data)
+
 	if status == "video_web" {
-		if err := utils.DeleteFromR2("albums", data.VideoWeb); err != nil {
+		if err := queueStoredFileDeletion(tx, data.VideoWeb, "website_video_deleted"); err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to delete websites video_web photo: %v", err)
 		}
 
 		data.VideoWeb = ""
 	} else if status == "video_mobile" {
-		if err := utils.DeleteFromR2("websites", data.VideoMobile); err != nil {
+		if err := queueStoredFileDeletion(tx, data.VideoMobile, "website_video_deleted"); err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to delete websites video_mobile photo: %v", err)
 		}
 		data.VideoMobile = ""
 	} else if status == "og_image" {
-		if err := utils.DeleteFromR2("websites", data.OgImage); err != nil {
+		if err := queueStoredFileDeletion(tx, data.OgImage, "website_image_deleted"); err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to delete websites og_image photo: %v", err)
 		}
 		data.OgImage = ""
+	} else if status == "watermark" {
+		if err := queueStoredFileDeletion(tx, data.WatermarkURL, "watermark_deleted"); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to delete websites watermark: %v", err)
+		}
+		data.WatermarkURL = ""
+		data.WatermarkEnabled = false
+
+		// Salinan ber-watermark yang sudah ada dihapus worker
+		if err := queueWatermarkForAlbums(tx, albumIDQuery(tx, "visibility = ?", AlbumVisibilityPublic)); err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
 	if err := tx.Save(&data).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to update website information: %v", err)
 	}
+
+	if err := recordAudit(tx, actor, AuditActionUpdate, AuditEntityWebsite, data.UUID, before, auditSnapshot(data)); err != nil {
+		tx.Rollback()
+		return err
+	}
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
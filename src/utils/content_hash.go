@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+	"mime/multipart"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// SHA256Hex checksum isi file, dipakai untuk mendeteksi salinan yang persis sama
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// FileSHA256 menghitung SHA-256 file upload lalu mengembalikan posisi baca ke awal
+func FileSHA256(file multipart.File) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// DifferenceHash (dHash) 64-bit: gambar dikecilkan ke 9x8 grayscale, tiap bit = pixel kiri lebih terang
+// dari pixel kanannya. Tahan terhadap resize, kompresi ulang dan sedikit perubahan warna.
+func DifferenceHash(img image.Image) uint64 {
+	small := image.NewGray(image.Rect(0, 0, 9, 8))
+	xdraw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			left := small.At(x, y).(color.Gray).Y
+			right := small.At(x+1, y).(color.Gray).Y
+			if left > right {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HammingDistance jumlah bit yang berbeda antara dua perceptual hash (0 = identik secara visual)
+func HammingDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
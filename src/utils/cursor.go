@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor posisi terakhir keyset pagination: nilai kolom sort + id sebagai tie-breaker.
+// Dikirim ke client sebagai token opaque yang ditandatangani supaya tidak bisa diubah.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int32  `json:"id"`
+}
+
+func cursorSigningKey() []byte {
+	return []byte(GetEnvOrDefault("CURSOR_SIGNING_KEY", os.Getenv("JWT_SECRET")))
+}
+
+func signCursor(payload string) string {
+	mac := hmac.New(sha256.New, cursorSigningKey())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// EncodeCursor menghasilkan token "<payload>.<signature>" (base64url)
+func EncodeCursor(cursor Cursor) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signCursor(payload), nil
+}
+
+// DecodeCursor memverifikasi signature lalu membaca isi cursor
+func DecodeCursor(token string) (Cursor, error) {
+	var cursor Cursor
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signCursor(payload)), []byte(signature)) {
+		return cursor, ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
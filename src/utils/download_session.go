@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDownloadSession = errors.New("invalid or expired download session")
+
+// downloadSession isi cookie sesi download: resume hanya sah untuk link dan isi ZIP (ETag) yang sama
+type downloadSession struct {
+	Link      string `json:"l"`
+	ETag      string `json:"t"`
+	ExpiresAt int64  `json:"e"`
+}
+
+func downloadSigningKey() []byte {
+	return []byte(GetEnvOrDefault("DOWNLOAD_SIGNING_KEY", os.Getenv("JWT_SECRET")))
+}
+
+func signDownloadSession(payload string) string {
+	mac := hmac.New(sha256.New, downloadSigningKey())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// DownloadSessionExpiration dari DOWNLOAD_SESSION_EXPIRATION (default 24h)
+func DownloadSessionExpiration() time.Duration {
+	duration, err := time.ParseDuration(GetEnvOrDefault("DOWNLOAD_SESSION_EXPIRATION", "24h"))
+	if err != nil || duration <= 0 {
+		return 24 * time.Hour
+	}
+	return duration
+}
+
+// EncodeDownloadSession membuat token "<payload>.<signature>" untuk satu download yang sudah dihitung
+func EncodeDownloadSession(linkUUID string, etag string, expiresAt time.Time) (string, error) {
+	raw, err := json.Marshal(downloadSession{Link: linkUUID, ETag: etag, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signDownloadSession(payload), nil
+}
+
+// VerifyDownloadSession memastikan token valid, belum kadaluarsa, dan dibuat untuk link dan ETag ini
+func VerifyDownloadSession(token string, linkUUID string, etag string) error {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signDownloadSession(payload)), []byte(signature)) {
+		return ErrInvalidDownloadSession
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return ErrInvalidDownloadSession
+	}
+
+	var session downloadSession
+	if err := json.Unmarshal(raw, &session); err != nil {
+		return ErrInvalidDownloadSession
+	}
+	if session.Link != linkUUID || session.ETag != etag || time.Now().Unix() >= session.ExpiresAt {
+		return ErrInvalidDownloadSession
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"strings"
+	"time"
+)
+
+var ErrNoExif = errors.New("image has no EXIF data")
+
+// ExifData metadata kamera yang dipakai aplikasi. Field nil/kosong = tidak ada di file.
+type ExifData struct {
+	CameraMake      string
+	CameraModel     string
+	LensModel       string
+	FocalLength     *float64 // mm
+	FocalLength35mm *int
+	Aperture        *float64 // f-number
+	ExposureTime    string   // contoh: 1/250, 2s
+	ISO             *int
+	CapturedAt      *time.Time
+	Orientation     int // 1-8, 1 = normal
+	GPS             *ExifGPS
+}
+
+type ExifGPS struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  *float64
+}
+
+const (
+	exifTagMake             = 0x010F
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagGPSIFD           = 0x8825
+	exifTagExposureTime     = 0x829A
+	exifTagFNumber          = 0x829D
+	exifTagISO              = 0x8827
+	exifTagDateTimeOriginal = 0x9003
+	exifTagOffsetOriginal   = 0x9011
+	exifTagFocalLength      = 0x920A
+	exifTagFocalLength35mm  = 0xA405
+	exifTagLensModel        = 0xA434
+	exifTagGPSLatitudeRef   = 0x0001
+	exifTagGPSLatitude      = 0x0002
+	exifTagGPSLongitudeRef  = 0x0003
+	exifTagGPSLongitude     = 0x0004
+	exifTagGPSAltitudeRef   = 0x0005
+	exifTagGPSAltitude      = 0x0006
+)
+
+// ParseExif membaca EXIF dari JPEG (APP1), PNG (eXIf) atau WebP (chunk EXIF)
+func ParseExif(data []byte) (*ExifData, error) {
+	payload, err := findExifPayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tiff, err := newTiffReader(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ifd0 := tiff.readIFD(tiff.firstIFD)
+	exif := &ExifData{
+		CameraMake:  ifd0.string(exifTagMake),
+		CameraModel: ifd0.string(exifTagModel),
+		Orientation: 1,
+	}
+	if v, ok := ifd0.int(exifTagOrientation); ok && v >= 1 && v <= 8 {
+		exif.Orientation = v
+	}
+
+	sub := exifIFD{}
+	if offset, ok := ifd0.int(exifTagExifIFD); ok {
+		sub = tiff.readIFD(uint32(offset))
+	}
+
+	exif.LensModel = sub.string(exifTagLensModel)
+	if v, ok := sub.rational(exifTagFocalLength); ok {
+		exif.FocalLength = &v
+	}
+	if v, ok := sub.int(exifTagFocalLength35mm); ok && v > 0 {
+		exif.FocalLength35mm = &v
+	}
+	if v, ok := sub.rational(exifTagFNumber); ok {
+		exif.Aperture = &v
+	}
+	if v, ok := sub.int(exifTagISO); ok && v > 0 {
+		exif.ISO = &v
+	}
+	if num, den, ok := sub.rawRational(exifTagExposureTime); ok {
+		exif.ExposureTime = formatExposure(num, den)
+	}
+
+	captured := sub.string(exifTagDateTimeOriginal)
+	if captured == "" {
+		captured = ifd0.string(exifTagDateTime)
+	}
+	if t, ok := parseExifTime(captured, sub.string(exifTagOffsetOriginal)); ok {
+		exif.CapturedAt = &t
+	}
+
+	if offset, ok := ifd0.int(exifTagGPSIFD); ok {
+		exif.GPS = parseGPS(tiff.readIFD(uint32(offset)))
+	}
+
+	return exif, nil
+}
+
+func findExifPayload(data []byte) ([]byte, error) {
+	switch {
+	case len(data) > 4 && data[0] == 0xFF && data[1] == 0xD8:
+		i := 2
+		for i+4 <= len(data) && data[i] == 0xFF {
+			marker := data[i+1]
+			if marker == 0xDA {
+				break
+			}
+			length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+			end := i + 2 + length
+			if length < 2 || end > len(data) {
+				break
+			}
+			segment := data[i+4 : end]
+			if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				return segment[6:], nil
+			}
+			i = end
+		}
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		i := 8
+		for i+8 <= len(data) {
+			length := int(binary.BigEndian.Uint32(data[i : i+4]))
+			end := i + 12 + length
+			if length < 0 || end > len(data) {
+				break
+			}
+			if string(data[i+4:i+8]) == "eXIf" {
+				return data[i+8 : i+8+length], nil
+			}
+			i = end
+		}
+	case len(data) > 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		i := 12
+		for i+8 <= len(data) {
+			length := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+			end := i + 8 + length + length%2
+			if i+8+length > len(data) {
+				break
+			}
+			if string(data[i:i+4]) == "EXIF" {
+				return bytes.TrimPrefix(data[i+8:i+8+length], []byte("Exif\x00\x00")), nil
+			}
+			i = end
+		}
+	}
+	return nil, ErrNoExif
+}
+
+type tiffReader struct {
+	data     []byte
+	order    binary.ByteOrder
+	firstIFD uint32
+}
+
+func newTiffReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("invalid EXIF header")
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid EXIF byte order")
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return nil, fmt.Errorf("invalid EXIF header")
+	}
+
+	return &tiffReader{data: data, order: order, firstIFD: order.Uint32(data[4:8])}, nil
+}
+
+// exifValue isi satu entry IFD, raw sudah menunjuk ke data (inline atau lewat offset)
+type exifValue struct {
+	typ   uint16
+	count uint32
+	raw   []byte
+	order binary.ByteOrder
+}
+
+type exifIFD map[uint16]exifValue
+
+var exifTypeSize = map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+// readIFD membaca semua entry satu IFD. Data rusak diabaikan, bukan error, karena EXIF sering tidak rapi.
+func (t *tiffReader) readIFD(offset uint32) exifIFD {
+	ifd := exifIFD{}
+	if offset == 0 || int64(offset)+2 > int64(len(t.data)) {
+		return ifd
+	}
+
+	count := int(t.order.Uint16(t.data[offset:]))
+	for i := 0; i < count; i++ {
+		entry := int64(offset) + 2 + int64(i)*12
+		if entry+12 > int64(len(t.data)) {
+			break
+		}
+
+		tag := t.order.Uint16(t.data[entry:])
+		typ := t.order.Uint16(t.data[entry+2:])
+		n := t.order.Uint32(t.data[entry+4:])
+		size, ok := exifTypeSize[typ]
+		if !ok || n == 0 || uint64(size)*uint64(n) > uint64(len(t.data)) {
+			continue
+		}
+
+		total := size * n
+		var raw []byte
+		if total <= 4 {
+			raw = t.data[entry+8 : entry+8+int64(total)]
+		} else {
+			start := int64(t.order.Uint32(t.data[entry+8:]))
+			if start+int64(total) > int64(len(t.data)) {
+				continue
+			}
+			raw = t.data[start : start+int64(total)]
+		}
+
+		ifd[tag] = exifValue{typ: typ, count: n, raw: raw, order: t.order}
+	}
+	return ifd
+}
+
+func (ifd exifIFD) string(tag uint16) string {
+	v, ok := ifd[tag]
+	if !ok || v.typ != 2 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(v.raw), "\x00"))
+}
+
+func (ifd exifIFD) int(tag uint16) (int, bool) {
+	v, ok := ifd[tag]
+	if !ok {
+		return 0, false
+	}
+	switch v.typ {
+	case 1, 7:
+		return int(v.raw[0]), true
+	case 3:
+		return int(v.order.Uint16(v.raw)), true
+	case 4:
+		return int(v.order.Uint32(v.raw)), true
+	case 9:
+		return int(int32(v.order.Uint32(v.raw))), true
+	}
+	return 0, false
+}
+
+func (ifd exifIFD) rawRational(tag uint16) (int64, int64, bool) {
+	v, ok := ifd[tag]
+	if !ok || (v.typ != 5 && v.typ != 10) {
+		return 0, 0, false
+	}
+	return exifRationalAt(v, 0)
+}
+
+func (ifd exifIFD) rational(tag uint16) (float64, bool) {
+	num, den, ok := ifd.rawRational(tag)
+	if !ok || den == 0 {
+		return 0, false
+	}
+	return float64(num) / float64(den), true
+}
+
+func exifRationalAt(v exifValue, index int) (int64, int64, bool) {
+	if uint32(index) >= v.count {
+		return 0, 0, false
+	}
+	raw := v.raw[index*8:]
+	if v.typ == 10 {
+		return int64(int32(v.order.Uint32(raw))), int64(int32(v.order.Uint32(raw[4:]))), true
+	}
+	return int64(v.order.Uint32(raw)), int64(v.order.Uint32(raw[4:])), true
+}
+
+func formatExposure(num int64, den int64) string {
+	if num <= 0 || den <= 0 {
+		return ""
+	}
+	if num >= den {
+		seconds := float64(num) / float64(den)
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", seconds), ".0") + "s"
+	}
+	return fmt.Sprintf("1/%d", int64(math.Round(float64(den)/float64(num))))
+}
+
+// parseExifTime: EXIF tidak menyimpan zona waktu kecuali OffsetTimeOriginal, tanpa offset dianggap UTC
+func parseExifTime(value string, offset string) (time.Time, bool) {
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return time.Time{}, false
+	}
+
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t.UTC(), true
+		}
+	}
+	t, err := time.Parse("2006:01:02 15:04:05", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func parseGPS(ifd exifIFD) *ExifGPS {
+	lat, okLat := gpsDegrees(ifd[exifTagGPSLatitude])
+	lon, okLon := gpsDegrees(ifd[exifTagGPSLongitude])
+	if !okLat || !okLon {
+		return nil
+	}
+	if ifd.string(exifTagGPSLatitudeRef) == "S" {
+		lat = -lat
+	}
+	if ifd.string(exifTagGPSLongitudeRef) == "W" {
+		lon = -lon
+	}
+
+	gps := &ExifGPS{Latitude: lat, Longitude: lon}
+	if alt, ok := ifd.rational(exifTagGPSAltitude); ok {
+		if ref, ok := ifd.int(exifTagGPSAltitudeRef); ok && ref == 1 {
+			alt = -alt
+		}
+		gps.Altitude = &alt
+	}
+	return gps
+}
+
+// gpsDegrees mengubah derajat, menit, detik (3 rational) menjadi desimal
+func gpsDegrees(v exifValue) (float64, bool) {
+	if v.typ != 5 || v.count < 3 {
+		return 0, false
+	}
+
+	var result float64
+	for i, div := range []float64{1, 60, 3600} {
+		num, den, _ := exifRationalAt(v, i)
+		if den == 0 {
+			return 0, false
+		}
+		result += float64(num) / float64(den) / div
+	}
+	return result, true
+}
+
+// ApplyOrientation memutar/membalik gambar sesuai tag Orientation EXIF supaya tampil tegak
+func ApplyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for dy := 0; dy < dh; dy++ {
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-dx, dy
+			case 3:
+				sx, sy = w-1-dx, h-1-dy
+			case 4:
+				sx, sy = dx, h-1-dy
+			case 5:
+				sx, sy = dy, dx
+			case 6:
+				sx, sy = dy, h-1-dx
+			case 7:
+				sx, sy = w-1-dy, h-1-dx
+			case 8:
+				sx, sy = w-1-dy, dx
+			}
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrInvalidGalleryAccess = errors.New("invalid or expired gallery access token")
+
+// galleryAccess isi token akses setelah password gallery dibuka
+type galleryAccess struct {
+	Link      string `json:"l"`
+	ExpiresAt int64  `json:"e"`
+}
+
+func gallerySigningKey() []byte {
+	return []byte(GetEnvOrDefault("GALLERY_SIGNING_KEY", os.Getenv("JWT_SECRET")))
+}
+
+func signGalleryAccess(payload string) string {
+	mac := hmac.New(sha256.New, gallerySigningKey())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// GalleryAccessExpiration dari GALLERY_ACCESS_EXPIRATION (default 12h)
+func GalleryAccessExpiration() time.Duration {
+	duration, err := time.ParseDuration(GetEnvOrDefault("GALLERY_ACCESS_EXPIRATION", "12h"))
+	if err != nil || duration <= 0 {
+		return 12 * time.Hour
+	}
+	return duration
+}
+
+// EncodeGalleryAccess membuat token "<payload>.<signature>" untuk satu link gallery
+func EncodeGalleryAccess(linkUUID string, expiresAt time.Time) (string, error) {
+	raw, err := json.Marshal(galleryAccess{Link: linkUUID, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signGalleryAccess(payload), nil
+}
+
+// VerifyGalleryAccess memastikan token valid, belum kadaluarsa, dan dibuat untuk link ini
+func VerifyGalleryAccess(token string, linkUUID string) error {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signGalleryAccess(payload)), []byte(signature)) {
+		return ErrInvalidGalleryAccess
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return ErrInvalidGalleryAccess
+	}
+
+	var access galleryAccess
+	if err := json.Unmarshal(raw, &access); err != nil {
+		return ErrInvalidGalleryAccess
+	}
+	if access.Link != linkUUID || time.Now().Unix() >= access.ExpiresAt {
+		return ErrInvalidGalleryAccess
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// DecodeImage membaca bytes gambar (jpeg, png, gif, webp) dan mengembalikan format-nya
+func DecodeImage(data []byte) (image.Image, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, format, nil
+}
+
+// ResizeToWidth mengecilkan gambar ke lebar tertentu dengan rasio tetap
+func ResizeToWidth(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	if width >= bounds.Dx() {
+		return src
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeWebP memakai encoder pure Go (lossless), karena build kita CGO_ENABLED=0.
+// Untuk foto hasilnya bisa lebih besar dari JPEG, pemanggil harus membandingkan ukurannya dulu.
+func EncodeWebP(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// StripJPEGMetadata membuang segmen APP1..APP15 (EXIF, XMP, dll) dan COM tanpa
+// re-encode, jadi kualitas gambar tidak berubah. APP0 (JFIF) dan APP2 ICC
+// profile tetap disimpan supaya warna tidak bergeser.
+func StripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, fmt.Errorf("not a JPEG file")
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return nil, fmt.Errorf("invalid JPEG marker at %d", i)
+		}
+
+		marker := data[i+1]
+
+		// Start of Scan: sisa file adalah data gambar, salin semua
+		if marker == 0xDA {
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, fmt.Errorf("invalid JPEG segment length")
+		}
+
+		isICC := marker == 0xE2 && bytes.HasPrefix(data[i+4:end], []byte("ICC_PROFILE"))
+		isMetadata := (marker >= 0xE1 && marker <= 0xEF && !isICC) || marker == 0xFE
+		if !isMetadata {
+			out.Write(data[i:end])
+		}
+
+		i = end
+	}
+
+	return nil, fmt.Errorf("JPEG has no image data")
+}
+
+// StripWebPMetadata membuang chunk EXIF dan XMP dari container RIFF tanpa decode ulang,
+// jadi WebP lossy tetap lossy (re-encode pakai EncodeWebP bisa jauh lebih besar)
+func StripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("not a WebP file")
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+
+	i := 12
+	for i+8 <= len(data) {
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2 // chunk di-padding ke jumlah byte genap
+		if end > len(data) {
+			if i+8+size != len(data) {
+				return nil, fmt.Errorf("invalid WebP chunk size")
+			}
+			end = len(data)
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[i:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04 // flag EXIF dan XMP
+			}
+			out.Write(chunk)
+		default:
+			out.Write(data[i:end])
+		}
+
+		i = end
+	}
+
+	result := out.Bytes()
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+	return result, nil
+}
+
+// VariantKey membuat key turunan, contoh: albums/foo.jpg → albums/variants/foo_small.webp
+func VariantKey(originalKey string, name string, ext string) string {
+	dir := path.Dir(originalKey)
+	base := strings.TrimSuffix(path.Base(originalKey), path.Ext(originalKey))
+	key := fmt.Sprintf("variants/%s_%s.%s", base, name, ext)
+	if dir == "." {
+		return key
+	}
+	return dir + "/" + key
+}
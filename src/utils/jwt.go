@@ -17,11 +17,12 @@ type CustomClaims struct {
 }
 
 func GenerateAccessToken(UUID, role string) (string, error) {
-	return generateToken(UUID, role, getAccessSecret(), getAccessDuration())
+	return generateToken(UUID, role, "", getAccessSecret(), getAccessDuration())
 }
 
-func GenerateRefreshToken(UUID, role string) (string, error) {
-	return generateToken(UUID, role, getRefreshSecret(), getRefreshDuration())
+// GenerateRefreshToken membuat refresh token dengan jti yang disimpan di tabel sessions
+func GenerateRefreshToken(UUID, role, jti string) (string, error) {
+	return generateToken(UUID, role, jti, getRefreshSecret(), GetRefreshDuration())
 }
 
 func ValidateAccessToken(tokenStr string) (*jwt.Token, *CustomClaims, error) {
@@ -32,11 +33,12 @@ func ValidateRefreshToken(tokenStr string) (*jwt.Token, *CustomClaims, error) {
 	return validateToken(tokenStr, getRefreshSecret())
 }
 
-func generateToken(UUID, role string, secret []byte, duration time.Duration) (string, error) {
+func generateToken(UUID, role, jti string, secret []byte, duration time.Duration) (string, error) {
 	claims := CustomClaims{
 		UserID: UUID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -75,7 +77,7 @@ func getAccessDuration() time.Duration {
 	return parseDuration(GetEnvOrDefault("JWT_EXPIRATION", "15m"))
 }
 
-func getRefreshDuration() time.Duration {
+func GetRefreshDuration() time.Duration {
 	return parseDuration(GetEnvOrDefault("JWT_REFRESH_EXPIRATION", "7d"))
 }
 func parseDuration(d string) time.Duration {
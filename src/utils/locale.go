@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale: bahasa kolom utama di database
+func DefaultLocale() string {
+	return strings.ToLower(GetEnvOrDefault("DEFAULT_LOCALE", "id"))
+}
+
+// SupportedLocales dari SUPPORTED_LOCALES (dipisah koma), default locale selalu termasuk
+func SupportedLocales() []string {
+	locales := []string{DefaultLocale()}
+	for _, locale := range strings.Split(GetEnvOrDefault("SUPPORTED_LOCALES", "id,en"), ",") {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// matchLocale mencocokkan tag (mis. "en-US") dengan locale yang didukung: persis dulu, lalu bahasa dasarnya
+func matchLocale(tag string, locales []string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return ""
+	}
+	if slices.Contains(locales, tag) {
+		return tag
+	}
+	base, _, _ := strings.Cut(tag, "-")
+	if slices.Contains(locales, base) {
+		return base
+	}
+	return ""
+}
+
+// NegotiateLocale memilih locale dari ?lang= lalu header Accept-Language (sesuai q-value)
+func NegotiateLocale(lang string, acceptLanguage string) string {
+	locales := SupportedLocales()
+
+	if locale := matchLocale(lang, locales); locale != "" {
+		return locale
+	}
+
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if locale := matchLocale(c.tag, locales); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale()
+}
+
+// LocaleFallbacks urutan locale yang dicoba saat terjemahan kosong, selalu berakhir di default locale
+func LocaleFallbacks(locale string) []string {
+	chain := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		chain = append(chain, base)
+	}
+	if fallback := strings.ToLower(GetEnvOrDefault("FALLBACK_LOCALE", "en")); !slices.Contains(chain, fallback) {
+		chain = append(chain, fallback)
+	}
+	if !slices.Contains(chain, DefaultLocale()) {
+		chain = append(chain, DefaultLocale())
+	}
+	return chain
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Mailer adalah abstraksi pengiriman email
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+var Mail Mailer
+
+// InitMailer memilih implementasi mailer berdasarkan MAILER_DRIVER (smtp | outbox)
+func InitMailer() {
+	driver := GetEnvOrDefault("MAILER_DRIVER", "outbox")
+
+	switch driver {
+	case "smtp":
+		Mail = &SMTPMailer{
+			Host:     GetEnvOrPanic("SMTP_HOST"),
+			Port:     GetEnvOrDefault("SMTP_PORT", "587"),
+			Username: GetEnvOrDefault("SMTP_USERNAME", ""),
+			Password: GetEnvOrDefault("SMTP_PASSWORD", ""),
+			From:     GetEnvOrPanic("SMTP_FROM"),
+		}
+		log.Println("✅ SMTP mailer initialized")
+	case "outbox":
+		Mail = &OutboxMailer{Dir: GetEnvOrDefault("MAILER_OUTBOX_DIR", "./tmp/outbox")}
+		log.Println("✅ Outbox mailer initialized")
+	default:
+		log.Fatalf("❌ Unknown MAILER_DRIVER: %s", driver)
+	}
+}
+
+// SMTPMailer mengirim email lewat server SMTP
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	msg := buildMailMessage(m.From, to, subject, body)
+	if err := smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{to}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// OutboxMailer menulis email ke file .eml dan log, dipakai saat development
+type OutboxMailer struct {
+	Dir string
+}
+
+func (m *OutboxMailer) Send(to string, subject string, body string) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create outbox dir: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102-150405.000000"), GenerateSlug(to))
+	msg := buildMailMessage("outbox@localhost", to, subject, body)
+	if err := os.WriteFile(filepath.Join(m.Dir, filename), msg, 0o644); err != nil {
+		return fmt.Errorf("failed to write outbox email: %w", err)
+	}
+
+	log.Printf("📧 Email to %s (%s) written to outbox: %s\n", to, subject, filename)
+	return nil
+}
+
+func buildMailMessage(from string, to string, subject string, body string) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + to + "\r\n")
+	sb.WriteString("Subject: " + subject + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(body)
+	return []byte(sb.String())
+}
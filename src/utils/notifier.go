@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Notification dikirim ke tim saat ada kejadian yang perlu ditindaklanjuti (mis. lead baru)
+type Notification struct {
+	Type       string                 `json:"type"`
+	Subject    string                 `json:"subject"`
+	Body       string                 `json:"body"`
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// Notifier adalah abstraksi pengiriman notifikasi internal
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+var Notify Notifier
+
+// InitNotifier memilih implementasi berdasarkan NOTIFY_DRIVER (email | webhook | log)
+func InitNotifier() {
+	driver := GetEnvOrDefault("NOTIFY_DRIVER", "log")
+
+	switch driver {
+	case "email":
+		Notify = &EmailNotifier{To: GetEnvOrPanic("NOTIFY_EMAIL_TO")}
+		log.Println("✅ Email notifier initialized")
+	case "webhook":
+		Notify = &WebhookNotifier{
+			URL:    GetEnvOrPanic("NOTIFY_WEBHOOK_URL"),
+			Secret: GetEnvOrDefault("NOTIFY_WEBHOOK_SECRET", ""),
+			Client: &http.Client{Timeout: 10 * time.Second},
+		}
+		log.Println("✅ Webhook notifier initialized")
+	case "log":
+		Notify = &LogNotifier{}
+		log.Println("✅ Log notifier initialized")
+	default:
+		log.Fatalf("❌ Unknown NOTIFY_DRIVER: %s", driver)
+	}
+}
+
+// EmailNotifier mengirim notifikasi lewat Mail (harus sudah di-init)
+type EmailNotifier struct {
+	To string
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, notification Notification) error {
+	return Mail.Send(n.To, notification.Subject, notification.Body)
+}
+
+// WebhookNotifier POST notifikasi sebagai JSON, mis. ke Slack/Discord relay atau CRM
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.Secret != "" {
+		req.Header.Set("X-Notify-Secret", n.Secret)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// LogNotifier hanya menulis notifikasi ke log, dipakai saat development
+type LogNotifier struct{}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	log.Printf("🔔 %s: %s\n", notification.Type, notification.Subject)
+	return nil
+}
@@ -0,0 +1,14 @@
+package utils
+
+// Kode permission yang tersimpan di tabel permissions
+const (
+	PermAlbumWriteOwn = "album:write:own"
+	PermAlbumWriteAny = "album:write:any"
+	PermCategoryWrite = "category:write"
+	PermFaqWrite      = "faq:write"
+	PermWebsiteWrite  = "website:write"
+	PermUserManage    = "user:manage"
+	PermAuditRead     = "audit:read"
+	PermLeadManageOwn = "lead:manage:own"
+	PermLeadManageAny = "lead:manage:any"
+)
@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	lqipWidth        = 16
+	lqipJPEGQuality  = 50
+	blurhashMaxSide  = 64
+	paletteMaxSide   = 100
+	paletteMaxColors = 5
+)
+
+// ImagePlaceholder data kecil untuk ditampilkan selama gambar asli belum selesai dimuat
+type ImagePlaceholder struct {
+	Blurhash      string
+	LQIP          string // data URI base64
+	DominantColor string // #rrggbb
+	Palette       []string
+}
+
+// BuildImagePlaceholder menghitung blurhash, LQIP dan palette warna dari gambar yang sudah di-decode
+func BuildImagePlaceholder(img image.Image) (ImagePlaceholder, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return ImagePlaceholder{}, fmt.Errorf("image is empty")
+	}
+
+	// Blurhash cukup dihitung dari versi kecil, hasilnya nyaris sama dan jauh lebih cepat
+	xComponents, yComponents := 4, 3
+	if bounds.Dy() > bounds.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+	blurhash := EncodeBlurhash(toRGBA(fitWithin(img, blurhashMaxSide)), xComponents, yComponents)
+
+	lqip, err := encodeLQIP(img)
+	if err != nil {
+		return ImagePlaceholder{}, err
+	}
+
+	palette := DominantColors(fitWithin(img, paletteMaxSide), paletteMaxColors)
+	placeholder := ImagePlaceholder{Blurhash: blurhash, LQIP: lqip, Palette: palette}
+	if len(palette) > 0 {
+		placeholder.DominantColor = palette[0]
+	}
+	return placeholder, nil
+}
+
+func fitWithin(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxSide && bounds.Dy() <= maxSide {
+		return img
+	}
+	if bounds.Dx() >= bounds.Dy() {
+		return ResizeToWidth(img, maxSide)
+	}
+	return ResizeToWidth(img, max(1, maxSide*bounds.Dx()/bounds.Dy()))
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// encodeLQIP: gambar lebar 16px sebagai data URI. PNG kalau ada transparansi supaya logo tidak jadi kotak hitam.
+func encodeLQIP(img image.Image) (string, error) {
+	small := img
+	if img.Bounds().Dx() > lqipWidth {
+		small = ResizeToWidth(img, lqipWidth)
+	}
+
+	if opaque, ok := small.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		data, err := EncodePNG(small)
+		if err != nil {
+			return "", err
+		}
+		return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data), nil
+	}
+
+	data, err := EncodeJPEG(small, lqipJPEGQuality)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DominantColors mengelompokkan pixel ke bucket 4-bit per channel, lalu mengambil
+// rata-rata warna dari bucket terbanyak. Pixel (hampir) transparan diabaikan.
+func DominantColors(img image.Image, limit int) []string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+
+	buckets := map[uint16]*bucket{}
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+
+			key := uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+			b, ok := buckets[key]
+			if !ok {
+				b = &bucket{}
+				buckets[key] = b
+			}
+			b.count++
+			b.r += int(c.R)
+			b.g += int(c.G)
+			b.b += int(c.B)
+		}
+	}
+
+	sorted := make([]*bucket, 0, len(buckets))
+	for _, b := range buckets {
+		sorted = append(sorted, b)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].count > sorted[j].count })
+
+	colors := make([]string, 0, limit)
+	for _, b := range sorted {
+		if len(colors) == limit {
+			break
+		}
+		colors = append(colors, fmt.Sprintf("#%02x%02x%02x", b.r/b.count, b.g/b.count, b.b/b.count))
+	}
+	return colors
+}
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// EncodeBlurhash mengikuti algoritma referensi https://github.com/woltapp/blurhash
+func EncodeBlurhash(img *image.RGBA, xComponents int, yComponents int) string {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var r, g, b float64
+			for y := 0; y < height; y++ {
+				basisY := math.Cos(math.Pi * float64(j) * float64(y) / float64(height))
+				for x := 0; x < width; x++ {
+					basis := normalisation * math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) * basisY
+					p := img.Pix[y*img.Stride+x*4:]
+					r += basis * srgbToLinear(p[0])
+					g += basis * srgbToLinear(p[1])
+					b += basis * srgbToLinear(p[2])
+				}
+			}
+
+			scale := 1 / float64(width*height)
+			factors = append(factors, [3]float64{r * scale, g * scale, b * scale})
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encodeBase83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantisedMax+1) / 166
+		hash.WriteString(encodeBase83(quantisedMax, 1))
+	} else {
+		hash.WriteString(encodeBase83(0, 1))
+	}
+
+	hash.WriteString(encodeBase83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+
+	for _, f := range ac {
+		quant := func(v float64) int {
+			return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+		}
+		hash.WriteString(encodeBase83(quant(f[0])*19*19+quant(f[1])*19+quant(f[2]), 2))
+	}
+
+	return hash.String()
+}
+
+func encodeBase83(value int, length int) string {
+	result := make([]byte, length)
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		result[i-1] = base83Chars[digit]
+	}
+	return string(result)
+}
+
+func srgbToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value float64, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
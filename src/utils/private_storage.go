@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"os"
+	"strings"
+	"time"
+)
+
+// URL file private disimpan di DB sebagai "private://<key>", bukan URL yang bisa dibuka langsung
+const PrivateURLScheme = "private://"
+
+var ErrPrivateStorageDisabled = errors.New("private storage is not configured")
+
+// PrivateStore menyimpan file gallery private. Tidak punya URL publik, file dibuka lewat SignedURL.
+// nil kalau private storage tidak dikonfigurasi.
+var PrivateStore Storage
+
+// SignedURLStorage membuat URL GET bertanda tangan dengan masa berlaku pendek
+type SignedURLStorage interface {
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// initPrivateStorage dipanggil InitStorage. R2 butuh bucket terpisah yang tidak public.
+func initPrivateStorage(driver string) {
+	switch driver {
+	case "r2", "s3":
+		bucket := GetEnvOrDefault("R2_PRIVATE_BUCKET_NAME", "")
+		if bucket == "" {
+			log.Println("ℹ️  R2_PRIVATE_BUCKET_NAME not set, private galleries disabled")
+			return
+		}
+		PrivateStore = NewR2Storage(R2Client, bucket, "")
+		log.Println("✅ Private R2 storage initialized")
+	case "local":
+		store, err := NewLocalStorage(
+			GetEnvOrDefault("LOCAL_PRIVATE_STORAGE_DIR", "./storage-private"),
+			GetEnvOrDefault("LOCAL_PRIVATE_STORAGE_URL", "http://localhost:"+GetEnvOrDefault("PORT", "8080")+"/private-storage"),
+			GetEnvOrDefault("LOCAL_STORAGE_SIGNING_KEY", os.Getenv("JWT_SECRET")),
+		)
+		if err != nil {
+			log.Fatalf("❌ Failed to init local private storage: %v", err)
+		}
+		PrivateStore = store
+		log.Println("✅ Local private storage initialized")
+	}
+}
+
+func PrivateStorageEnabled() bool {
+	return PrivateStore != nil
+}
+
+// StoreFor memilih storage public atau private
+func StoreFor(private bool) Storage {
+	if private {
+		return PrivateStore
+	}
+	return Store
+}
+
+// ObjectURL adalah nilai yang disimpan di DB untuk key di storage public/private
+func ObjectURL(key string, private bool) string {
+	if private {
+		return PrivateURLScheme + key
+	}
+	return Store.PublicURL(key)
+}
+
+func IsPrivateURL(fileURL string) bool {
+	return strings.HasPrefix(strings.Trim(fileURL, `"`), PrivateURLScheme)
+}
+
+// ResolveObjectURL mengubah URL file (public atau private://) menjadi key dan storage-nya
+func ResolveObjectURL(fileURL string) (string, bool, error) {
+	fileURL = strings.Trim(fileURL, `"`)
+	if key, ok := strings.CutPrefix(fileURL, PrivateURLScheme); ok {
+		if key == "" {
+			return "", true, fmt.Errorf("empty object key")
+		}
+		return key, true, nil
+	}
+
+	key, err := ObjectKeyFromURL(fileURL)
+	return key, false, err
+}
+
+// PrivateURLExpiration masa berlaku signed URL dari PRIVATE_URL_EXPIRATION (default 15m)
+func PrivateURLExpiration() time.Duration {
+	duration, err := time.ParseDuration(GetEnvOrDefault("PRIVATE_URL_EXPIRATION", "15m"))
+	if err != nil || duration <= 0 {
+		return 15 * time.Minute
+	}
+	return duration
+}
+
+// SignObjectURL mengganti URL private:// dengan signed URL, URL public dikembalikan apa adanya
+func SignObjectURL(ctx context.Context, fileURL string, expires time.Duration) (string, error) {
+	key, ok := strings.CutPrefix(fileURL, PrivateURLScheme)
+	if !ok {
+		return fileURL, nil
+	}
+
+	signer, ok := PrivateStore.(SignedURLStorage)
+	if !ok {
+		return "", ErrPrivateStorageDisabled
+	}
+	return signer.SignedURL(ctx, key, expires)
+}
+
+// UploadPrivateFile sama seperti UploadFile tapi ke PrivateStore, mengembalikan URL private://
+func UploadPrivateFile(file multipart.File, fileHeader *multipart.FileHeader, prefix string) (string, error) {
+	defer file.Close()
+
+	if PrivateStore == nil {
+		return "", ErrPrivateStorageDisabled
+	}
+
+	objectName := BuildObjectKey(prefix, fileHeader.Filename)
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	if err := PrivateStore.Put(context.TODO(), objectName, file, fileHeader.Size, contentType); err != nil {
+		return "", err
+	}
+
+	return ObjectURL(objectName, true), nil
+}
+
+// CopyObject menyalin objek antar storage dengan key yang sama
+func CopyObject(ctx context.Context, from Storage, to Storage, key string) error {
+	body, info, err := from.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return to.Put(ctx, key, body, info.Size, contentType)
+}
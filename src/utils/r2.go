@@ -2,12 +2,10 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"mime/multipart"
-	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
@@ -15,7 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/gin-gonic/gin"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 var R2Client *s3.Client
@@ -48,97 +46,159 @@ func InitR2() {
 	log.Println("✅ R2 client initialized")
 }
 
-func UploadToR2(file multipart.File, fileHeader *multipart.FileHeader, prefix string) (string, error) {
-	defer file.Close()
-
-	bucket := GetEnvOrPanic("R2_BUCKET_NAME")
-	publicURL := GetEnvOrPanic("R2_PUBLIC_URL")
-
-	// 1. Clean nama file
-	cleanFilename := strings.ReplaceAll(fileHeader.Filename, " ", "-")
-	timestamp := time.Now().Format("20060102-150405")
-	uniqueSuffix := time.Now().UnixNano()
-	filename := fmt.Sprintf("%s_%d_%s", timestamp, uniqueSuffix, cleanFilename)
+// R2Storage adalah implementasi Storage untuk Cloudflare R2 / S3
+type R2Storage struct {
+	client    *s3.Client
+	bucket    string
+	publicURL string
+}
 
-	// 2. Gabungkan prefix kalau ada
-	objectName := filename
-	if prefix != "" {
-		objectName = fmt.Sprintf("%s/%s", strings.Trim(prefix, "/"), filename)
+func NewR2Storage(client *s3.Client, bucket string, publicURL string) *R2Storage {
+	return &R2Storage{
+		client:    client,
+		bucket:    bucket,
+		publicURL: strings.TrimRight(publicURL, "/"),
 	}
+}
 
-	// 3. Ambil Content-Type
-	contentType := fileHeader.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "application/octet-stream"
+func (s *R2Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	}
+	if size > 0 {
+		input.ContentLength = aws.Int64(size)
 	}
 
-	// 4. Upload ke R2
-	_, err := R2Client.PutObject(context.TODO(), &s3.PutObjectInput{
-		Bucket:      aws.String(bucket),
-		Key:         aws.String(objectName),
-		Body:        file,
-		ContentType: aws.String(contentType),
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+func (s *R2Storage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
 	})
 	if err != nil {
-		return "", err
+		return nil, ObjectInfo{}, mapR2Error(err)
 	}
 
-	// 5. Generate Public URL
-	url := fmt.Sprintf("%s/%s", strings.TrimRight(publicURL, "/"), objectName)
-	return url, nil
-}
-
-func StreamImageFromR2(c *gin.Context, filename string, contentType string, cacheDuration time.Duration) {
-	if filename == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "filename is required"})
-		return
+	info := ObjectInfo{
+		Key:         key,
+		Size:        aws.ToInt64(resp.ContentLength),
+		ContentType: aws.ToString(resp.ContentType),
 	}
+	if resp.LastModified != nil {
+		info.LastModified = *resp.LastModified
+	}
+
+	return resp.Body, info, nil
+}
 
-	resp, err := R2Client.GetObject(context.TODO(), &s3.GetObjectInput{
-		Bucket: aws.String(R2BucketName),
-		Key:    aws.String(filename),
+func (s *R2Storage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
 	})
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch image from storage"})
-		return
+		return nil, mapR2Error(err)
 	}
-	defer resp.Body.Close()
+	return resp.Body, nil
+}
 
-	c.Header("Content-Type", contentType)
-	c.Header("Cache-Control", fmt.Sprintf("max-age=%.0f", cacheDuration.Seconds()))
-	c.Status(http.StatusOK)
-	io.Copy(c.Writer, resp.Body)
+func (s *R2Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
 }
 
-func DeleteFromR2(bucket string, fileURL string) error {
-	parsed, err := url.Parse(fileURL)
+func (s *R2Storage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	resp, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
 	if err != nil {
-		return fmt.Errorf("invalid URL: %w", err)
+		return ObjectInfo{}, mapR2Error(err)
 	}
 
-	r2BaseURL := strings.TrimSuffix(GetEnvOrPanic("R2_PUBLIC_URL"), "/")
+	info := ObjectInfo{
+		Key:         key,
+		Size:        aws.ToInt64(resp.ContentLength),
+		ContentType: aws.ToString(resp.ContentType),
+	}
+	if resp.LastModified != nil {
+		info.LastModified = *resp.LastModified
+	}
+
+	return info, nil
+}
 
-	// Pastikan URL berasal dari R2 bucket yang kita pakai
-	if !strings.HasPrefix(fileURL, r2BaseURL) {
-		return fmt.Errorf("URL does not match R2 base URL")
+func (s *R2Storage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list R2 objects: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			info := ObjectInfo{
+				Key:  aws.ToString(obj.Key),
+				Size: aws.ToInt64(obj.Size),
+			}
+			if obj.LastModified != nil {
+				info.LastModified = *obj.LastModified
+			}
+			objects = append(objects, info)
+		}
 	}
 
-	// Ambil path setelah base URL → jadi nama objeknya (bisa termasuk folder)
-	objectKey := strings.TrimPrefix(parsed.Path, "/")
+	return objects, nil
+}
 
-	// Pastikan tidak kosong
-	if objectKey == "" {
-		return fmt.Errorf("empty object key")
+func (s *R2Storage) PresignPut(ctx context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	req, err := s3.NewPresignClient(s.client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload: %w", err)
 	}
+	return req.URL, nil
+}
 
-	// Hapus dari R2
-	_, err = R2Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
-		Bucket: aws.String(GetEnvOrPanic("R2_BUCKET_NAME")),
-		Key:    aws.String(objectKey),
-	})
+func (s *R2Storage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	req, err := s3.NewPresignClient(s.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
 	if err != nil {
-		return fmt.Errorf("failed to delete R2 object: %w", err)
+		return "", fmt.Errorf("failed to presign download: %w", err)
 	}
+	return req.URL, nil
+}
+
+func (s *R2Storage) PublicURL(key string) string {
+	return fmt.Sprintf("%s/%s", s.publicURL, key)
+}
 
-	return nil
+func mapR2Error(err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return ErrObjectNotFound
+	}
+	return err
 }
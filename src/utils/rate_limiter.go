@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter membatasi jumlah aksi per key dalam satu window waktu (in-memory)
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	hits      map[string][]time.Time
+	lastSweep time.Time
+}
+
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow mencatat satu hit untuk key dan mengembalikan false jika sudah melewati limit
+func (r *RateLimiter) Allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-r.window)
+	r.sweep(now, cutoff)
+
+	recent := r.hits[key][:0]
+	for _, t := range r.hits[key] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= r.limit {
+		r.hits[key] = recent
+		return false
+	}
+
+	r.hits[key] = append(recent, now)
+	return true
+}
+
+// sweep menghapus key yang semua hit-nya sudah kedaluwarsa (paling sering sekali per window),
+// supaya key dari input penyerang (email, IP palsu) tidak menumpuk di memory
+func (r *RateLimiter) sweep(now time.Time, cutoff time.Time) {
+	if now.Sub(r.lastSweep) < r.window {
+		return
+	}
+	r.lastSweep = now
+
+	for key, hits := range r.hits {
+		if len(hits) == 0 || !hits[len(hits)-1].After(cutoff) {
+			delete(r.hits, key)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RevalidationEvent dikirim ke frontend saat konten berubah visibilitas
+type RevalidationEvent struct {
+	EntityType string    `json:"entity_type"`
+	UUID       string    `json:"uuid"`
+	Slug       string    `json:"slug"`
+	Transition string    `json:"transition"` // published | unpublished
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Revalidator adalah abstraksi pengiriman event revalidation
+type Revalidator interface {
+	Revalidate(ctx context.Context, event RevalidationEvent) error
+}
+
+var Revalidate Revalidator
+
+// InitRevalidator memilih implementasi berdasarkan REVALIDATE_DRIVER (webhook | log)
+func InitRevalidator() {
+	driver := GetEnvOrDefault("REVALIDATE_DRIVER", "log")
+
+	switch driver {
+	case "webhook":
+		Revalidate = &WebhookRevalidator{
+			URL:    GetEnvOrPanic("REVALIDATE_WEBHOOK_URL"),
+			Secret: GetEnvOrDefault("REVALIDATE_WEBHOOK_SECRET", ""),
+			Client: &http.Client{Timeout: 10 * time.Second},
+		}
+		log.Println("✅ Webhook revalidator initialized")
+	case "log":
+		Revalidate = &LogRevalidator{}
+		log.Println("✅ Log revalidator initialized")
+	default:
+		log.Fatalf("❌ Unknown REVALIDATE_DRIVER: %s", driver)
+	}
+}
+
+// WebhookRevalidator POST event sebagai JSON, mis. ke endpoint revalidate frontend
+type WebhookRevalidator struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (r *WebhookRevalidator) Revalidate(ctx context.Context, event RevalidationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.Secret != "" {
+		req.Header.Set("X-Revalidate-Secret", r.Secret)
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send revalidation: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("revalidation webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// LogRevalidator hanya menulis event ke log, dipakai saat development
+type LogRevalidator struct{}
+
+func (r *LogRevalidator) Revalidate(ctx context.Context, event RevalidationEvent) error {
+	log.Printf("🔄 Revalidate %s %s (%s): %s\n", event.EntityType, event.UUID, event.Slug, event.Transition)
+	return nil
+}
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrObjectNotFound dikembalikan oleh Storage ketika key tidak ada
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo berisi metadata sebuah objek di storage
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Storage adalah abstraksi object storage (R2/S3, local disk, dll)
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	PublicURL(key string) string
+}
+
+// PresignStorage diimplementasikan storage yang bisa menerima upload langsung
+// dari client (browser PUT ke URL bertanda tangan) tanpa lewat server kita
+type PresignStorage interface {
+	PresignPut(ctx context.Context, key string, contentType string, expires time.Duration) (string, error)
+}
+
+// RangeStorage bisa membaca sebagian objek (dipakai untuk resume download)
+type RangeStorage interface {
+	GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error)
+}
+
+var Store Storage
+
+// InitStorage memilih backend storage berdasarkan STORAGE_DRIVER (r2 | local)
+func InitStorage() {
+	driver := GetEnvOrDefault("STORAGE_DRIVER", "r2")
+
+	switch driver {
+	case "r2", "s3":
+		InitR2()
+		Store = NewR2Storage(R2Client, R2BucketName, R2PublicURL)
+	case "local":
+		store, err := NewLocalStorage(
+			GetEnvOrDefault("LOCAL_STORAGE_DIR", "./storage"),
+			GetEnvOrDefault("LOCAL_STORAGE_PUBLIC_URL", "http://localhost:"+GetEnvOrDefault("PORT", "8080")+"/storage"),
+			GetEnvOrDefault("LOCAL_STORAGE_SIGNING_KEY", os.Getenv("JWT_SECRET")),
+		)
+		if err != nil {
+			log.Fatalf("❌ Failed to init local storage: %v", err)
+		}
+		Store = store
+		log.Println("✅ Local storage initialized")
+	default:
+		log.Fatalf("❌ Unknown STORAGE_DRIVER: %s", driver)
+	}
+
+	initPrivateStorage(driver)
+}
+
+// GetUploadMaxSize membaca UPLOAD_MAX_SIZE_MB (default 50MB) dalam bytes
+func GetUploadMaxSize() int64 {
+	sizeMB, err := strconv.ParseInt(GetEnvOrDefault("UPLOAD_MAX_SIZE_MB", "50"), 10, 64)
+	if err != nil || sizeMB <= 0 {
+		sizeMB = 50
+	}
+	return sizeMB << 20
+}
+
+// BuildObjectKey membuat key unik untuk file upload di bawah prefix tertentu
+func BuildObjectKey(prefix string, filename string) string {
+	cleanFilename := strings.ReplaceAll(filename, " ", "-")
+	timestamp := time.Now().Format("20060102-150405")
+	uniqueSuffix := time.Now().UnixNano()
+	name := fmt.Sprintf("%s_%d_%s", timestamp, uniqueSuffix, cleanFilename)
+
+	if prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", strings.Trim(prefix, "/"), name)
+}
+
+var objectKeyPrefixPattern = regexp.MustCompile(`^\d{8}-\d{6}_\d+_`)
+
+// OriginalFilename mengembalikan nama file asli dari key buatan BuildObjectKey
+func OriginalFilename(key string) string {
+	return objectKeyPrefixPattern.ReplaceAllString(path.Base(key), "")
+}
+
+// UploadFile mengunggah file multipart ke storage dan mengembalikan public URL
+func UploadFile(file multipart.File, fileHeader *multipart.FileHeader, prefix string) (string, error) {
+	defer file.Close()
+
+	objectName := BuildObjectKey(prefix, fileHeader.Filename)
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	if err := Store.Put(context.TODO(), objectName, file, fileHeader.Size, contentType); err != nil {
+		return "", err
+	}
+
+	return Store.PublicURL(objectName), nil
+}
+
+// ObjectKeyFromURL mengubah public URL menjadi object key milik storage aktif
+func ObjectKeyFromURL(fileURL string) (string, error) {
+	fileURL = strings.Trim(fileURL, `"`)
+	baseURL := strings.TrimSuffix(Store.PublicURL(""), "/")
+
+	// Pastikan URL berasal dari storage yang kita pakai
+	if !strings.HasPrefix(fileURL, baseURL+"/") {
+		return "", fmt.Errorf("URL does not match storage base URL")
+	}
+
+	objectKey, err := url.PathUnescape(strings.TrimPrefix(fileURL, baseURL+"/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if objectKey == "" {
+		return "", fmt.Errorf("empty object key")
+	}
+
+	return objectKey, nil
+}
+
+// DeleteFile menghapus objek berdasarkan public URL-nya
+func DeleteFile(fileURL string) error {
+	objectKey, err := ObjectKeyFromURL(fileURL)
+	if err != nil {
+		return err
+	}
+
+	if err := Store.Delete(context.TODO(), objectKey); err != nil {
+		return fmt.Errorf("failed to delete storage object: %w", err)
+	}
+
+	return nil
+}
+
+// StreamFromStorage mengirim isi objek langsung ke response
+func StreamFromStorage(c *gin.Context, key string, cacheDuration time.Duration) {
+	StreamObject(c, Store, key, "max-age="+fmt.Sprintf("%.0f", cacheDuration.Seconds()))
+}
+
+// StreamObject mengirim isi objek dari storage tertentu dengan header Cache-Control yang diberikan
+func StreamObject(c *gin.Context, store Storage, key string, cacheControl string) {
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "filename is required"})
+		return
+	}
+
+	body, info, err := store.Get(c.Request.Context(), key)
+	if errors.Is(err, ErrObjectNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch file from storage"})
+		return
+	}
+	defer body.Close()
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", cacheControl)
+	if info.Size > 0 {
+		c.Header("Content-Length", fmt.Sprintf("%d", info.Size))
+	}
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, body)
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	WatermarkTopLeft     = "top-left"
+	WatermarkTopRight    = "top-right"
+	WatermarkBottomLeft  = "bottom-left"
+	WatermarkBottomRight = "bottom-right"
+	WatermarkCenter      = "center"
+)
+
+// ApplyWatermark menempel mark di atas img. scale = lebar mark relatif terhadap lebar foto (0-1),
+// opacity 0-1. Jarak dari tepi 3% sisi terpendek foto.
+func ApplyWatermark(img image.Image, mark image.Image, position string, opacity float64, scale float64) image.Image {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+
+	markBounds := mark.Bounds()
+	if markBounds.Dx() == 0 || markBounds.Dy() == 0 || opacity <= 0 || scale <= 0 {
+		return dst
+	}
+
+	width := int(float64(bounds.Dx()) * scale)
+	height := markBounds.Dy() * width / markBounds.Dx()
+	// Mark yang tinggi (logo vertikal) dibatasi supaya tidak lebih tinggi dari foto
+	if maxHeight := int(float64(bounds.Dy()) * scale); height > maxHeight {
+		height = maxHeight
+		width = markBounds.Dx() * height / markBounds.Dy()
+	}
+	if width < 1 || height < 1 {
+		return dst
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), mark, markBounds, xdraw.Src, nil)
+
+	margin := min(bounds.Dx(), bounds.Dy()) * 3 / 100
+	x, y := watermarkOrigin(position, dst.Bounds().Size(), image.Pt(width, height), margin)
+
+	alpha := uint8(min(opacity, 1) * 255)
+	draw.DrawMask(dst, image.Rect(x, y, x+width, y+height), scaled, image.Point{}, image.NewUniform(color.Alpha{A: alpha}), image.Point{}, draw.Over)
+	return dst
+}
+
+func watermarkOrigin(position string, size image.Point, mark image.Point, margin int) (int, int) {
+	left, top := margin, margin
+	right, bottom := size.X-mark.X-margin, size.Y-mark.Y-margin
+
+	switch position {
+	case WatermarkTopLeft:
+		return left, top
+	case WatermarkTopRight:
+		return right, top
+	case WatermarkBottomLeft:
+		return left, bottom
+	case WatermarkCenter:
+		return (size.X - mark.X) / 2, (size.Y - mark.Y) / 2
+	default:
+		return right, bottom
+	}
+}
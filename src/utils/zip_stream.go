@@ -0,0 +1,337 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ZipEntry satu file di ZIP. Size dan CRC32 harus sudah diketahui sebelum stream dibuat.
+type ZipEntry struct {
+	Name     string
+	Key      string
+	Size     int64
+	CRC32    uint32
+	Modified time.Time
+}
+
+const (
+	zipVersion20     = 20
+	zipVersion45     = 45
+	zipFlagUTF8      = 0x0800
+	zipMethodStore   = 0
+	zipMax16         = 0xFFFF
+	zipMax32         = 0xFFFFFFFF
+	zipExtraZip64    = 0x0001
+	zipLocalHeader   = 0x04034b50
+	zipCentralHeader = 0x02014b50
+	zipEnd           = 0x06054b50
+	zipEnd64         = 0x06064b50
+	zipEnd64Locator  = 0x07064b50
+)
+
+// zipSegment potongan ZIP: header yang sudah jadi (data) atau isi objek dari storage (key)
+type zipSegment struct {
+	start  int64
+	length int64
+	data   []byte
+	key    string
+}
+
+// ZipStream ZIP tanpa kompresi (store) yang dibaca langsung dari storage.
+// Semua header dihitung di awal sehingga ukuran total diketahui dan bisa di-Seek,
+// jadi http.ServeContent bisa melayani Range untuk resume download.
+// Foto sudah terkompresi (JPEG/WebP), deflate hampir tidak mengecilkan ukuran
+// dan membuat offset tiap file tidak bisa dihitung dari awal.
+type ZipStream struct {
+	ctx      context.Context
+	store    Storage
+	segments []zipSegment
+	size     int64
+	offset   int64
+
+	reader    io.ReadCloser
+	readerPos int64
+}
+
+func NewZipStream(ctx context.Context, store Storage, entries []ZipEntry) (*ZipStream, error) {
+	z := &ZipStream{ctx: ctx, store: store}
+
+	var central []byte
+	for _, entry := range entries {
+		if entry.Size < 0 {
+			return nil, fmt.Errorf("invalid size for %s", entry.Name)
+		}
+
+		headerOffset := z.size
+		local := zipLocalFileHeader(entry)
+		z.addData(local)
+		if entry.Size > 0 {
+			z.segments = append(z.segments, zipSegment{start: z.size, length: entry.Size, key: entry.Key})
+			z.size += entry.Size
+		}
+		central = append(central, zipCentralFileHeader(entry, headerOffset)...)
+	}
+
+	centralOffset := z.size
+	z.addData(central)
+	z.addData(zipEndRecords(len(entries), int64(len(central)), centralOffset))
+	return z, nil
+}
+
+func (z *ZipStream) addData(data []byte) {
+	z.segments = append(z.segments, zipSegment{start: z.size, length: int64(len(data)), data: data})
+	z.size += int64(len(data))
+}
+
+func (z *ZipStream) Size() int64 {
+	return z.size
+}
+
+func (z *ZipStream) Read(p []byte) (int, error) {
+	if z.offset >= z.size {
+		return 0, io.EOF
+	}
+
+	seg := z.segmentAt(z.offset)
+	pos := z.offset - seg.start
+	remaining := seg.length - pos
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	if seg.data != nil {
+		n := copy(p, seg.data[pos:])
+		z.offset += int64(n)
+		return n, nil
+	}
+
+	// Reader objek dipakai lagi selama pembacaan berurutan, dibuka ulang kalau posisi pindah
+	if z.reader == nil || z.readerPos != z.offset {
+		z.closeReader()
+		reader, err := openObjectRange(z.ctx, z.store, seg.key, pos, remaining)
+		if err != nil {
+			return 0, err
+		}
+		z.reader = reader
+		z.readerPos = z.offset
+	}
+
+	n, err := z.reader.Read(p)
+	z.offset += int64(n)
+	z.readerPos += int64(n)
+	if errors.Is(err, io.EOF) {
+		z.closeReader()
+		if int64(n) < remaining {
+			return n, fmt.Errorf("object %s ended early: %w", seg.key, io.ErrUnexpectedEOF)
+		}
+		err = nil
+	} else if err == nil && int64(n) == remaining {
+		z.closeReader()
+	}
+	return n, err
+}
+
+func (z *ZipStream) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += z.offset
+	case io.SeekEnd:
+		offset += z.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	z.offset = offset
+	return offset, nil
+}
+
+func (z *ZipStream) Close() error {
+	z.closeReader()
+	return nil
+}
+
+func (z *ZipStream) closeReader() {
+	if z.reader != nil {
+		z.reader.Close()
+		z.reader = nil
+	}
+}
+
+func (z *ZipStream) segmentAt(offset int64) zipSegment {
+	lo, hi := 0, len(z.segments)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if z.segments[mid].start <= offset {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return z.segments[lo]
+}
+
+// openObjectRange membaca sebagian objek, storage tanpa GetRange dibaca dari awal lalu dilewati
+func openObjectRange(ctx context.Context, store Storage, key string, offset int64, length int64) (io.ReadCloser, error) {
+	if ranged, ok := store.(RangeStorage); ok {
+		return ranged.GetRange(ctx, key, offset, length)
+	}
+
+	body, _, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+		body.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(body, length), body}, nil
+}
+
+// ZipETag ETag dari daftar file, berubah kalau isi ZIP berubah
+func ZipETag(entries []ZipEntry) string {
+	h := sha256.New()
+	for _, entry := range entries {
+		fmt.Fprintf(h, "%s\x00%s\x00%d\x00%08x\x00%d\n", entry.Name, entry.Key, entry.Size, entry.CRC32, entry.Modified.Unix())
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+func zipDOSTime(t time.Time) (uint16, uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	date := uint16((t.Year()-1980)<<9 | int(t.Month())<<5 | t.Day())
+	clock := uint16(t.Hour()<<11 | t.Minute()<<5 | t.Second()/2)
+	return clock, date
+}
+
+func zipNeeds64(entry ZipEntry) bool {
+	return entry.Size >= zipMax32
+}
+
+func zipLocalFileHeader(entry ZipEntry) []byte {
+	clock, date := zipDOSTime(entry.Modified)
+	zip64 := zipNeeds64(entry)
+
+	var extra []byte
+	version := uint16(zipVersion20)
+	size32 := uint32(entry.Size)
+	if zip64 {
+		version = zipVersion45
+		size32 = zipMax32
+		extra = binary.LittleEndian.AppendUint16(extra, zipExtraZip64)
+		extra = binary.LittleEndian.AppendUint16(extra, 16)
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(entry.Size))
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(entry.Size))
+	}
+
+	b := make([]byte, 0, 30+len(entry.Name)+len(extra))
+	b = binary.LittleEndian.AppendUint32(b, zipLocalHeader)
+	b = binary.LittleEndian.AppendUint16(b, version)
+	b = binary.LittleEndian.AppendUint16(b, zipFlagUTF8)
+	b = binary.LittleEndian.AppendUint16(b, zipMethodStore)
+	b = binary.LittleEndian.AppendUint16(b, clock)
+	b = binary.LittleEndian.AppendUint16(b, date)
+	b = binary.LittleEndian.AppendUint32(b, entry.CRC32)
+	b = binary.LittleEndian.AppendUint32(b, size32)
+	b = binary.LittleEndian.AppendUint32(b, size32)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(entry.Name)))
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(extra)))
+	b = append(b, entry.Name...)
+	return append(b, extra...)
+}
+
+func zipCentralFileHeader(entry ZipEntry, headerOffset int64) []byte {
+	clock, date := zipDOSTime(entry.Modified)
+
+	// Extra zip64 hanya berisi field yang tidak muat 32 bit, urutannya sesuai spesifikasi
+	var extra []byte
+	size32 := uint32(entry.Size)
+	offset32 := uint32(headerOffset)
+	if zipNeeds64(entry) {
+		size32 = zipMax32
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(entry.Size))
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(entry.Size))
+	}
+	if headerOffset >= zipMax32 {
+		offset32 = zipMax32
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(headerOffset))
+	}
+	version := uint16(zipVersion20)
+	if len(extra) > 0 {
+		version = zipVersion45
+		header := binary.LittleEndian.AppendUint16(nil, zipExtraZip64)
+		header = binary.LittleEndian.AppendUint16(header, uint16(len(extra)))
+		extra = append(header, extra...)
+	}
+
+	b := make([]byte, 0, 46+len(entry.Name)+len(extra))
+	b = binary.LittleEndian.AppendUint32(b, zipCentralHeader)
+	b = binary.LittleEndian.AppendUint16(b, zipVersion45)
+	b = binary.LittleEndian.AppendUint16(b, version)
+	b = binary.LittleEndian.AppendUint16(b, zipFlagUTF8)
+	b = binary.LittleEndian.AppendUint16(b, zipMethodStore)
+	b = binary.LittleEndian.AppendUint16(b, clock)
+	b = binary.LittleEndian.AppendUint16(b, date)
+	b = binary.LittleEndian.AppendUint32(b, entry.CRC32)
+	b = binary.LittleEndian.AppendUint32(b, size32)
+	b = binary.LittleEndian.AppendUint32(b, size32)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(entry.Name)))
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(extra)))
+	b = binary.LittleEndian.AppendUint16(b, 0) // comment
+	b = binary.LittleEndian.AppendUint16(b, 0) // disk
+	b = binary.LittleEndian.AppendUint16(b, 0) // internal attr
+	b = binary.LittleEndian.AppendUint32(b, 0) // external attr
+	b = binary.LittleEndian.AppendUint32(b, offset32)
+	b = append(b, entry.Name...)
+	return append(b, extra...)
+}
+
+func zipEndRecords(count int, centralSize int64, centralOffset int64) []byte {
+	var b []byte
+
+	count16 := uint16(count)
+	size32 := uint32(centralSize)
+	offset32 := uint32(centralOffset)
+	if count >= zipMax16 || centralSize >= zipMax32 || centralOffset >= zipMax32 {
+		count16, size32, offset32 = zipMax16, zipMax32, zipMax32
+		end64Offset := centralOffset + centralSize
+
+		b = binary.LittleEndian.AppendUint32(b, zipEnd64)
+		b = binary.LittleEndian.AppendUint64(b, 44)
+		b = binary.LittleEndian.AppendUint16(b, zipVersion45)
+		b = binary.LittleEndian.AppendUint16(b, zipVersion45)
+		b = binary.LittleEndian.AppendUint32(b, 0)
+		b = binary.LittleEndian.AppendUint32(b, 0)
+		b = binary.LittleEndian.AppendUint64(b, uint64(count))
+		b = binary.LittleEndian.AppendUint64(b, uint64(count))
+		b = binary.LittleEndian.AppendUint64(b, uint64(centralSize))
+		b = binary.LittleEndian.AppendUint64(b, uint64(centralOffset))
+
+		b = binary.LittleEndian.AppendUint32(b, zipEnd64Locator)
+		b = binary.LittleEndian.AppendUint32(b, 0)
+		b = binary.LittleEndian.AppendUint64(b, uint64(end64Offset))
+		b = binary.LittleEndian.AppendUint32(b, 1)
+	}
+
+	b = binary.LittleEndian.AppendUint32(b, zipEnd)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint16(b, count16)
+	b = binary.LittleEndian.AppendUint16(b, count16)
+	b = binary.LittleEndian.AppendUint32(b, size32)
+	b = binary.LittleEndian.AppendUint32(b, offset32)
+	return binary.LittleEndian.AppendUint16(b, 0)
+}
@@ -0,0 +1,395 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+	"time"
+)
+
+// memStorage Storage di memory. Objek di sized tidak disimpan, isinya pola patternByte sepanjang ukurannya.
+type memStorage struct {
+	objects map[string][]byte
+	sized   map[string]int64
+}
+
+func (s *memStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	if data, ok := s.objects[key]; ok {
+		return io.NopCloser(bytes.NewReader(data)), ObjectInfo{Key: key, Size: int64(len(data))}, nil
+	}
+	if size, ok := s.sized[key]; ok {
+		return io.NopCloser(&patternReader{size: size}), ObjectInfo{Key: key, Size: size}, nil
+	}
+	return nil, ObjectInfo{}, ErrObjectNotFound
+}
+
+func (s *memStorage) Delete(ctx context.Context, key string) error {
+	delete(s.objects, key)
+	delete(s.sized, key)
+	return nil
+}
+
+func (s *memStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	_, info, err := s.Get(ctx, key)
+	return info, err
+}
+
+func (s *memStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	return nil, nil
+}
+
+func (s *memStorage) PublicURL(key string) string {
+	return key
+}
+
+// rangeMemStorage memStorage yang juga mengimplementasikan RangeStorage
+type rangeMemStorage struct {
+	*memStorage
+}
+
+func (s rangeMemStorage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	if data, ok := s.objects[key]; ok {
+		return io.NopCloser(io.NewSectionReader(bytes.NewReader(data), offset, length)), nil
+	}
+	if size, ok := s.sized[key]; ok {
+		return io.NopCloser(&patternReader{pos: offset, size: min(size, offset+length)}), nil
+	}
+	return nil, ErrObjectNotFound
+}
+
+func patternByte(pos int64) byte {
+	return byte(pos % 251)
+}
+
+type patternReader struct {
+	pos  int64
+	size int64
+}
+
+func (r *patternReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.size-r.pos {
+		p = p[:r.size-r.pos]
+	}
+	for i := range p {
+		p[i] = patternByte(r.pos + int64(i))
+	}
+	r.pos += int64(len(p))
+	return len(p), nil
+}
+
+// streamReaderAt ReaderAt di atas ZipStream untuk archive/zip
+type streamReaderAt struct {
+	stream *ZipStream
+}
+
+func (r streamReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := r.stream.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.ReadFull(r.stream, p)
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{objects: map[string][]byte{}, sized: map[string]int64{}}
+}
+
+func putEntry(t *testing.T, store *memStorage, name string, data []byte) ZipEntry {
+	t.Helper()
+	key := "albums/" + name
+	if err := store.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	return ZipEntry{
+		Name:     "album/" + name,
+		Key:      key,
+		Size:     int64(len(data)),
+		CRC32:    crc32.ChecksumIEEE(data),
+		Modified: time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC),
+	}
+}
+
+func sampleData(size int, seed byte) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i*31) + seed
+	}
+	return data
+}
+
+func checkArchive(t *testing.T, stream *ZipStream, want map[string][]byte) {
+	t.Helper()
+
+	reader, err := zip.NewReader(streamReaderAt{stream}, stream.Size())
+	if err != nil {
+		t.Fatalf("archive/zip cannot open stream: %v", err)
+	}
+	if len(reader.File) != len(want) {
+		t.Fatalf("got %d files, want %d", len(reader.File), len(want))
+	}
+
+	for _, file := range reader.File {
+		data, ok := want[file.Name]
+		if !ok {
+			t.Fatalf("unexpected file %s", file.Name)
+		}
+		if file.Method != zip.Store {
+			t.Errorf("%s: method %d, want store", file.Name, file.Method)
+		}
+		if file.UncompressedSize64 != uint64(len(data)) {
+			t.Errorf("%s: size %d, want %d", file.Name, file.UncompressedSize64, len(data))
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("%s: open: %v", file.Name, err)
+		}
+		got, err := io.ReadAll(rc) // archive/zip juga mengecek CRC32
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: read: %v", file.Name, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("%s: content mismatch", file.Name)
+		}
+	}
+}
+
+func TestZipStreamReadableByArchiveZip(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		store func(*memStorage) Storage
+	}{
+		{"get", func(s *memStorage) Storage { return s }},
+		{"get range", func(s *memStorage) Storage { return rangeMemStorage{s} }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := newMemStorage()
+			files := map[string][]byte{
+				"001-first.jpg":  sampleData(70000, 1),
+				"002-empty.jpg":  {},
+				"003-small.png":  sampleData(13, 2),
+				"004-foto é.jpg": sampleData(4096, 3),
+				"005-empty.jpg":  {},
+			}
+			var entries []ZipEntry
+			want := map[string][]byte{}
+			for _, name := range []string{"001-first.jpg", "002-empty.jpg", "003-small.png", "004-foto é.jpg", "005-empty.jpg"} {
+				entry := putEntry(t, mem, name, files[name])
+				entries = append(entries, entry)
+				want[entry.Name] = files[name]
+			}
+
+			stream, err := NewZipStream(context.Background(), tc.store(mem), entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+
+			checkArchive(t, stream, want)
+		})
+	}
+}
+
+func TestZipStreamSeekMatchesFullRead(t *testing.T) {
+	mem := newMemStorage()
+	var entries []ZipEntry
+	for i, size := range []int{5000, 0, 1, 20000, 0, 333} {
+		entries = append(entries, putEntry(t, mem, string(rune('a'+i))+".jpg", sampleData(size, byte(i))))
+	}
+
+	for _, store := range []Storage{mem, rangeMemStorage{mem}} {
+		stream, err := NewZipStream(context.Background(), store, entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		full, err := io.ReadAll(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(full)) != stream.Size() {
+			t.Fatalf("read %d bytes, Size() = %d", len(full), stream.Size())
+		}
+
+		size := stream.Size()
+		ranges := [][2]int64{
+			{0, 1}, {1, size - 1}, {29, 40}, {30, 5000}, {5020, 10}, {size - 22, 22},
+			{size / 2, size / 3}, {size - 1, 1}, {100, 0},
+		}
+		for _, r := range ranges {
+			offset, length := r[0], r[1]
+			pos, err := stream.Seek(offset, io.SeekStart)
+			if err != nil || pos != offset {
+				t.Fatalf("seek %d: pos %d, err %v", offset, pos, err)
+			}
+			got := make([]byte, length)
+			if _, err := io.ReadFull(stream, got); err != nil {
+				t.Fatalf("read %d+%d: %v", offset, length, err)
+			}
+			if !bytes.Equal(got, full[offset:offset+length]) {
+				t.Errorf("range %d+%d differs from full stream", offset, length)
+			}
+		}
+
+		// Seek relatif dan dari akhir
+		if _, err := stream.Seek(-10, io.SeekEnd); err != nil {
+			t.Fatal(err)
+		}
+		tail, err := io.ReadAll(stream)
+		if err != nil || !bytes.Equal(tail, full[size-10:]) {
+			t.Errorf("seek from end: %v", err)
+		}
+		if _, err := stream.Seek(100, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Seek(50, io.SeekCurrent); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, 64)
+		if _, err := io.ReadFull(stream, got); err != nil || !bytes.Equal(got, full[150:214]) {
+			t.Errorf("seek current: %v", err)
+		}
+		if _, err := stream.Seek(-1, io.SeekStart); err == nil {
+			t.Error("negative seek should fail")
+		}
+
+		stream.Close()
+	}
+}
+
+// Ukuran sintetis: isi objek besar tidak pernah dibuat di memory, hanya bagian yang dibaca
+func TestZipStreamZip64(t *testing.T) {
+	mem := newMemStorage()
+	bigSize := int64(zipMax32) + 1000
+	mem.sized["albums/big.jpg"] = bigSize
+
+	modified := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	first := putEntry(t, mem, "001-first.jpg", sampleData(100, 1))
+	big := ZipEntry{Name: "album/002-big.jpg", Key: "albums/big.jpg", Size: bigSize, CRC32: 0, Modified: modified}
+	empty := putEntry(t, mem, "003-empty.jpg", nil)
+	last := putEntry(t, mem, "004-last.jpg", sampleData(500, 4))
+
+	stream, err := NewZipStream(context.Background(), rangeMemStorage{mem}, []ZipEntry{first, big, empty, last})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	if stream.Size() <= bigSize {
+		t.Fatalf("size %d should be larger than the big entry", stream.Size())
+	}
+
+	reader, err := zip.NewReader(streamReaderAt{stream}, stream.Size())
+	if err != nil {
+		t.Fatalf("archive/zip cannot open zip64 stream: %v", err)
+	}
+	if len(reader.File) != 4 {
+		t.Fatalf("got %d files, want 4", len(reader.File))
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	bigFile := files[big.Name]
+	if bigFile == nil || bigFile.UncompressedSize64 != uint64(bigSize) || bigFile.CompressedSize64 != uint64(bigSize) {
+		t.Fatalf("big entry has wrong size: %+v", bigFile)
+	}
+
+	// Entry setelah file besar ada di offset >= 4 GiB, harus terbaca lewat extra zip64
+	for _, entry := range []ZipEntry{empty, last} {
+		file := files[entry.Name]
+		if file == nil {
+			t.Fatalf("missing %s", entry.Name)
+		}
+		offset, err := file.DataOffset()
+		if err != nil {
+			t.Fatalf("%s: data offset: %v", entry.Name, err)
+		}
+		if offset < zipMax32 {
+			t.Fatalf("%s: offset %d should be beyond 4 GiB", entry.Name, offset)
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("%s: open: %v", entry.Name, err)
+		}
+		got, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: read: %v", entry.Name, err)
+		}
+		want, _, _ := mem.Get(context.Background(), entry.Key)
+		wantData, _ := io.ReadAll(want)
+		if !bytes.Equal(got, wantData) {
+			t.Errorf("%s: content mismatch", entry.Name)
+		}
+	}
+
+	// Bagian tengah objek besar dibaca dari offset yang benar
+	dataOffset, err := bigFile.DataOffset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pos := range []int64{0, 1 << 20, zipMax32 - 3, bigSize - 16} {
+		if _, err := stream.Seek(dataOffset+pos, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, 16)
+		if _, err := io.ReadFull(stream, got); err != nil {
+			t.Fatalf("read big entry at %d: %v", pos, err)
+		}
+		for i, b := range got {
+			if b != patternByte(pos+int64(i)) {
+				t.Fatalf("big entry byte %d = %d, want %d", pos+int64(i), b, patternByte(pos+int64(i)))
+			}
+		}
+	}
+}
+
+func TestZipStreamObjectEndsEarly(t *testing.T) {
+	mem := newMemStorage()
+	entry := putEntry(t, mem, "short.jpg", sampleData(10, 1))
+	entry.Size = 20
+
+	stream, err := NewZipStream(context.Background(), mem, []ZipEntry{entry})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	if _, err := io.ReadAll(stream); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("got %v, want unexpected EOF", err)
+	}
+}
+
+func TestZipETagChangesWithContent(t *testing.T) {
+	mem := newMemStorage()
+	entry := putEntry(t, mem, "a.jpg", sampleData(10, 1))
+
+	changed := entry
+	changed.CRC32++
+	if ZipETag([]ZipEntry{entry}) == ZipETag([]ZipEntry{changed}) {
+		t.Error("ETag should change when an entry changes")
+	}
+	if ZipETag([]ZipEntry{entry}) != ZipETag([]ZipEntry{entry}) {
+		t.Error("ETag should be stable")
+	}
+}